@@ -0,0 +1,227 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Account Types
+const (
+	AccountStandard = "standard"
+	AccountExpress  = "express"
+	AccountCustom   = "custom"
+)
+
+// Account Rejection Reasons
+const (
+	RejectFraud          = "fraud"
+	RejectTermsOfService = "terms_of_service"
+	RejectOther          = "other"
+)
+
+// Account represents a Stripe account, either your own or a connected account
+// managed by your platform.
+//
+// see https://stripe.com/docs/api#account_object
+type Account struct {
+	APIResource
+	ID               string               `json:"id"`
+	Type             string               `json:"type"`
+	Email            string               `json:"email,omitempty"`
+	Country          string               `json:"country"`
+	DefaultCurrency  string               `json:"default_currency"`
+	BusinessType     string               `json:"business_type,omitempty"`
+	BusinessProfile  *BusinessProfile     `json:"business_profile,omitempty"`
+	Individual       *Person              `json:"individual,omitempty"`
+	ChargesEnabled   bool                 `json:"charges_enabled"`
+	PayoutsEnabled   bool                 `json:"payouts_enabled"`
+	Capabilities     map[string]string    `json:"capabilities,omitempty"`
+	DetailsSubmitted bool                 `json:"details_submitted"`
+	ExternalAccounts *ExternalAccountList `json:"external_accounts,omitempty"`
+	Requirements     *AccountRequirements `json:"requirements,omitempty"`
+	TOSAcceptance    *TOSAcceptance       `json:"tos_acceptance,omitempty"`
+	Created          UnixTime             `json:"created"`
+	Metadata         map[string]string    `json:"metadata,omitempty"`
+}
+
+// BusinessProfile holds the publicly visible details about the business
+// behind an Account.
+type BusinessProfile struct {
+	Name               string `json:"name,omitempty" form:"name"`
+	URL                string `json:"url,omitempty" form:"url"`
+	MCC                string `json:"mcc,omitempty" form:"mcc"`
+	ProductDescription string `json:"product_description,omitempty" form:"product_description"`
+	SupportEmail       string `json:"support_email,omitempty" form:"support_email"`
+	SupportPhone       string `json:"support_phone,omitempty" form:"support_phone"`
+}
+
+// TOSAcceptance records when and from where the Account holder accepted the
+// Stripe Services Agreement.
+type TOSAcceptance struct {
+	Date      *UnixTime `json:"date,omitempty" form:"date"`
+	IP        string    `json:"ip,omitempty" form:"ip"`
+	UserAgent string    `json:"user_agent,omitempty" form:"user_agent"`
+}
+
+type ExternalAccountList struct {
+	ListObject
+	Data []*ExternalAccount `json:"data"`
+}
+
+// CapabilityParams encapsulates options for requesting a capability, ie
+// card_payments or transfers, of a connected Account.
+type CapabilityParams struct {
+	// Whether the capability is requested. Passing false removes a capability
+	// that was requested before.
+	Requested *bool `form:"requested"`
+}
+
+// AccountParams encapsulates options for creating or updating connected
+// Accounts.
+type AccountParams struct {
+	// The type of account to create, one of standard, express or custom. Only
+	// used when creating an Account.
+	Type string `form:"type"`
+
+	// (Optional) The country of the account holder. Default is the country of
+	// your platform. Only used when creating an Account.
+	Country string `form:"country"`
+
+	// (Optional) The email address of the account holder.
+	Email string `form:"email"`
+
+	// (Optional) Three-letter ISO currency code representing the default
+	// currency for the account.
+	DefaultCurrency string `form:"default_currency"`
+
+	// (Optional) The business type. Either individual or company.
+	BusinessType string `form:"business_type"`
+
+	// (Optional) Publicly visible details about the business.
+	BusinessProfile *BusinessProfile `form:"business_profile"`
+
+	// (Optional) A bank account or debit card Token to replace the account's
+	// External Accounts with.
+	ExternalAccount string `form:"external_account"`
+
+	// (Optional) Details on the account holder's acceptance of the Stripe
+	// Services Agreement.
+	TOSAcceptance *TOSAcceptance `form:"tos_acceptance"`
+
+	// (Optional) The capabilities to request, keyed by name, ie
+	// card_payments or transfers.
+	Capabilities map[string]*CapabilityParams `form:"capabilities"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// AccountClient encapsulates operations for creating, querying, updating,
+// verifying, rejecting and deleting connected accounts using the Stripe REST
+// API.
+type AccountClient struct{ api }
+
+// Creates a new connected Account managed by your platform.
+//
+// see https://stripe.com/docs/api#create_account
+func (c AccountClient) Create(ctx context.Context, params *AccountParams) (*Account, error) {
+	res := &Account{}
+	return res, c.query(ctx, "POST", "/accounts", formValues(params), res)
+}
+
+// Retrieves your own Account, ie the account of the API key.
+//
+// see https://stripe.com/docs/api#retrieve_account
+func (c AccountClient) Current(ctx context.Context) (*Account, error) {
+	res := &Account{}
+	return res, c.query(ctx, "GET", "/account", nil, res)
+}
+
+// Retrieves the connected Account with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_account
+func (c AccountClient) Get(ctx context.Context, id string) (*Account, error) {
+	res := &Account{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Account into v, which may be any
+// type with matching JSON fields.
+func (c AccountClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/accounts/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the connected Account with the given ID.
+//
+// see https://stripe.com/docs/api#update_account
+func (c AccountClient) Update(ctx context.Context, id string, params *AccountParams) (*Account, error) {
+	res := &Account{}
+	return res, c.query(ctx, "POST", "/accounts/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// Reject flags the connected Account with the given ID as suspicious. The
+// reason must be one of fraud, terms_of_service or other. Only custom
+// accounts with a zero balance can be rejected.
+//
+// see https://stripe.com/docs/api#reject_account
+func (c AccountClient) Reject(ctx context.Context, id, reason string) (*Account, error) {
+	values := url.Values{"reason": {reason}}
+	res := &Account{}
+	path := fmt.Sprintf("/accounts/%s/reject", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, values, res)
+}
+
+// Deletes the connected Account with the given ID. Test-mode accounts can be
+// deleted at any time; live-mode custom and express accounts can be deleted
+// once all balances are zero.
+//
+// see https://stripe.com/docs/api#delete_account
+func (c AccountClient) Delete(ctx context.Context, id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := c.query(ctx, "DELETE", "/accounts/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of the Accounts connected to your platform.
+//
+// see https://stripe.com/docs/api#list_accounts
+func (c AccountClient) List(ctx context.Context, limit int, before, after string) ([]*Account, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Account
+	}{}
+	err := c.query(ctx, "GET", "/accounts", listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of connected Accounts, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_accounts
+func (c AccountClient) ListFiltered(ctx context.Context, params *ListParams) (*List[Account], error) {
+	res := &List[Account]{}
+	return res, c.query(ctx, "GET", "/accounts", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Account connected to your platform,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c AccountClient) ListAll(ctx context.Context, params *ListParams) *Iter[Account] {
+	return newIter(ctx, c.backend(), "/accounts", params, func(a *Account) string { return a.ID })
+}
+
+// UploadDocument uploads the images of the given identity document with the
+// identity_document purpose, and attaches the resulting File IDs to the
+// verification of the individual on the Account with the given ID. This is
+// only possible for accounts with the individual business type; use
+// Persons.UploadDocument for the persons of a company.
+func (c AccountClient) UploadDocument(ctx context.Context, id string, doc *IdentityDocument) (*Account, error) {
+	values, err := c.uploadIdentityDocument(ctx, "individual[verification]", doc)
+	if err != nil {
+		return nil, err
+	}
+	res := &Account{}
+	return res, c.query(ctx, "POST", "/accounts/"+url.QueryEscape(id), values, res)
+}
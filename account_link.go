@@ -0,0 +1,43 @@
+package stripe
+
+import "context"
+
+// Account Link Types
+const (
+	AccountLinkOnboarding = "account_onboarding"
+	AccountLinkUpdate     = "account_update"
+)
+
+// AccountLink represents a single-use URL to the hosted flow where the holder
+// of a connected Account provides the details Stripe requires.
+//
+// see https://stripe.com/docs/api#account_link_object
+type AccountLink struct {
+	APIResource
+	URL       string   `json:"url"`
+	Created   UnixTime `json:"created"`
+	ExpiresAt UnixTime `json:"expires_at"`
+}
+
+// AccountLinkClient encapsulates operations for creating account links using
+// the Stripe REST API.
+type AccountLinkClient struct{ api }
+
+// Creates a new Account Link for the connected Account with the given ID. The
+// link type must be one of account_onboarding or account_update. The holder
+// is sent to refreshURL if the link expires or was already visited, and to
+// returnURL once they leave the flow, which does not mean that every
+// requirement was provided.
+//
+// see https://stripe.com/docs/api#create_account_link
+func (c AccountLinkClient) Create(ctx context.Context, account, refreshURL, returnURL, linkType string) (*AccountLink, error) {
+	values := formValues(struct {
+		Account    string `form:"account"`
+		RefreshURL string `form:"refresh_url"`
+		ReturnURL  string `form:"return_url"`
+		Type       string `form:"type"`
+	}{account, refreshURL, returnURL, linkType})
+
+	res := &AccountLink{}
+	return res, c.query(ctx, "POST", "/account_links", values, res)
+}
@@ -0,0 +1,138 @@
+package stripe
+
+import (
+	"context"
+	"sort"
+)
+
+// AccountRequirements lists the information a connected Account still needs
+// to provide, and by when, to keep charges and payouts enabled. Each list
+// holds requirement names such as "individual.dob.day" or
+// "external_account".
+//
+// see https://stripe.com/docs/api#account_object-requirements
+type AccountRequirements struct {
+	CurrentDeadline     *UnixTime           `json:"current_deadline,omitempty"`
+	CurrentlyDue        []string            `json:"currently_due"`
+	EventuallyDue       []string            `json:"eventually_due"`
+	PastDue             []string            `json:"past_due"`
+	PendingVerification []string            `json:"pending_verification"`
+	DisabledReason      string              `json:"disabled_reason,omitempty"`
+	Errors              []*RequirementError `json:"errors,omitempty"`
+}
+
+// RequirementError describes why information provided for a requirement was
+// rejected, and must be provided again.
+type RequirementError struct {
+	Code        string `json:"code"`
+	Reason      string `json:"reason"`
+	Requirement string `json:"requirement"`
+}
+
+// RequirementsDiff describes how an Account's requirements changed between
+// two snapshots.
+type RequirementsDiff struct {
+	// Requirements that became currently due.
+	NewlyDue []string
+
+	// Requirements that became past due.
+	NewlyPastDue []string
+
+	// Requirements that were due before, and no longer appear in any of the
+	// due or pending lists.
+	Resolved []string
+
+	// Whether the current deadline was set, cleared or moved.
+	DeadlineChanged bool
+
+	// Whether the Account was disabled, re-enabled or disabled for a
+	// different reason.
+	DisabledReasonChanged bool
+}
+
+// Changed reports whether any requirements changed.
+func (d *RequirementsDiff) Changed() bool {
+	return len(d.NewlyDue) != 0 || len(d.NewlyPastDue) != 0 || len(d.Resolved) != 0 ||
+		d.DeadlineChanged || d.DisabledReasonChanged
+}
+
+// Requirements fetches the Account with the given ID and returns a
+// normalized copy of its requirements, along with how they differ from the
+// previous snapshot. The prev snapshot may be nil for an Account seen for the
+// first time, in which case everything that is due is reported as newly due.
+func (c AccountClient) Requirements(ctx context.Context, id string, prev *AccountRequirements) (*AccountRequirements, *RequirementsDiff, error) {
+	acct, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	cur := normalizeRequirements(acct.Requirements)
+	return cur, DiffRequirements(prev, cur), nil
+}
+
+// DiffRequirements compares two requirements snapshots, either of which may
+// be nil.
+func DiffRequirements(prev, cur *AccountRequirements) *RequirementsDiff {
+	prev, cur = normalizeRequirements(prev), normalizeRequirements(cur)
+	diff := &RequirementsDiff{
+		NewlyDue:              difference(cur.CurrentlyDue, prev.CurrentlyDue),
+		NewlyPastDue:          difference(cur.PastDue, prev.PastDue),
+		DisabledReasonChanged: cur.DisabledReason != prev.DisabledReason,
+	}
+
+	outstanding := union(cur.CurrentlyDue, cur.EventuallyDue, cur.PastDue, cur.PendingVerification)
+	diff.Resolved = difference(union(prev.CurrentlyDue, prev.EventuallyDue, prev.PastDue), outstanding)
+
+	switch {
+	case prev.CurrentDeadline == nil && cur.CurrentDeadline == nil:
+	case prev.CurrentDeadline == nil || cur.CurrentDeadline == nil:
+		diff.DeadlineChanged = true
+	default:
+		diff.DeadlineChanged = !prev.CurrentDeadline.Equal(cur.CurrentDeadline.Time)
+	}
+	return diff
+}
+
+// normalizeRequirements returns a copy of r with every list sorted and free
+// of duplicates, treating a nil r as having no requirements.
+func normalizeRequirements(r *AccountRequirements) *AccountRequirements {
+	if r == nil {
+		return &AccountRequirements{}
+	}
+	n := *r
+	n.CurrentlyDue = union(r.CurrentlyDue)
+	n.EventuallyDue = union(r.EventuallyDue)
+	n.PastDue = union(r.PastDue)
+	n.PendingVerification = union(r.PendingVerification)
+	return &n
+}
+
+// union returns the sorted, de-duplicated union of the given lists.
+func union(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	res := []string{}
+	for _, list := range lists {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				res = append(res, s)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// difference returns the elements of a that are not in b, in order.
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, s := range b {
+		exclude[s] = true
+	}
+	res := []string{}
+	for _, s := range a {
+		if !exclude[s] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
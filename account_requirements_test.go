@@ -0,0 +1,48 @@
+package stripe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDiffRequirements will test that changes between two requirements
+// snapshots are reported as newly due, newly past due or resolved.
+func TestDiffRequirements(t *testing.T) {
+	deadline := &UnixTime{time.Now().Add(72 * time.Hour)}
+	prev := &AccountRequirements{
+		CurrentlyDue:  []string{"individual.dob.day", "external_account"},
+		EventuallyDue: []string{"individual.id_number"},
+	}
+	cur := &AccountRequirements{
+		CurrentDeadline: deadline,
+		CurrentlyDue:    []string{"individual.id_number", "external_account", "external_account"},
+		PastDue:         []string{"external_account"},
+		DisabledReason:  "requirements.past_due",
+	}
+
+	diff := DiffRequirements(prev, cur)
+	if !reflect.DeepEqual(diff.NewlyDue, []string{"individual.id_number"}) {
+		t.Errorf("Expected NewlyDue [individual.id_number], got %v", diff.NewlyDue)
+	}
+	if !reflect.DeepEqual(diff.NewlyPastDue, []string{"external_account"}) {
+		t.Errorf("Expected NewlyPastDue [external_account], got %v", diff.NewlyPastDue)
+	}
+	if !reflect.DeepEqual(diff.Resolved, []string{"individual.dob.day"}) {
+		t.Errorf("Expected Resolved [individual.dob.day], got %v", diff.Resolved)
+	}
+	if !diff.DeadlineChanged || !diff.DisabledReasonChanged {
+		t.Errorf("Expected deadline and disabled reason changes")
+	}
+
+	// comparing a snapshot with itself should not report any changes
+	if diff := DiffRequirements(cur, cur); diff.Changed() {
+		t.Errorf("Expected no changes, got %+v", diff)
+	}
+
+	// a first snapshot reports everything currently due as newly due
+	diff = DiffRequirements(nil, prev)
+	if !reflect.DeepEqual(diff.NewlyDue, []string{"external_account", "individual.dob.day"}) {
+		t.Errorf("Expected everything currently due to be newly due, got %v", diff.NewlyDue)
+	}
+}
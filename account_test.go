@@ -0,0 +1,118 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCreateAccount will test that a custom Account is created with its
+// requested capabilities, and that your own Account is retrieved.
+func TestCreateAccount(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id": "acct_1", "type": "custom", "country": "US", "capabilities": {"card_payments": "pending"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "acct_platform", "type": "standard", "country": "US"}`)
+	})
+	ctx := context.Background()
+	requested := true
+	acct, err := c.Accounts.Create(ctx, &AccountParams{
+		Type:    AccountCustom,
+		Country: "US",
+		Email:   "jenny@example.com",
+		Capabilities: map[string]*CapabilityParams{
+			"card_payments": {Requested: &requested},
+		},
+	})
+	if err != nil || acct.ID != "acct_1" || acct.Capabilities["card_payments"] != CapabilityPending {
+		t.Fatalf("Expected Account acct_1 with pending card payments, got %v %v", acct, err)
+	}
+	want := url.Values{
+		"type":                                   {"custom"},
+		"country":                                {"US"},
+		"email":                                  {"jenny@example.com"},
+		"capabilities[card_payments][requested]": {"true"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	own, err := c.Accounts.Current(ctx)
+	if err != nil || own.ID != "acct_platform" {
+		t.Errorf("Expected own Account acct_platform, got %v %v", own, err)
+	}
+	if want := []string{"POST /v1/accounts", "GET /v1/account"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestCreateAccountLink will test that an onboarding link is created for a
+// connected Account.
+func TestCreateAccountLink(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object": "account_link", "url": "https://connect.stripe.com/setup/s/1", "created": 1600000000, "expires_at": 1600000300}`)
+	})
+	link, err := c.AccountLinks.Create(context.Background(), "acct_1", "https://example.com/refresh", "https://example.com/return", AccountLinkOnboarding)
+	if err != nil || link.URL != "https://connect.stripe.com/setup/s/1" || link.ExpiresAt.Unix() != 1600000300 {
+		t.Fatalf("Expected Account Link, got %v %v", link, err)
+	}
+	want := url.Values{
+		"account":     {"acct_1"},
+		"refresh_url": {"https://example.com/refresh"},
+		"return_url":  {"https://example.com/return"},
+		"type":        {"account_onboarding"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestRequestCapability will test that a Capability is requested for a
+// connected Account, and that its requirements are decoded.
+func TestRequestCapability(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object": "capability", "id": "transfers", "account": "acct_1", "requested": true, "status": "inactive", "requirements": {"currently_due": ["external_account"]}}`)
+	})
+	capability, err := c.Capabilities.Request(context.Background(), "acct_1", CapabilityTransfers, true)
+	if err != nil || capability.Status != CapabilityInactive || !reflect.DeepEqual(capability.Requirements.CurrentlyDue, []string{"external_account"}) {
+		t.Fatalf("Expected inactive Capability due an external account, got %+v %v", capability, err)
+	}
+	if want := []string{"POST /v1/accounts/acct_1/capabilities/transfers"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	if want := (url.Values{"requested": {"true"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestUploadAccountDocument will test that an additional document is
+// uploaded to the files API, and attached to the individual's verification.
+func TestUploadAccountDocument(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "acct_1"}`)
+	})
+	uploads := newUploadServer(t, c)
+
+	doc := &IdentityDocument{FrontFilename: "bill.pdf", Front: strings.NewReader("bill"), Additional: true}
+	account, err := c.Accounts.UploadDocument(context.Background(), "acct_1", doc)
+	if err != nil || account.ID != "acct_1" {
+		t.Fatalf("Expected Account acct_1, got %v %v", account, err)
+	}
+
+	if want := []string{"identity_document bill.pdf bill"}; !reflect.DeepEqual(*uploads, want) {
+		t.Errorf("Expected uploads %v, got %v", want, *uploads)
+	}
+	if want := []string{"POST /v1/accounts/acct_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Fatalf("Expected requests %v, got %v", want, srv.paths)
+	}
+	want := url.Values{"individual[verification][additional_document][front]": {"file_1"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"encoding/json"
+	"runtime"
+)
+
+// the version of this library, reported to Stripe in the User-Agent
+const clientVersion = "1.0.0"
+
+// AppInfo describes the application or plugin making requests, see
+// SetAppInfo.
+type AppInfo struct {
+	// The name of the application, ie MyPlugin.
+	Name string `json:"name"`
+
+	// (Optional) The version of the application, ie 1.2.3.
+	Version string `json:"version,omitempty"`
+
+	// (Optional) The website of the application or its author.
+	URL string `json:"url,omitempty"`
+}
+
+// String returns the application as it appears in a User-Agent, ie
+// MyPlugin/1.2.3 (https://example.com).
+func (a *AppInfo) String() string {
+	s := a.Name
+	if a.Version != "" {
+		s += "/" + a.Version
+	}
+	if a.URL != "" {
+		s += " (" + a.URL + ")"
+	}
+	return s
+}
+
+// userAgent returns the User-Agent of requests made by the application a,
+// which may be nil.
+func (a *AppInfo) userAgent() string {
+	ua := "Stripe/v1 GoBindings/" + clientVersion
+	if a != nil && a.Name != "" {
+		ua += " " + a.String()
+	}
+	return ua
+}
+
+// clientUserAgent returns the X-Stripe-Client-User-Agent of requests made by
+// the application a, which may be nil, describing this library and its
+// environment as JSON.
+func (a *AppInfo) clientUserAgent() string {
+	ua := struct {
+		BindingsVersion string   `json:"bindings_version"`
+		Lang            string   `json:"lang"`
+		LangVersion     string   `json:"lang_version"`
+		Publisher       string   `json:"publisher"`
+		Platform        string   `json:"platform"`
+		Application     *AppInfo `json:"application,omitempty"`
+	}{
+		BindingsVersion: clientVersion,
+		Lang:            "go",
+		LangVersion:     runtime.Version(),
+		Publisher:       "cupcake",
+		Platform:        runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if a != nil && a.Name != "" {
+		ua.Application = a
+	}
+	b, _ := json.Marshal(ua)
+	return string(b)
+}
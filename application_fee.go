@@ -0,0 +1,196 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ApplicationFee represents the fee collected by your platform from a Charge
+// made on a connected account.
+//
+// see https://stripe.com/docs/api#application_fee_object
+type ApplicationFee struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Account            string            `json:"account"`
+	Application        string            `json:"application"`
+	Amount             int64             `json:"amount"`
+	AmountRefunded     int64             `json:"amount_refunded"`
+	Currency           string            `json:"currency"`
+	Created            UnixTime          `json:"created"`
+	Charge             string            `json:"charge"`
+	BalanceTransaction string            `json:"balance_transaction"`
+	Refunded           bool              `json:"refunded"`
+	Refunds            []*FeeRefund      `json:"refunds,omitempty"`
+	Livemode           bool              `json:"livemode"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// FeeRefund represents an Application Fee returned to the connected account
+// it was collected from.
+//
+// see https://stripe.com/docs/api#fee_refund_object
+type FeeRefund struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Amount             int64             `json:"amount"`
+	Currency           string            `json:"currency"`
+	Created            UnixTime          `json:"created"`
+	Fee                string            `json:"fee"`
+	BalanceTransaction string            `json:"balance_transaction"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// ApplicationFeeListParams encapsulates options for filtering a list of
+// Application Fees.
+type ApplicationFeeListParams struct {
+	ListParams
+
+	// (Optional) Only return application fees collected from the charge
+	// with this ID.
+	Charge string `form:"charge"`
+
+	// (Optional) Only return application fees created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// FeeRefundParams encapsulates options for refunding an Application Fee.
+type FeeRefundParams struct {
+	// (Optional) The amount in cents to refund. Default is the entire amount
+	// of the Application Fee that has not been refunded yet.
+	Amount int64 `form:"amount"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows a Create request to be safely
+	// retried without refunding the Application Fee twice.
+	IdempotencyKey string
+}
+
+// ApplicationFeeClient encapsulates operations for querying application fees
+// using the Stripe REST API.
+type ApplicationFeeClient struct{ api }
+
+// Retrieves the Application Fee with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_application_fee
+func (c ApplicationFeeClient) Get(ctx context.Context, id string) (*ApplicationFee, error) {
+	res := &ApplicationFee{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Application Fee into v, which may be
+// any type with matching JSON fields.
+func (c ApplicationFeeClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/application_fees/"+url.QueryEscape(id), nil, v)
+}
+
+// Refund returns amount cents of the Application Fee with the given ID to the
+// connected account, or all of what has not been refunded yet if amount is
+// zero. See FeeRefundClient for more options.
+//
+// see https://stripe.com/docs/api#create_fee_refund
+func (c ApplicationFeeClient) Refund(ctx context.Context, id string, amount int64) (*FeeRefund, error) {
+	return FeeRefundClient{c.api}.Create(ctx, id, &FeeRefundParams{Amount: amount})
+}
+
+// Returns a list of Application Fees matching the given filters, or all of
+// your Application Fees when params is nil.
+//
+// see https://stripe.com/docs/api#list_application_fees
+func (c ApplicationFeeClient) List(ctx context.Context, params *ApplicationFeeListParams) ([]*ApplicationFee, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Application Fees matching the given filters, or of all your
+// Application Fees when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_application_fees
+func (c ApplicationFeeClient) ListFiltered(ctx context.Context, params *ApplicationFeeListParams) (*List[ApplicationFee], error) {
+	res := &List[ApplicationFee]{}
+	return res, c.query(ctx, "GET", "/application_fees", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Application Fee matching the filters of
+// params, newest first, fetching pages as they are needed. The Limit of
+// params, if any, sets the size of each page.
+func (c ApplicationFeeClient) ListAll(ctx context.Context, params *ApplicationFeeListParams) *Iter[ApplicationFee] {
+	return newIter(ctx, c.backend(), "/application_fees", params, func(fee *ApplicationFee) string { return fee.ID })
+}
+
+// FeeRefundClient encapsulates operations for refunding application fees
+// using the Stripe REST API.
+type FeeRefundClient struct{ api }
+
+func (c FeeRefundClient) path(feeID, refundID string) string {
+	p := fmt.Sprintf("/application_fees/%s/refunds", url.QueryEscape(feeID))
+	if refundID != "" {
+		p += "/" + url.QueryEscape(refundID)
+	}
+	return p
+}
+
+// Refunds all or part of the Application Fee with the given ID.
+//
+// see https://stripe.com/docs/api#create_fee_refund
+func (c FeeRefundClient) Create(ctx context.Context, feeID string, params *FeeRefundParams) (*FeeRefund, error) {
+	if params == nil {
+		params = &FeeRefundParams{}
+	}
+	res := &FeeRefund{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
+	return res, c.query(ctx, "POST", c.path(feeID, ""), formValues(params), res)
+}
+
+// Retrieves the refund with the given ID of an Application Fee.
+//
+// see https://stripe.com/docs/api#retrieve_fee_refund
+func (c FeeRefundClient) Get(ctx context.Context, feeID, refundID string) (*FeeRefund, error) {
+	res := &FeeRefund{}
+	return res, c.GetInto(ctx, feeID, refundID, res)
+}
+
+// GetInto is like Get, but decodes the Fee Refund into v, which may be any
+// type with matching JSON fields.
+func (c FeeRefundClient) GetInto(ctx context.Context, feeID, refundID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(feeID, refundID), nil, v)
+}
+
+// Updates the metadata of a Fee Refund. Other details of a Fee Refund cannot
+// be changed once it is created.
+//
+// see https://stripe.com/docs/api#update_fee_refund
+func (c FeeRefundClient) Update(ctx context.Context, feeID, refundID string, metadata map[string]string) (*FeeRefund, error) {
+	values := formValues(struct {
+		Metadata map[string]string `form:"metadata"`
+	}{metadata})
+
+	res := &FeeRefund{}
+	return res, c.query(ctx, "POST", c.path(feeID, refundID), values, res)
+}
+
+// Returns a list of the refunds of an Application Fee.
+//
+// see https://stripe.com/docs/api#list_fee_refunds
+func (c FeeRefundClient) List(ctx context.Context, feeID string, limit int, before, after string) ([]*FeeRefund, bool, error) {
+	res := struct {
+		ListObject
+		Data []*FeeRefund
+	}{}
+	err := c.query(ctx, "GET", c.path(feeID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the refunds of the Application Fee with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_fee_refunds
+func (c FeeRefundClient) ListFiltered(ctx context.Context, feeID string, params *ListParams) (*List[FeeRefund], error) {
+	res := &List[FeeRefund]{}
+	return res, c.query(ctx, "GET", c.path(feeID, ""), formValues(params), res)
+}
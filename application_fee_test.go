@@ -0,0 +1,48 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestApplicationFees will test that application fees are listed by charge
+// and refunded through their refunds.
+func TestApplicationFees(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"object": "list", "has_more": false, "data": [{"id": "fee_1", "charge": "ch_1", "amount": 100, "refunds": []}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "fr_1", "fee": "fee_1", "amount": 40}`)
+	})
+	ctx := context.Background()
+	fees, _, err := c.ApplicationFees.List(ctx, &ApplicationFeeListParams{Charge: "ch_1"})
+	if err != nil || len(fees) != 1 || fees[0].ID != "fee_1" || fees[0].Charge != "ch_1" {
+		t.Fatalf("Expected Application Fee fee_1 of ch_1, got %v %v", fees, err)
+	}
+	if got := srv.forms[0].Get("charge"); got != "ch_1" {
+		t.Errorf("Expected charge ch_1, got %q", got)
+	}
+
+	fr, err := c.ApplicationFees.Refund(ctx, "fee_1", 40)
+	if err != nil || fr.ID != "fr_1" || fr.Fee != "fee_1" || fr.Amount != 40 {
+		t.Fatalf("Expected Fee Refund fr_1 of fee_1, got %v %v", fr, err)
+	}
+	if want := (url.Values{"amount": {"40"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	// a full refund sends no amount
+	if _, err := c.ApplicationFees.Refund(ctx, "fee_1", 0); err != nil || len(srv.forms[2]) != 0 {
+		t.Errorf("Expected no parameters for a full refund, got %v %v", srv.forms[2], err)
+	}
+
+	want := []string{"GET /v1/application_fees", "POST /v1/application_fees/fee_1/refunds", "POST /v1/application_fees/fee_1/refunds"}
+	if !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
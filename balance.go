@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"context"
+)
+
+// Balance represents the funds in your Stripe account, per currency.
+//
+// see https://stripe.com/docs/api#balance_object
+type Balance struct {
+	APIResource
+	Available []*BalanceAmount `json:"available"`
+	Pending   []*BalanceAmount `json:"pending"`
+	Livemode  bool             `json:"livemode"`
+}
+
+// BalanceAmount is the amount of a Balance in a single currency.
+type BalanceAmount struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// AvailableIn returns the amount of the Balance available to be paid out in
+// the given currency, or zero if there is none.
+func (b *Balance) AvailableIn(currency string) int64 {
+	return amountIn(b.Available, currency)
+}
+
+// PendingIn returns the amount of the Balance in the given currency that is
+// not yet available, or zero if there is none.
+func (b *Balance) PendingIn(currency string) int64 {
+	return amountIn(b.Pending, currency)
+}
+
+func amountIn(amounts []*BalanceAmount, currency string) int64 {
+	var total int64
+	for _, a := range amounts {
+		if a.Currency == currency {
+			total += a.Amount
+		}
+	}
+	return total
+}
+
+// BalanceClient encapsulates operations for querying the balance of your
+// account using the Stripe REST API.
+type BalanceClient struct{ api }
+
+// Retrieves the current Balance of your account, or of the connected
+// account set with WithStripeAccount.
+//
+// see https://stripe.com/docs/api#retrieve_balance
+func (c BalanceClient) Get(ctx context.Context) (*Balance, error) {
+	res := &Balance{}
+	return res, c.query(ctx, "GET", "/balance", nil, res)
+}
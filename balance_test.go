@@ -0,0 +1,61 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestGetBalance will test that the Balance is retrieved, and that its
+// amounts are summed per currency.
+func TestGetBalance(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/balance" {
+			w.WriteHeader(404)
+			return
+		}
+		fmt.Fprint(w, `{
+			"available": [{"amount": 1000, "currency": "usd"}, {"amount": 200, "currency": "eur"}],
+			"pending": [{"amount": 300, "currency": "usd"}, {"amount": 50, "currency": "usd"}]
+		}`)
+	})
+	b, err := c.Balances.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected Balance, got Error %s", err.Error())
+	}
+	if b.AvailableIn(USD) != 1000 || b.AvailableIn(EUR) != 200 || b.AvailableIn(GBP) != 0 {
+		t.Errorf("Expected available amounts per currency, got %v", b.Available)
+	}
+	if b.PendingIn(USD) != 350 {
+		t.Errorf("Expected 350 pending in usd, got %d", b.PendingIn(USD))
+	}
+}
+
+// TestListBalanceTransactions will test that Balance Transactions are listed
+// with their filters, and that their fees are broken down by type.
+func TestListBalanceTransactions(t *testing.T) {
+	var query string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"has_more": false, "data": [{
+			"id": "txn_1", "amount": 1000, "fee": 89, "net": 911, "type": "charge", "source": "ch_1",
+			"fee_details": [
+				{"amount": 59, "type": "stripe_fee"},
+				{"amount": 30, "type": "application_fee"}
+			]
+		}]}`)
+	})
+	params := &BalanceTransactionListParams{Type: TxCharge, Payout: "po_1", Created: Since(time.Unix(1400000000, 0))}
+	txns, _, err := c.BalanceTransactions.List(context.Background(), params)
+	if err != nil || len(txns) != 1 {
+		t.Fatalf("Expected a Balance Transaction, got %v %v", txns, err)
+	}
+	if want := "created%5Bgte%5D=1400000000&payout=po_1&type=charge"; query != want {
+		t.Errorf("Expected query %s, got %s", want, query)
+	}
+	if txns[0].FeeOf(TxStripeFee) != 59 || txns[0].FeeOf(TxApplicationFee) != 30 || txns[0].FeeOf("tax") != 0 {
+		t.Errorf("Expected fees by type, got %v", txns[0].FeeDetails)
+	}
+}
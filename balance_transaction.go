@@ -0,0 +1,174 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Balance Transaction Types
+const (
+	TxCharge         = "charge"
+	TxRefund         = "refund"
+	TxAdjustment     = "adjustment"
+	TxApplicationFee = "application_fee"
+	TxTransfer       = "transfer"
+	TxPayment        = "payment"
+	TxPayout         = "payout"
+	TxPayoutCancel   = "payout_cancel"
+	TxPayoutFailure  = "payout_failure"
+	TxStripeFee      = "stripe_fee"
+)
+
+// Balance Transaction Statuses
+const (
+	TxAvailable = "available"
+	TxPending   = "pending"
+)
+
+// BalanceTransaction represents a single movement of funds into or out of a
+// Stripe balance, such as a charge, refund, transfer or payout.
+//
+// see https://stripe.com/docs/api#balance_transaction_object
+type BalanceTransaction struct {
+	APIResource
+	ID                string       `json:"id"`
+	Amount            int64        `json:"amount"`
+	AvailableOn       UnixTime     `json:"available_on"`
+	Created           UnixTime     `json:"created"`
+	Currency          string       `json:"currency"`
+	Description       string       `json:"description,omitempty"`
+	ExchangeRate      float64      `json:"exchange_rate,omitempty"`
+	Fee               int64        `json:"fee"`
+	FeeDetails        []*FeeDetail `json:"fee_details"`
+	Net               int64        `json:"net"`
+	ReportingCategory string       `json:"reporting_category,omitempty"`
+	Source            string       `json:"source"`
+	Status            string       `json:"status"`
+	Type              string       `json:"type"`
+}
+
+// FeeDetail describes an individual fee (Stripe, application or tax) that
+// was deducted from a Balance Transaction.
+type FeeDetail struct {
+	Amount      int64  `json:"amount"`
+	Application string `json:"application,omitempty"`
+	Currency    string `json:"currency"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+}
+
+// FeeOf returns the total of the fees of the given type deducted from the
+// Balance Transaction, ie stripe_fee, application_fee or tax.
+func (t *BalanceTransaction) FeeOf(feeType string) int64 {
+	var total int64
+	for _, fee := range t.FeeDetails {
+		if fee.Type == feeType {
+			total += fee.Amount
+		}
+	}
+	return total
+}
+
+// BalanceTransactionListParams encapsulates options for filtering a list of
+// Balance Transactions.
+type BalanceTransactionListParams struct {
+	ListParams
+
+	// (Optional) Only return transactions that were paid out in the Payout
+	// with this ID.
+	Payout string `form:"payout"`
+
+	// (Optional) Only return transactions of the given type, such as charge,
+	// refund or transfer.
+	Type string `form:"type"`
+
+	// (Optional) Only return transactions related to the given source ID.
+	Source string `form:"source"`
+
+	// (Optional) Only return transactions in a certain currency.
+	Currency string `form:"currency"`
+
+	// (Optional) Only return transactions created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// BalanceTransactionClient encapsulates operations for querying the balance
+// history using the Stripe REST API.
+type BalanceTransactionClient struct{ api }
+
+// Retrieves the Balance Transaction with the given ID.
+//
+// see https://stripe.com/docs/api#balance_transaction_retrieve
+func (c BalanceTransactionClient) Get(ctx context.Context, id string) (*BalanceTransaction, error) {
+	res := &BalanceTransaction{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Balance Transaction into v, which may be any
+// type with matching JSON fields.
+func (c BalanceTransactionClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/balance_transactions/"+url.QueryEscape(id), nil, v)
+}
+
+// Returns a list of Balance Transactions matching the given filters, or your
+// entire balance history when params is nil.
+//
+// see https://stripe.com/docs/api#balance_history
+func (c BalanceTransactionClient) List(ctx context.Context, params *BalanceTransactionListParams) ([]*BalanceTransaction, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Balance Transactions matching the given filters, or of all your
+// Balance Transactions when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#balance_history
+func (c BalanceTransactionClient) ListFiltered(ctx context.Context, params *BalanceTransactionListParams) (*List[BalanceTransaction], error) {
+	res := &List[BalanceTransaction]{}
+	return res, c.query(ctx, "GET", "/balance_transactions", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Balance Transaction matching the filters
+// of params, newest first, fetching pages as they are needed. The Limit of
+// params, if any, sets the size of each page.
+func (c BalanceTransactionClient) ListAll(ctx context.Context, params *BalanceTransactionListParams) *Iter[BalanceTransaction] {
+	return newIter(ctx, c.backend(), "/balance_transactions", params, func(tx *BalanceTransaction) string { return tx.ID })
+}
+
+// ListAllSince returns every Balance Transaction created at or after t,
+// oldest first, fetching as many pages as needed.
+func (c BalanceTransactionClient) ListAllSince(ctx context.Context, t time.Time) ([]*BalanceTransaction, error) {
+	return listAllSince(ctx, c.backend(), "/balance_transactions", "created", t,
+		func(tx *BalanceTransaction) string { return tx.ID },
+		func(tx *BalanceTransaction) time.Time { return tx.Created.Time })
+}
+
+// ListParallel returns every Balance Transaction created within the time
+// range of opts, oldest first, fetching several shards of the range at the
+// same time.
+func (c BalanceTransactionClient) ListParallel(ctx context.Context, opts *ParallelOptions) ([]*BalanceTransaction, error) {
+	return listParallel(ctx, c.backend(), "/balance_transactions", opts,
+		func(tx *BalanceTransaction) string { return tx.ID },
+		func(tx *BalanceTransaction) time.Time { return tx.Created.Time })
+}
+
+// Stream sends every Balance Transaction matching the filters of params,
+// which may be nil, newest first, on the returned channel as pages are
+// fetched in the background. The transaction channel is closed when the list
+// is exhausted or ctx is done, after which the error channel reports any
+// failure.
+func (c BalanceTransactionClient) Stream(ctx context.Context, params *BalanceTransactionListParams) (<-chan *BalanceTransaction, <-chan error) {
+	return stream(ctx, c.backend(), "/balance_transactions", params, func(tx *BalanceTransaction) string { return tx.ID })
+}
+
+// Returns a list of the Balance Transactions that were paid out in the Payout
+// with the given ID, which together make up the amount of the bank deposit.
+//
+// see https://stripe.com/docs/api#balance_history
+func (c BalanceTransactionClient) PayoutList(ctx context.Context, id string, limit int, before, after string) ([]*BalanceTransaction, bool, error) {
+	return c.List(ctx, &BalanceTransactionListParams{
+		ListParams: ListParams{Limit: limit, EndingBefore: before, StartingAfter: after},
+		Payout:     id,
+	})
+}
@@ -0,0 +1,150 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Bank Account Statuses
+const (
+	BankAccountNew                = "new"
+	BankAccountValidated          = "validated"
+	BankAccountVerified           = "verified"
+	BankAccountVerificationFailed = "verification_failed"
+	BankAccountErrored            = "errored"
+)
+
+// Bank Account Holder Types
+const (
+	AccountHolderIndividual = "individual"
+	AccountHolderCompany    = "company"
+)
+
+// BankAccount represents details about a bank account that can be used as a
+// payout destination for a connected account, or debited through ACH as a
+// payment source of a customer.
+//
+// see https://stripe.com/docs/api#bank_account_object
+type BankAccount struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Account            string            `json:"account,omitempty"`
+	Customer           string            `json:"customer,omitempty"`
+	AccountHolderName  string            `json:"account_holder_name,omitempty"`
+	AccountHolderType  string            `json:"account_holder_type,omitempty"`
+	BankName           string            `json:"bank_name"`
+	Country            string            `json:"country"`
+	Currency           string            `json:"currency"`
+	DefaultForCurrency bool              `json:"default_for_currency,omitempty"`
+	Fingerprint        string            `json:"fingerprint"`
+	Last4              string            `json:"last4"`
+	RoutingNumber      string            `json:"routing_number,omitempty"`
+	Status             string            `json:"status"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// BankAccountParams encapsulates options for creating a Bank Account from raw
+// account details rather than a token.
+type BankAccountParams struct {
+	// The country in which the bank account is located.
+	Country string `form:"country"`
+
+	// The currency the bank account is in.
+	Currency string `form:"currency"`
+
+	// The account number for the bank account, in string form.
+	AccountNumber string `form:"account_number"`
+
+	// (Optional) The routing number, sort code, or other country-appropriate
+	// institution number for the bank account.
+	RoutingNumber string `form:"routing_number"`
+
+	// (Optional) The name of the person or business that owns the bank
+	// account.
+	AccountHolderName string `form:"account_holder_name"`
+
+	// (Optional) The type of entity that holds the account. Either
+	// individual or company.
+	AccountHolderType string `form:"account_holder_type"`
+}
+
+// BankAccountClient encapsulates operations for adding, verifying, deleting
+// and querying the bank accounts of a customer, which can be debited through
+// ACH once verified, using the Stripe REST API. See ExternalAccountClient for
+// the bank accounts of connected accounts.
+type BankAccountClient struct{ api }
+
+func (c BankAccountClient) path(customerID, bankAccountID string) string {
+	p := fmt.Sprintf("/customers/%s/sources", url.QueryEscape(customerID))
+	if bankAccountID != "" {
+		p += "/" + url.QueryEscape(bankAccountID)
+	}
+	return p
+}
+
+// Adds a bank account to the Customer with the given ID from a bank account
+// Token. The bank account must be verified before it can be debited.
+//
+// see https://stripe.com/docs/api#customer_create_bank_account
+func (c BankAccountClient) Create(ctx context.Context, customerID, token string) (*BankAccount, error) {
+	values := url.Values{"source": {token}}
+	res := &BankAccount{}
+	return res, c.query(ctx, "POST", c.path(customerID, ""), values, res)
+}
+
+// Retrieves the bank account with the given ID of a Customer.
+//
+// see https://stripe.com/docs/api#customer_retrieve_bank_account
+func (c BankAccountClient) Get(ctx context.Context, customerID, bankAccountID string) (*BankAccount, error) {
+	res := &BankAccount{}
+	return res, c.query(ctx, "GET", c.path(customerID, bankAccountID), nil, res)
+}
+
+// Verify verifies a bank account of a Customer with the amounts, in cents,
+// of the two micro-deposits sent to it.
+//
+// see https://stripe.com/docs/api#customer_verify_bank_account
+func (c BankAccountClient) Verify(ctx context.Context, customerID, bankAccountID string, amount1, amount2 int64) (*BankAccount, error) {
+	values := formValues(struct {
+		Amounts []int64 `form:"amounts"`
+	}{[]int64{amount1, amount2}})
+
+	res := &BankAccount{}
+	return res, c.query(ctx, "POST", c.path(customerID, bankAccountID)+"/verify", values, res)
+}
+
+// Deletes a bank account of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#customer_delete_bank_account
+func (c BankAccountClient) Delete(ctx context.Context, customerID, bankAccountID string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", c.path(customerID, bankAccountID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the bank accounts of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#customer_list_bank_accounts
+func (c BankAccountClient) List(ctx context.Context, customerID string, limit int, before, after string) ([]*BankAccount, bool, error) {
+	res := struct {
+		ListObject
+		Data []*BankAccount
+	}{}
+	params := listParams(limit, before, after)
+	params.Set("object", ExternalAccountBankAccount)
+	err := c.query(ctx, "GET", c.path(customerID, ""), params, &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the bank accounts of the Customer with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#customer_list_bank_accounts
+func (c BankAccountClient) ListFiltered(ctx context.Context, customerID string, params *ListParams) (*List[BankAccount], error) {
+	values := formValues(params)
+	values.Set("object", ExternalAccountBankAccount)
+
+	res := &List[BankAccount]{}
+	return res, c.query(ctx, "GET", c.path(customerID, ""), values, res)
+}
@@ -0,0 +1,44 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestVerifyBankAccount will test that a bank account is added to a Customer
+// from a Token and verified with its micro-deposit amounts.
+func TestVerifyBankAccount(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		status := BankAccountNew
+		if strings.HasSuffix(r.URL.Path, "/verify") {
+			status = BankAccountVerified
+		}
+		fmt.Fprintf(w, `{"id": "ba_1", "object": "bank_account", "customer": "cus_1", "last4": "6789", "routing_number": "110000000", "status": %q}`, status)
+	})
+	ctx := context.Background()
+	ba, err := c.BankAccounts.Create(ctx, "cus_1", "btok_1")
+	if err != nil || ba.Customer != "cus_1" || ba.Status != BankAccountNew {
+		t.Fatalf("Expected new Bank Account of cus_1, got %v %v", ba, err)
+	}
+	if want := (url.Values{"source": {"btok_1"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	ba, err = c.BankAccounts.Verify(ctx, "cus_1", "ba_1", 32, 45)
+	if err != nil || ba.Status != BankAccountVerified {
+		t.Fatalf("Expected verified Bank Account, got %v %v", ba, err)
+	}
+	if want := (url.Values{"amounts[0]": {"32"}, "amounts[1]": {"45"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	want := []string{"POST /v1/customers/cus_1/sources", "POST /v1/customers/cus_1/sources/ba_1/verify"}
+	if !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
@@ -0,0 +1,81 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Capability Names
+const (
+	CapabilityCardPayments = "card_payments"
+	CapabilityTransfers    = "transfers"
+)
+
+// Capability Statuses
+const (
+	CapabilityActive   = "active"
+	CapabilityInactive = "inactive"
+	CapabilityPending  = "pending"
+)
+
+// Capability represents a feature, such as accepting card payments, that was
+// requested for a connected Account, along with what the Account still needs
+// to provide for it to become active.
+//
+// see https://stripe.com/docs/api#capability_object
+type Capability struct {
+	APIResource
+	ID           string               `json:"id"`
+	Account      string               `json:"account"`
+	Requested    bool                 `json:"requested"`
+	RequestedAt  *UnixTime            `json:"requested_at,omitempty"`
+	Status       string               `json:"status"`
+	Requirements *AccountRequirements `json:"requirements,omitempty"`
+}
+
+// CapabilityClient encapsulates operations for querying and requesting the
+// capabilities of connected accounts using the Stripe REST API.
+type CapabilityClient struct{ api }
+
+func (c CapabilityClient) path(accountID, name string) string {
+	p := fmt.Sprintf("/accounts/%s/capabilities", url.QueryEscape(accountID))
+	if name != "" {
+		p += "/" + url.QueryEscape(name)
+	}
+	return p
+}
+
+// Retrieves the Capability with the given name of a connected Account.
+//
+// see https://stripe.com/docs/api#retrieve_capability
+func (c CapabilityClient) Get(ctx context.Context, accountID, name string) (*Capability, error) {
+	res := &Capability{}
+	return res, c.query(ctx, "GET", c.path(accountID, name), nil, res)
+}
+
+// Request requests the Capability with the given name for a connected
+// Account, or removes the request if requested is false.
+//
+// see https://stripe.com/docs/api#update_capability
+func (c CapabilityClient) Request(ctx context.Context, accountID, name string, requested bool) (*Capability, error) {
+	values := formValues(struct {
+		Requested *bool `form:"requested"`
+	}{&requested})
+
+	res := &Capability{}
+	return res, c.query(ctx, "POST", c.path(accountID, name), values, res)
+}
+
+// Returns every Capability of a connected Account. The list is not
+// paginated.
+//
+// see https://stripe.com/docs/api#list_capabilities
+func (c CapabilityClient) List(ctx context.Context, accountID string) ([]*Capability, error) {
+	res := struct {
+		ListObject
+		Data []*Capability
+	}{}
+	err := c.query(ctx, "GET", c.path(accountID, ""), nil, &res)
+	return res.Data, err
+}
@@ -1,10 +1,12 @@
 package stripe
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Credit Card Types accepted by the Stripe API.
@@ -20,59 +22,72 @@ const (
 
 // Card represents details about a Credit Card entered into Stripe.
 type Card struct {
-	ID                string `json:"id"`
-	Name              string `json:"name,omitempty"`
-	Type              string `json:"type"`
-	ExpMonth          int    `json:"exp_month"`
-	ExpYear           int    `json:"exp_year"`
-	Last4             string `json:"last4"`
-	Fingerprint       string `json:"fingerprint"`
-	Country           string `json:"country,omitempty"`
-	Address1          string `json:"address_line1,omitempty"`
-	Address2          string `json:"address_line2,omitempty"`
-	AddressCountry    string `json:"address_country,omitempty"`
-	AddressState      string `json:"address_state,omitempty"`
-	AddressZip        string `json:"address_zip,omitempty"`
-	AddressLine1Check string `json:"address_line1_check,omitempty"`
-	AddressZipCheck   string `json:"address_zip_check,omitempty"`
-	CVCCheck          string `json:"cvc_check,omitempty"`
-	Customer          string `json:"customer,omitempty"`
+	APIResource
+	ID                string            `json:"id"`
+	Name              string            `json:"name,omitempty"`
+	Type              string            `json:"type"`
+	ExpMonth          int               `json:"exp_month"`
+	ExpYear           int               `json:"exp_year"`
+	Last4             string            `json:"last4"`
+	Fingerprint       string            `json:"fingerprint"`
+	Country           string            `json:"country,omitempty"`
+	Address1          string            `json:"address_line1,omitempty"`
+	Address2          string            `json:"address_line2,omitempty"`
+	AddressCountry    string            `json:"address_country,omitempty"`
+	AddressState      string            `json:"address_state,omitempty"`
+	AddressZip        string            `json:"address_zip,omitempty"`
+	AddressLine1Check string            `json:"address_line1_check,omitempty"`
+	AddressZipCheck   string            `json:"address_zip_check,omitempty"`
+	CVCCheck          string            `json:"cvc_check,omitempty"`
+	Customer          string            `json:"customer,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+
+	// Account, Currency and DefaultForCurrency are only set for debit cards
+	// attached to a connected account as an external account.
+	Account            string `json:"account,omitempty"`
+	Currency           string `json:"currency,omitempty"`
+	DefaultForCurrency bool   `json:"default_for_currency,omitempty"`
 }
 
 // CardParams encapsulates options for Creating or Updating Credit Cards.
 type CardParams struct {
 	// (Optional) Cardholder's full name.
-	Name string
+	Name string `form:"name"`
 
 	// The card number, as a string without any separators.
-	Number string
+	Number string `form:"number"`
 
 	// The card's expiration month.
-	ExpMonth int
+	ExpMonth int `form:"exp_month"`
 
 	// The card's expiration year.
-	ExpYear int
+	ExpYear int `form:"exp_year"`
 
 	// Card security code
-	CVC string
+	CVC string `form:"cvc"`
 
 	// (Optional) Billing address line 1
-	Address1 string
+	Address1 string `form:"address_line1"`
 
 	// (Optional) Billing address line 2
-	Address2 string
+	Address2 string `form:"address_line2"`
 
 	// (Optional) Billing address country
-	AddressCountry string
+	AddressCountry string `form:"address_country"`
 
 	// (Optional) Billing address state
-	AddressState string
+	AddressState string `form:"address_state"`
 
 	// (Optional) Billing address zip code
-	AddressZip string
+	AddressZip string `form:"address_zip"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
 }
 
-type CardClient struct{}
+// CardClient encapsulates operations for adding, updating, deleting and
+// querying the cards of a customer using the Stripe REST API.
+type CardClient struct{ api }
 
 func (c CardClient) path(customerID, cardID string) string {
 	p := fmt.Sprintf("/customers/%s/cards", url.QueryEscape(customerID))
@@ -82,44 +97,75 @@ func (c CardClient) path(customerID, cardID string) string {
 	return p
 }
 
-func (c CardClient) Create(customerID, token string, card *CardParams) (*Card, error) {
+// Adds a card to the Customer with the given ID, either from a card Token or,
+// when token is empty, from the raw details of card.
+//
+// see https://stripe.com/docs/api#create_card
+func (c CardClient) Create(ctx context.Context, customerID, token string, card *CardParams) (*Card, error) {
 	params := make(url.Values)
 	if token != "" {
 		params.Add("card", token)
 	} else {
-		appendCardParams(params, false, card)
+		// raw details are nested under card, ie card[number]
+		appendForm(params, "card", card)
 	}
 	res := &Card{}
-	return res, query("POST", c.path(customerID, ""), params, res)
+	return res, c.query(ctx, "POST", c.path(customerID, ""), params, res)
 }
 
-func (c CardClient) Update(customerID, cardID string, card *CardParams) (*Card, error) {
-	params := make(url.Values)
-	appendCardParams(params, false, card)
+// Updates the name, expiration or address of a card of the Customer with the
+// given ID. The number of a card can not be changed.
+//
+// see https://stripe.com/docs/api#update_card
+func (c CardClient) Update(ctx context.Context, customerID, cardID string, card *CardParams) (*Card, error) {
 	res := &Card{}
-	return res, query("POST", c.path(customerID, cardID), params, res)
+	return res, c.query(ctx, "POST", c.path(customerID, cardID), formValues(card), res)
 }
 
-func (c CardClient) Delete(customerID, cardID string) (bool, error) {
+// Deletes a card of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#delete_card
+func (c CardClient) Delete(ctx context.Context, customerID, cardID string) (bool, error) {
 	res := &DeleteResp{}
-	err := query("DELETE", c.path(customerID, cardID), nil, res)
+	err := c.query(ctx, "DELETE", c.path(customerID, cardID), nil, res)
 	return res.Deleted, err
 }
 
-func (c CardClient) Get(customerID, cardID string) (*Card, error) {
+// Retrieves the card with the given ID of a Customer.
+//
+// see https://stripe.com/docs/api#retrieve_card
+func (c CardClient) Get(ctx context.Context, customerID, cardID string) (*Card, error) {
 	res := &Card{}
-	return res, query("GET", c.path(customerID, cardID), nil, res)
+	return res, c.GetInto(ctx, customerID, cardID, res)
+}
+
+// GetInto is like Get, but decodes the Card into v, which may be any
+// type with matching JSON fields.
+func (c CardClient) GetInto(ctx context.Context, customerID, cardID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(customerID, cardID), nil, v)
 }
 
-func (c CardClient) List(customerID string, limit int, before, after string) ([]*Card, bool, error) {
+// Returns a list of the cards of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#list_cards
+func (c CardClient) List(ctx context.Context, customerID string, limit int, before, after string) ([]*Card, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Card
 	}{}
-	err := query("GET", c.path(customerID, ""), listParams(limit, before, after), &res)
+	err := c.query(ctx, "GET", c.path(customerID, ""), listParams(limit, before, after), &res)
 	return res.Data, res.More, err
 }
 
+// Returns a page of the cards of the Customer with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_cards
+func (c CardClient) ListFiltered(ctx context.Context, customerID string, params *ListParams) (*List[Card], error) {
+	res := &List[Card]{}
+	return res, c.query(ctx, "GET", c.path(customerID, ""), formValues(params), res)
+}
+
 // IsLuhnValid uses the Luhn Algorithm (also known as the Mod 10 algorithm) to
 // verify a credit cards checksum, which helps flag accidental data entry
 // errors.
@@ -159,6 +205,10 @@ func IsLuhnValid(card string) (bool, error) {
 // Discover) based on the Credit Card Number. If the Number is not recognized, a
 // value of "Unknown" will be returned.
 func GetCardType(card string) string {
+	if len(card) < 4 {
+		return UnknownCard
+	}
+
 	switch card[0:1] {
 	case "4":
 		return Visa
@@ -195,3 +245,54 @@ func GetCardType(card string) string {
 
 	return UnknownCard
 }
+
+// CardValidationError is returned, before any request is made, when the
+// details of a card fail local validation. Field is the name of the failing
+// parameter (ie number, exp_month, cvc).
+type CardValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *CardValidationError) Error() string {
+	return fmt.Sprintf("stripe: invalid card %s: %s", e.Field, e.Message)
+}
+
+// ValidateCard checks the number, expiration date and security code of a card
+// locally, returning a *CardValidationError for the first field that is
+// obviously invalid. A 2-digit expiration year, ie 30, is taken to be in the
+// 2000s, as Stripe does. A card that passes may still be declined by Stripe.
+func ValidateCard(card *CardParams) error {
+	if len(card.Number) < 12 || len(card.Number) > 19 {
+		return &CardValidationError{"number", "must be between 12 and 19 digits"}
+	}
+	if valid, err := IsLuhnValid(card.Number); err != nil {
+		return &CardValidationError{"number", "must contain only digits"}
+	} else if !valid {
+		return &CardValidationError{"number", "failed the Luhn check"}
+	}
+
+	if card.ExpMonth < 1 || card.ExpMonth > 12 {
+		return &CardValidationError{"exp_month", "must be between 1 and 12"}
+	}
+	year := card.ExpYear
+	if year < 100 {
+		year += 2000
+	}
+	now := time.Now()
+	if year < now.Year() || (year == now.Year() && card.ExpMonth < int(now.Month())) {
+		return &CardValidationError{"exp_year", "card has expired"}
+	}
+
+	// the security code is optional, but must match the brand if provided
+	if card.CVC != "" {
+		size := 3
+		if GetCardType(card.Number) == AmericanExpress {
+			size = 4
+		}
+		if _, err := strconv.Atoi(card.CVC); err != nil || len(card.CVC) != size {
+			return &CardValidationError{"cvc", fmt.Sprintf("must be %d digits", size)}
+		}
+	}
+	return nil
+}
@@ -1,7 +1,13 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 type card struct {
@@ -38,3 +44,95 @@ func TestLuhn(t *testing.T) {
 		}
 	}
 }
+
+// TestValidateCard will test that obviously invalid numbers, expiration dates
+// and security codes are reported for the field they are in, and that 2-digit
+// expiration years are accepted.
+func TestValidateCard(t *testing.T) {
+	year := time.Now().Year() + 1
+	tests := []struct {
+		card  CardParams
+		field string
+	}{
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: year, CVC: "123"}, ""},
+		{CardParams{Number: "378282246310005", ExpMonth: 1, ExpYear: year, CVC: "1234"}, ""},
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: year}, ""},
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: year % 100}, ""},
+		{CardParams{Number: "4242", ExpMonth: 1, ExpYear: year}, "number"},
+		{CardParams{Number: "4242-4242-4242-4242", ExpMonth: 1, ExpYear: year}, "number"},
+		{CardParams{Number: "4213729238347292", ExpMonth: 1, ExpYear: year}, "number"},
+		{CardParams{Number: "4242424242424242", ExpMonth: 13, ExpYear: year}, "exp_month"},
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: year - 2}, "exp_year"},
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: (year - 2) % 100}, "exp_year"},
+		{CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: year, CVC: "1234"}, "cvc"},
+		{CardParams{Number: "378282246310005", ExpMonth: 1, ExpYear: year, CVC: "123"}, "cvc"},
+	}
+	for _, test := range tests {
+		err := ValidateCard(&test.card)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("Expected card %s to be valid, got %s", test.card.Number, err)
+			}
+			continue
+		}
+		if verr, ok := err.(*CardValidationError); !ok {
+			t.Errorf("Expected CardValidationError for card %s, got %v", test.card.Number, err)
+		} else if verr.Field != test.field {
+			t.Errorf("Expected invalid field %s, got %s", test.field, verr.Field)
+		}
+	}
+
+	if typ := GetCardType("4"); typ != UnknownCard {
+		t.Errorf("Expected short number to be Unknown, got %s", typ)
+	}
+}
+
+// TestCreateCard will test that the raw details of a card added to a Customer
+// are nested under card, and that updates are sent as top-level fields.
+func TestCreateCard(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "card_1", "customer": "cus_1", "last4": "4242", "exp_month": 12, "exp_year": 2030}`)
+	})
+	ctx := context.Background()
+	card, err := c.Cards.Create(ctx, "cus_1", "", &CardParams{Number: "4242424242424242", ExpMonth: 12, ExpYear: 2030, CVC: "123"})
+	if err != nil || card.ID != "card_1" || card.Customer != "cus_1" {
+		t.Fatalf("Expected Card card_1 of cus_1, got %v %v", card, err)
+	}
+	want := url.Values{
+		"card[number]":    {"4242424242424242"},
+		"card[exp_month]": {"12"},
+		"card[exp_year]":  {"2030"},
+		"card[cvc]":       {"123"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	if _, err := c.Cards.Update(ctx, "cus_1", "card_1", &CardParams{ExpYear: 2031}); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if want := (url.Values{"exp_year": {"2031"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	if want := []string{"POST /v1/customers/cus_1/cards", "POST /v1/customers/cus_1/cards/card_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestSetDefaultCard will test that a card of a Customer is made its default.
+func TestSetDefaultCard(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "cus_1", "default_card": "card_2"}`)
+	})
+	cust, err := c.Customers.SetDefaultCard(context.Background(), "cus_1", "card_2")
+	if err != nil || cust.DefaultCard != "card_2" {
+		t.Fatalf("Expected default card card_2, got %v %v", cust, err)
+	}
+	if want := []string{"POST /v1/customers/cus_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	if want := (url.Values{"default_card": {"card_2"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
@@ -1,8 +1,10 @@
 package stripe
 
 import (
+	"context"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // ISO 3-digit Currency Codes for major currencies (not the full list).
@@ -21,161 +23,279 @@ const (
 //
 // see https://stripe.com/docs/api#charge_object
 type Charge struct {
-	ID                 string            `json:"id"`
-	Description        string            `json:"description,omitempty"`
-	Amount             int               `json:"amount"`
-	Card               *Card             `json:"card"`
-	Currency           string            `json:"currency"`
-	Created            UnixTime          `json:"created"`
-	Customer           string            `json:"customer,omitempty"`
-	Invoice            string            `json:"invoice,omitempty"`
-	Paid               bool              `json:"paid"`
-	Refunded           bool              `json:"refunded,omitempty"`
-	AmountRefunded     int               `json:"amount_refunded,omitempty"`
-	BalanceTransaction string            `json:"balance_transaction"`
-	Dispute            *Dispute          `json:"dispute,omitempty"`
-	FailureMessage     string            `json:"failure_message,omitempty"`
-	FailureCode        string            `json:"failure_code,omitempty"`
-	Metadata           map[string]string `json:"metadata,omitempty"`
-	Livemode           bool              `json:"livemode"`
-}
-
-type Dispute struct {
-	Charge             string    `json:"charge"`
-	Livemode           bool      `json:"livemode"`
-	Amount             int       `json:"amount"`
-	Created            UnixTime  `json:"created"`
-	Currency           string    `json:"currency"`
-	Reason             string    `json:"reason"`
-	Status             string    `json:"status"`
-	BalanceTransaction string    `json:"balance_transaction"`
-	Evidence           string    `json:"evidence,omitempty"`
-	EvidenceDueBy      *UnixTime `json:"evidence_due_by,omitempty"`
-	Protected          bool      `json:"is_protected,omitempty"`
+	APIResource
+	ID                 string                         `json:"id"`
+	Description        string                         `json:"description,omitempty"`
+	Amount             int64                          `json:"amount"`
+	Card               *Card                          `json:"card"`
+	Currency           string                         `json:"currency"`
+	Created            UnixTime                       `json:"created"`
+	Customer           Expandable[Customer]           `json:"customer,omitempty"`
+	Invoice            Expandable[Invoice]            `json:"invoice,omitempty"`
+	Paid               bool                           `json:"paid"`
+	Refunded           bool                           `json:"refunded,omitempty"`
+	AmountRefunded     int64                          `json:"amount_refunded,omitempty"`
+	Refunds            []*Refund                      `json:"refunds,omitempty"`
+	BalanceTransaction Expandable[BalanceTransaction] `json:"balance_transaction"`
+	Dispute            *Dispute                       `json:"dispute,omitempty"`
+	FailureMessage     string                         `json:"failure_message,omitempty"`
+	FailureCode        string                         `json:"failure_code,omitempty"`
+	Metadata           map[string]string              `json:"metadata,omitempty"`
+	Livemode           bool                           `json:"livemode"`
+
+	// the Client the Charge was retrieved with
+	client *Client
+}
+
+func (c *Charge) setClient(client *Client) {
+	c.client = client
+}
+
+// backend returns the Client the Charge was retrieved with.
+func (c *Charge) backend() *Client {
+	if c.client == nil {
+		return _default
+	}
+	return c.client
+}
+
+// GetCustomer returns the Customer that was charged, retrieving it if it was
+// not expanded. The retrieved Customer is cached on the Charge, so it is
+// fetched at most once; a Charge must therefore not be resolved from
+// multiple goroutines at the same time.
+func (c *Charge) GetCustomer(ctx context.Context) (*Customer, error) {
+	return c.Customer.resolve(ctx, c.backend(), "/customers")
+}
+
+// GetInvoice returns the Invoice the Charge paid for, retrieving and caching
+// it if it was not expanded. It returns nil if the Charge was not made for an
+// Invoice.
+func (c *Charge) GetInvoice(ctx context.Context) (*Invoice, error) {
+	return c.Invoice.resolve(ctx, c.backend(), "/invoices")
+}
+
+// GetBalanceTransaction returns the BalanceTransaction that moved the funds
+// of the Charge, retrieving and caching it if it was not expanded. It returns
+// nil if the Charge has not been paid.
+func (c *Charge) GetBalanceTransaction(ctx context.Context) (*BalanceTransaction, error) {
+	return c.BalanceTransaction.resolve(ctx, c.backend(), "/balance_transactions")
+}
+
+// ChargeListParams encapsulates options for filtering a list of Charges.
+type ChargeListParams struct {
+	ListParams
+
+	// (Optional) Only return charges for the customer with this ID.
+	Customer string `form:"customer"`
+
+	// (Optional) Only return charges created within this range.
+	Created *DateRange `form:"created"`
 }
 
 // ChargeParams encapsulates options for creating a new Charge.
 type ChargeParams struct {
 	// A positive integer in cents representing how much to charge the card.
-	// The minimum amount is 50 cents.
-	Amount int
+	// The amount must be within the limits for the currency, see
+	// MinimumChargeAmounts.
+	Amount int64 `form:"amount"`
 
 	// 3-letter ISO code for currency. Currently, only 'usd' is supported.
-	Currency string
+	Currency string `form:"currency"`
 
-	// (Optional) Either customer or card is required, but not both The ID of an
-	// existing customer that will be charged in this request.
-	Customer string
+	// (Optional) Either customer or card is required. The ID of an existing
+	// customer that will be charged in this request. If Token is also given,
+	// it may be the ID of one of the customer's cards.
+	Customer string `form:"customer"`
 
-	// (Optional) Credit Card that should be charged.
-	Card *CardParams
+	// (Optional) Credit Card that should be charged. The card is checked with
+	// ValidateCard before the request is sent.
+	Card *CardParams `form:"card"`
 
-	// (Optional) Credit Card token that should be charged.
-	Token string
+	// (Optional) Credit Card token that should be charged. Ignored if Card
+	// is set.
+	Token string `form:"-"`
 
 	// An arbitrary string which you can attach to a charge object. It is
 	// displayed when in the web interface alongside the charge. It's often a
 	// good idea to use an email address as a description for tracking later.
-	Description string
+	Description string `form:"description"`
 
 	// Whether or not to immediately capture the charge. Default is true.
-	Capture *bool
+	Capture *bool `form:"capture"`
 
 	// An arbitrary string to be displayed alongside your company name on your
 	// customer's credit card statement. This may be up to 15 characters.
-	StatementDescription string
+	StatementDescription string `form:"statement_description"`
+
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) The ID of a connected account to transfer the charge to, less
+	// the application fee.
+	Destination string `form:"transfer_data[destination]"`
+
+	// (Optional) The fee, in the smallest currency unit, kept by the platform
+	// when charging to a Destination.
+	ApplicationFeeAmount int64 `form:"application_fee_amount"`
 
-	Metadata map[string]string
+	// (Optional) A string that identifies the charge as part of a group of
+	// transfers.
+	TransferGroup string `form:"transfer_group"`
+
+	// (Optional) A unique key that allows the request to be safely retried
+	// without charging the card twice.
+	IdempotencyKey string
 }
 
 // ChargeClient encapsulates operations for creating, updating, deleting and
 // querying charges using the Stripe REST API.
-type ChargeClient struct{}
+type ChargeClient struct{ api }
 
 // Creates a new credit card Charge.
 //
 // see https://stripe.com/docs/api#create_charge
-func (ChargeClient) Create(params *ChargeParams) (*Charge, error) {
-	charge := Charge{}
-	values := url.Values{
-		"amount":   {strconv.Itoa(params.Amount)},
-		"currency": {params.Currency},
+func (c ChargeClient) Create(ctx context.Context, params *ChargeParams) (*Charge, error) {
+	if err := ValidateAmount(params.Currency, params.Amount); err != nil {
+		return nil, err
 	}
-
-	if params.Description != "" {
-		values.Add("description", params.Description)
-	}
-	if params.Capture != nil && !*params.Capture {
-		values.Add("capture", "false")
-	}
-	if params.StatementDescription != "" {
-		values.Add("statement_description", params.StatementDescription)
-	}
-	appendMetadata(values, params.Metadata)
-
-	// add optional credit card details, if specified
 	if params.Card != nil {
-		appendCardParams(values, true, params.Card)
-	} else if len(params.Token) > 0 {
-		values.Add("card", params.Token)
-	} else {
-		// if no credit card is provide we need to specify the customer
-		values.Add("customer", params.Customer)
+		if err := ValidateCard(params.Card); err != nil {
+			return nil, err
+		}
 	}
 
-	err := query("POST", "/charges", values, &charge)
+	charge := Charge{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
+	err := c.query(ctx, "POST", "/charges", chargeValues(params), &charge)
 	return &charge, err
 }
 
+// chargeValues returns the form-encoded fields of params, with the Token sent
+// as the card only when no Card is given.
+func chargeValues(params *ChargeParams) url.Values {
+	values := formValues(params)
+	if params.Card == nil && params.Token != "" {
+		values.Set("card", params.Token)
+	}
+	return values
+}
+
 // Retrieves the details of a charge with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_charge
-func (ChargeClient) Get(id string) (*Charge, error) {
-	charge := Charge{}
+func (c ChargeClient) Get(ctx context.Context, id string) (*Charge, error) {
+	charge := &Charge{}
+	return charge, c.GetInto(ctx, id, charge)
+}
+
+// GetInto is like Get, but decodes the Charge into v, which may be any
+// type with matching JSON fields.
+func (c ChargeClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/charges/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &charge)
-	return &charge, err
+	return c.query(ctx, "GET", path, nil, v)
 }
 
 // Refunds a charge for the full amount.
 //
 // see https://stripe.com/docs/api#refund_charge
-func (ChargeClient) Refund(id string) (*Charge, error) {
+func (c ChargeClient) Refund(ctx context.Context, id string) (*Charge, error) {
 	values := url.Values{}
 	charge := Charge{}
 	path := "/charges/" + url.QueryEscape(id) + "/refund"
-	err := query("POST", path, values, &charge)
+	err := c.query(ctx, "POST", path, values, &charge)
 	return &charge, err
 }
 
 // Refunds a charge for the specified amount.
 //
 // see https://stripe.com/docs/api#refund_charge
-func (ChargeClient) RefundAmount(id string, amt int) (*Charge, error) {
+func (c ChargeClient) RefundAmount(ctx context.Context, id string, amt int64) (*Charge, error) {
 	values := url.Values{
-		"amount": {strconv.Itoa(amt)},
+		"amount": {strconv.FormatInt(amt, 10)},
+	}
+	charge := Charge{}
+	path := "/charges/" + url.QueryEscape(id) + "/refund"
+	err := c.query(ctx, "POST", path, values, &charge)
+	return &charge, err
+}
+
+// Refunds a charge as described by params, which may be nil to refund the
+// full amount.
+//
+// see https://stripe.com/docs/api#refund_charge
+func (c ChargeClient) RefundWithParams(ctx context.Context, id string, params *RefundParams) (*Charge, error) {
+	if params == nil {
+		params = &RefundParams{}
 	}
 	charge := Charge{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
 	path := "/charges/" + url.QueryEscape(id) + "/refund"
-	err := query("POST", path, values, &charge)
+	err := c.query(ctx, "POST", path, formValues(params), &charge)
 	return &charge, err
 }
 
 // Returns a list of your Charges with the specified range.
 //
 // see https://stripe.com/docs/api#list_charges
-func (c ChargeClient) List(limit int, before, after string) ([]*Charge, bool, error) {
-	return c.list("", limit, before, after)
+func (c ChargeClient) List(ctx context.Context, limit int, before, after string) ([]*Charge, bool, error) {
+	return c.list(ctx, "", limit, before, after)
+}
+
+// ListAll returns an Iter over every Charge matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c ChargeClient) ListAll(ctx context.Context, params *ChargeListParams) *Iter[Charge] {
+	return newIter(ctx, c.backend(), "/charges", params, func(ch *Charge) string { return ch.ID })
+}
+
+// Returns a page of Charges matching the given filters, or of all your
+// Charges when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_charges
+func (c ChargeClient) ListFiltered(ctx context.Context, params *ChargeListParams) (*List[Charge], error) {
+	res := &List[Charge]{}
+	return res, c.query(ctx, "GET", "/charges", formValues(params), res)
 }
 
 // Returns a list of your Charges with the given Customer ID.
 //
 // see https://stripe.com/docs/api#list_charges
-func (c ChargeClient) CustomerList(id string, limit int, before, after string) ([]*Charge, bool, error) {
-	return c.list(id, limit, before, after)
+func (c ChargeClient) CustomerList(ctx context.Context, id string, limit int, before, after string) ([]*Charge, bool, error) {
+	return c.list(ctx, id, limit, before, after)
+}
+
+// ListAllSince returns every Charge created at or after t, oldest first,
+// fetching as many pages as needed. It is intended for incremental exports,
+// which can pass the creation time of the last Charge they saw.
+func (c ChargeClient) ListAllSince(ctx context.Context, t time.Time) ([]*Charge, error) {
+	return listAllSince(ctx, c.backend(), "/charges", "created", t,
+		func(c *Charge) string { return c.ID },
+		func(c *Charge) time.Time { return c.Created.Time })
+}
+
+// ListParallel returns every Charge created within the time range of opts,
+// oldest first, fetching several shards of the range at the same time. This
+// shortens backfills of large accounts considerably, at the cost of making
+// concurrent requests against the account's rate limit.
+func (c ChargeClient) ListParallel(ctx context.Context, opts *ParallelOptions) ([]*Charge, error) {
+	return listParallel(ctx, c.backend(), "/charges", opts,
+		func(c *Charge) string { return c.ID },
+		func(c *Charge) time.Time { return c.Created.Time })
+}
+
+// Stream sends every Charge matching the filters of params, which may be nil,
+// newest first, on the returned channel as pages are fetched in the
+// background, so large exports need not hold every Charge in memory. The
+// Charge channel is closed when the list is exhausted or ctx is done; the
+// error channel then reports why streaming stopped, if it stopped early.
+func (c ChargeClient) Stream(ctx context.Context, params *ChargeListParams) (<-chan *Charge, <-chan error) {
+	return stream(ctx, c.backend(), "/charges", params, func(c *Charge) string { return c.ID })
 }
 
-func (ChargeClient) list(id string, limit int, before, after string) ([]*Charge, bool, error) {
+func (c ChargeClient) list(ctx context.Context, id string, limit int, before, after string) ([]*Charge, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Charge
@@ -184,6 +304,6 @@ func (ChargeClient) list(id string, limit int, before, after string) ([]*Charge,
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/charges", params, &res)
+	err := c.query(ctx, "GET", "/charges", params, &res)
 	return res.Data, res.More, err
 }
@@ -1,6 +1,10 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -36,7 +40,7 @@ var (
 func TestCreateCharge(t *testing.T) {
 
 	// Create the charge
-	resp, err := Charges.Create(&charge1)
+	resp, err := Charges.Create(context.Background(), &charge1)
 
 	if err != nil {
 		t.Errorf("Expected Successful Charge, got Error %s", err.Error())
@@ -60,7 +64,7 @@ func TestCreateCharge(t *testing.T) {
 func TestCreateChargeToken(t *testing.T) {
 
 	// Create a Token for the credit card
-	token, err := Tokens.Create(&token1)
+	token, err := Tokens.Create(context.Background(), &token1)
 	if err != nil {
 		t.Errorf("Expected Token Creation, got Error %s", err.Error())
 	}
@@ -74,7 +78,7 @@ func TestCreateChargeToken(t *testing.T) {
 	}
 
 	// Create the charge
-	_, err = Charges.Create(&charge)
+	_, err = Charges.Create(context.Background(), &charge)
 	if err != nil {
 		t.Errorf("Expected Successful Charge, got Error %s", err.Error())
 	}
@@ -86,8 +90,8 @@ func TestCreateChargeCustomer(t *testing.T) {
 
 	// Create a Customer and defer deletion
 	// This customer should have a credit card setup
-	cust, _ := Customers.Create(&cust4)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust4)
+	defer Customers.Delete(context.Background(), cust.ID)
 	if cust.DefaultCard == "" {
 		t.Errorf("Cannot test charging a customer with no pre-defined Card")
 		return
@@ -102,7 +106,7 @@ func TestCreateChargeCustomer(t *testing.T) {
 	}
 
 	// Create the charge
-	_, err := Charges.Create(&charge)
+	_, err := Charges.Create(context.Background(), &charge)
 	if err != nil {
 		t.Errorf("Expected Successful Charge, got Error %s", err.Error())
 	}
@@ -110,14 +114,14 @@ func TestCreateChargeCustomer(t *testing.T) {
 
 func TestRetrieveCharge(t *testing.T) {
 	// Create the charge
-	resp, err := Charges.Create(&charge1)
+	resp, err := Charges.Create(context.Background(), &charge1)
 	if err != nil {
 		t.Errorf("Expected Successful Charge, got Error %s", err.Error())
 		return
 	}
 
 	// Retrieve the charge from the database
-	_, err = Charges.Get(resp.ID)
+	_, err = Charges.Get(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected to retrieve Charge by ID, got Error %s", err.Error())
 		return
@@ -126,14 +130,14 @@ func TestRetrieveCharge(t *testing.T) {
 
 func TestRefundCharge(t *testing.T) {
 	// Create the charge
-	resp, err := Charges.Create(&charge1)
+	resp, err := Charges.Create(context.Background(), &charge1)
 	if err != nil {
 		t.Errorf("Expected Successful Charge, got Error %s", err.Error())
 		return
 	}
 
 	// Refund the full amount
-	charge, err := Charges.Refund(resp.ID)
+	charge, err := Charges.Refund(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected Refund, got Error %s", err.Error())
 		return
@@ -147,3 +151,43 @@ func TestRefundCharge(t *testing.T) {
 		return
 	}
 }
+
+// TestCardOrToken will test that only the Card is sent when both a Card and a
+// Token are given, and that the Token is sent as the card otherwise.
+func TestCardOrToken(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "obj_1"}`)
+	})
+	ctx := context.Background()
+	card := &CardParams{Number: "4242424242424242", ExpMonth: 5, ExpYear: time.Now().Year() + 1}
+	if _, err := c.Charges.Create(ctx, &ChargeParams{Amount: 400, Currency: USD, Card: card, Token: "tok_1"}); err != nil {
+		t.Fatalf("Create Charge failed: %s", err)
+	}
+	if _, err := c.Customers.Create(ctx, &CustomerParams{Card: card, Token: "tok_1"}); err != nil {
+		t.Fatalf("Create Customer failed: %s", err)
+	}
+	if _, err := c.Subscriptions.Create(ctx, "cus_1", &SubscriptionParams{Plan: "gold", Card: card, Token: "tok_1"}); err != nil {
+		t.Fatalf("Create Subscription failed: %s", err)
+	}
+	for i, form := range srv.forms {
+		if _, ok := form["card"]; ok || form.Get("card[number]") != card.Number {
+			t.Errorf("Expected only the card of %s, got %v", srv.paths[i], form)
+		}
+	}
+
+	n := len(srv.forms)
+	if _, err := c.Charges.Create(ctx, &ChargeParams{Amount: 400, Currency: USD, Token: "tok_1"}); err != nil {
+		t.Fatalf("Create Charge failed: %s", err)
+	}
+	if _, err := c.Customers.Update(ctx, "cus_1", &CustomerParams{Token: "tok_1"}); err != nil {
+		t.Fatalf("Update Customer failed: %s", err)
+	}
+	if _, err := c.Subscriptions.Update(ctx, "cus_1", "sub_1", &SubscriptionParams{Token: "tok_1"}); err != nil {
+		t.Fatalf("Update Subscription failed: %s", err)
+	}
+	for i, form := range srv.forms[n:] {
+		if got := form["card"]; !reflect.DeepEqual(got, []string{"tok_1"}) {
+			t.Errorf("Expected card tok_1 for %s, got %v", srv.paths[n+i], form)
+		}
+	}
+}
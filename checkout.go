@@ -0,0 +1,111 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+)
+
+// CheckoutOptions encapsulates options for charging a customer with
+// Checkout.
+type CheckoutOptions struct {
+	// The customer's email address, used to find an existing customer or to
+	// create a new one.
+	Email string
+
+	// A card token, typically created by Stripe.js, to charge. For an existing
+	// customer the card is added to the customer's cards.
+	Token string
+
+	// A positive integer in the smallest currency unit representing how much
+	// to charge.
+	Amount int64
+
+	// 3-letter ISO code for currency.
+	Currency string
+
+	// (Optional) An arbitrary string to attach to the charge.
+	Description string
+
+	// (Optional) Metadata to attach to the charge.
+	Metadata map[string]string
+
+	// (Optional) A unique key for this checkout. If given, the customer and
+	// charge are created with keys derived from it, so the whole checkout may
+	// be safely retried after a failure.
+	IdempotencyKey string
+}
+
+// CheckoutResult describes the outcome of a successful Checkout.
+type CheckoutResult struct {
+	Customer *Customer
+
+	// The card that was charged. It is nil if the customer was created by
+	// this checkout, in which case the customer's default card was charged.
+	Card *Card
+
+	Charge *Charge
+
+	// Whether the customer was created by this checkout.
+	NewCustomer bool
+}
+
+// Checkout charges a card for a customer identified by email address. The
+// first customer with a matching email is used, and the card is added to its
+// cards; if there is none, a new customer is created with the card. The charge
+// is then made to the customer, so its card can be charged again later.
+//
+// PaymentIntents are not wrapped by this package yet, so cards that require
+// authentication will be declined.
+func Checkout(ctx context.Context, opts CheckoutOptions) (*CheckoutResult, error) {
+	return _default.Checkout(ctx, opts)
+}
+
+// Checkout is like the package-level Checkout, using the Client.
+func (c *Client) Checkout(ctx context.Context, opts CheckoutOptions) (*CheckoutResult, error) {
+	if opts.Email == "" || opts.Token == "" {
+		return nil, errors.New("stripe: checkout requires an email and token")
+	}
+	if err := ValidateAmount(opts.Currency, opts.Amount); err != nil {
+		return nil, err
+	}
+	key := func(step string) string {
+		if opts.IdempotencyKey == "" {
+			return ""
+		}
+		return opts.IdempotencyKey + "-" + step
+	}
+
+	// find or create the customer, with the card attached
+	res := &CheckoutResult{}
+	var err error
+	res.Customer, res.NewCustomer, err = c.Customers.GetOrCreateByEmail(ctx, opts.Email, &CustomerParams{
+		Token:          opts.Token,
+		IdempotencyKey: key("customer"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	charge := &ChargeParams{
+		Amount:         opts.Amount,
+		Currency:       opts.Currency,
+		Description:    opts.Description,
+		Metadata:       opts.Metadata,
+		IdempotencyKey: key("charge"),
+	}
+	if !res.NewCustomer {
+		if res.Card, err = c.Cards.Create(ctx, res.Customer.ID, opts.Token, nil); err != nil {
+			return res, err
+		}
+		charge.Token = res.Card.ID
+	}
+	charge.Customer = res.Customer.ID
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	res.Charge, err = c.Charges.Create(ctx, charge)
+	if err != nil {
+		res.Charge = nil
+	}
+	return res, err
+}
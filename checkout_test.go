@@ -0,0 +1,65 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestCheckout will test that Checkout reuses a customer found by email,
+// creates one otherwise, and sends derived idempotency keys.
+func TestCheckout(t *testing.T) {
+	keys := map[string]string{}
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys[r.URL.Path] = r.Header.Get("Idempotency-Key")
+		switch {
+		case r.URL.Path == "/v1/customers" && r.Method == "GET":
+			if r.Form.Get("email") == "known@example.com" {
+				fmt.Fprint(w, `{"data": [{"id": "cus_known"}]}`)
+			} else {
+				fmt.Fprint(w, `{"data": []}`)
+			}
+		case r.URL.Path == "/v1/customers":
+			fmt.Fprintf(w, `{"id": "cus_new", "email": "%s"}`, r.Form.Get("email"))
+		case r.URL.Path == "/v1/customers/cus_known/cards":
+			fmt.Fprint(w, `{"id": "card_1"}`)
+		case r.URL.Path == "/v1/charges":
+			fmt.Fprintf(w, `{"id": "ch_1", "customer": "%s", "card": {"id": "%s"}}`, r.Form.Get("customer"), r.Form.Get("card"))
+		default:
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"error": {"message": "not found"}}`)
+		}
+	})
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	opts := CheckoutOptions{Email: "known@example.com", Token: "tok_1", Amount: 1000, Currency: USD, IdempotencyKey: "order_1"}
+	res, err := Checkout(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Checkout failed: %s", err)
+	}
+	if res.NewCustomer || res.Customer.ID != "cus_known" || res.Card.ID != "card_1" {
+		t.Errorf("Expected existing customer cus_known charged with card_1, got %+v", res)
+	}
+	if res.Charge.Card.ID != "card_1" {
+		t.Errorf("Expected charge to card_1, got %s", res.Charge.Card.ID)
+	}
+	if keys["/v1/charges"] != "order_1-charge" {
+		t.Errorf("Expected charge idempotency key order_1-charge, got %q", keys["/v1/charges"])
+	}
+
+	opts.Email = "new@example.com"
+	res, err = Checkout(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Checkout failed: %s", err)
+	}
+	if !res.NewCustomer || res.Customer.ID != "cus_new" || res.Card != nil {
+		t.Errorf("Expected new customer cus_new, got %+v", res)
+	}
+	if keys["/v1/customers"] != "order_1-customer" {
+		t.Errorf("Expected customer idempotency key order_1-customer, got %q", keys["/v1/customers"])
+	}
+}
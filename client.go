@@ -0,0 +1,226 @@
+package stripe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client is a connection to the Stripe API with its own API key and
+// settings, for programs that talk to Stripe as more than one account, ie a
+// Connect platform acting for each of its tenants. The package-level APIs,
+// such as Charges and Customers, use a default Client that is configured with
+// the package-level setters.
+type Client struct {
+	// The API Key used to authenticate requests.
+	Key string
+
+	// The URL of the Stripe API.
+	URL string
+
+	// The URL of the Stripe file upload API.
+	UploadURL string
+
+	// (Optional) The API version requests are made with, see SetAPIVersion.
+	APIVersion string
+
+	// (Optional) The ID of the connected account requests are made on behalf
+	// of, sent as the Stripe-Account header. Default is the account that owns
+	// Key.
+	StripeAccount string
+
+	// (Optional) The application making requests, reported to Stripe in the
+	// User-Agent, see SetAppInfo.
+	AppInfo *AppInfo
+
+	// (Optional) The http.Client used to submit requests. Default is
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// (Optional) The policy used to retry failed requests. Default is no
+	// retries, see SetRetryPolicy.
+	RetryPolicy RetryPolicy
+
+	// (Optional) Functions called with every request and response, see
+	// AddRequestInterceptor and AddResponseInterceptor.
+	RequestInterceptors  []RequestInterceptor
+	ResponseInterceptors []ResponseInterceptor
+
+	// (Optional) The Logger that receives a RequestLog for every request, see
+	// SetLogger.
+	Logger Logger
+
+	// (Optional) The RequestObserver notified of every request, see
+	// SetObserver.
+	Observer RequestObserver
+
+	// (Optional) How long to wait, in total, for rate limiting to clear, see
+	// SetRateLimitWait.
+	RateLimitWait time.Duration
+
+	// (Optional) Whether identical concurrent GET requests are coalesced, see
+	// SetCoalescing.
+	Coalescing bool
+
+	// (Optional) The maximum size of a response body, see SetMaxResponseSize.
+	MaxResponseSize int64
+
+	// (Optional) How long a request may take in total, see SetTimeout.
+	Timeout time.Duration
+
+	// (Optional) How long to wait for response headers, see
+	// SetResponseHeaderTimeout.
+	ResponseHeaderTimeout time.Duration
+
+	// (Optional) How responses are checked against their types, see
+	// SetStrictMode.
+	StrictMode StrictMode
+
+	// (Optional) The function called with the SchemaError of mismatched
+	// responses in StrictLog mode, see SetSchemaErrorHandler.
+	SchemaErrorHandler func(*SchemaError)
+
+	// Available APIs
+	Accounts                    *AccountClient
+	AccountLinks                *AccountLinkClient
+	ApplicationFees             *ApplicationFeeClient
+	Balances                    *BalanceClient
+	BankAccounts                *BankAccountClient
+	BalanceTransactions         *BalanceTransactionClient
+	Capabilities                *CapabilityClient
+	Charges                     *ChargeClient
+	Coupons                     *CouponClient
+	Customers                   *CustomerClient
+	CustomerBalanceTransactions *CustomerBalanceTransactionClient
+	Disputes                    *DisputeClient
+	Events                      *EventClient
+	FeeRefunds                  *FeeRefundClient
+	Files                       *FileClient
+	FileLinks                   *FileLinkClient
+	Invoices                    *InvoiceClient
+	InvoiceItems                *InvoiceItemClient
+	Payouts                     *PayoutClient
+	Persons                     *PersonClient
+	Plans                       *PlanClient
+	Prices                      *PriceClient
+	Products                    *ProductClient
+	PromotionCodes              *PromotionCodeClient
+	Recipients                  *RecipientClient
+	Refunds                     *RefundClient
+	SourceTransactions          *SourceTransactionClient
+	Subscriptions               *SubscriptionClient
+	SubscriptionItems           *SubscriptionItemClient
+	SubscriptionSchedules       *SubscriptionScheduleClient
+	TaxCalculations             *TaxCalculationClient
+	TaxIDs                      *TaxIDClient
+	TaxRates                    *TaxRateClient
+	TaxTransactions             *TaxTransactionClient
+	Tokens                      *TokenClient
+	Transfers                   *TransferClient
+	TransferReversals           *TransferReversalClient
+	UsageRecords                *UsageRecordClient
+	Cards                       *CardClient
+	ExternalAccounts            *ExternalAccountClient
+	WebhookEndpoints            *WebhookEndpointClient
+
+	// the GET requests currently in flight, when coalescing is enabled
+	inflight flightGroup
+}
+
+// New returns a Client that authenticates with the given API key.
+func New(key string) *Client {
+	c := &Client{
+		Key:        key,
+		URL:        "https://api.stripe.com",
+		UploadURL:  "https://files.stripe.com",
+		APIVersion: apiVersion,
+	}
+	a := api{c}
+	c.Accounts = &AccountClient{a}
+	c.AccountLinks = &AccountLinkClient{a}
+	c.ApplicationFees = &ApplicationFeeClient{a}
+	c.Balances = &BalanceClient{a}
+	c.BankAccounts = &BankAccountClient{a}
+	c.BalanceTransactions = &BalanceTransactionClient{a}
+	c.Capabilities = &CapabilityClient{a}
+	c.Charges = &ChargeClient{a}
+	c.Coupons = &CouponClient{a}
+	c.Customers = &CustomerClient{a}
+	c.CustomerBalanceTransactions = &CustomerBalanceTransactionClient{a}
+	c.Disputes = &DisputeClient{a}
+	c.Events = &EventClient{a}
+	c.FeeRefunds = &FeeRefundClient{a}
+	c.Files = &FileClient{a}
+	c.FileLinks = &FileLinkClient{a}
+	c.Invoices = &InvoiceClient{a}
+	c.InvoiceItems = &InvoiceItemClient{a}
+	c.Payouts = &PayoutClient{a}
+	c.Persons = &PersonClient{a}
+	c.Plans = &PlanClient{a}
+	c.Prices = &PriceClient{a}
+	c.Products = &ProductClient{a}
+	c.PromotionCodes = &PromotionCodeClient{a}
+	c.Recipients = &RecipientClient{a}
+	c.Refunds = &RefundClient{a}
+	c.SourceTransactions = &SourceTransactionClient{a}
+	c.Subscriptions = &SubscriptionClient{a}
+	c.SubscriptionItems = &SubscriptionItemClient{a}
+	c.SubscriptionSchedules = &SubscriptionScheduleClient{a}
+	c.TaxCalculations = &TaxCalculationClient{a}
+	c.TaxIDs = &TaxIDClient{a}
+	c.TaxRates = &TaxRateClient{a}
+	c.TaxTransactions = &TaxTransactionClient{a}
+	c.Tokens = &TokenClient{a}
+	c.Transfers = &TransferClient{a}
+	c.TransferReversals = &TransferReversalClient{a}
+	c.UsageRecords = &UsageRecordClient{a}
+	c.Cards = &CardClient{a}
+	c.ExternalAccounts = &ExternalAccountClient{a}
+	c.WebhookEndpoints = &WebhookEndpointClient{a}
+	return c
+}
+
+// A RequestInterceptor is called with each request before it is submitted.
+type RequestInterceptor func(*http.Request) error
+
+// A ResponseInterceptor is called with each response before its body is read.
+type ResponseInterceptor func(*http.Response) error
+
+// api is embedded in each of the API clients, submitting their requests with
+// the Client they belong to. API clients created with new, rather than by a
+// Client, use the default client.
+type api struct {
+	client *Client
+}
+
+// backend returns the Client the API client belongs to.
+func (a api) backend() *Client {
+	if a.client == nil {
+		return _default
+	}
+	return a.client
+}
+
+func (a api) query(ctx context.Context, method, path string, values url.Values, v interface{}) error {
+	return a.backend().query(ctx, method, path, values, v)
+}
+
+func (a api) upload(ctx context.Context, path string, fields url.Values, filename string, file io.Reader, v interface{}) error {
+	return a.backend().upload(ctx, path, fields, filename, file, v)
+}
+
+// clientSetter is implemented by objects that make requests of their own, ie
+// to retrieve references that were not expanded, so that they are made with
+// the Client the object was retrieved with.
+type clientSetter interface {
+	setClient(c *Client)
+}
+
+// setClient records c on v, if v makes requests of its own.
+func setClient(v interface{}, c *Client) {
+	if s, ok := v.(clientSetter); ok {
+		s.setClient(c)
+	}
+}
@@ -0,0 +1,271 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestClient will test that each Client authenticates with its own key, and
+// that references are resolved with the Client that retrieved the object.
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch r.URL.Path {
+		case "/v1/charges/ch_1":
+			fmt.Fprintf(w, `{"id": "ch_1", "description": "%s", "customer": "cus_1"}`, key)
+		case "/v1/customers/cus_1":
+			fmt.Fprintf(w, `{"id": "cus_1", "description": "%s"}`, key)
+		}
+	}))
+	defer srv.Close()
+
+	a, b := New("sk_test_a"), New("sk_test_b")
+	a.URL, b.URL = srv.URL, srv.URL
+
+	charge, err := a.Charges.Get(context.Background(), "ch_1")
+	if err != nil || charge.Description != "sk_test_a" {
+		t.Fatalf("Expected charge with key sk_test_a, got %v %v", charge, err)
+	}
+	cust, err := charge.GetCustomer(context.Background())
+	if err != nil || cust.Description != "sk_test_a" {
+		t.Errorf("Expected customer resolved with key sk_test_a, got %v %v", cust, err)
+	}
+
+	cust, err = b.Customers.Get(context.Background(), "cus_1")
+	if err != nil || cust.Description != "sk_test_b" {
+		t.Errorf("Expected customer with key sk_test_b, got %v %v", cust, err)
+	}
+
+	// the key must not leak into the errors of a failed request
+	srv.Close()
+	if _, err := a.Charges.Get(context.Background(), "ch_1"); err == nil || strings.Contains(err.Error(), "sk_test_a") {
+		t.Errorf("Expected error without the key, got %v", err)
+	}
+}
+
+// testServer is an httptest.Server that records the requests submitted to
+// it by the Client of a test.
+type testServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	// The method and path of each request, ie POST /v1/charges.
+	paths []string
+
+	// The parameters of each request, from its form-encoded body, or from its
+	// URL for GET requests.
+	forms []url.Values
+}
+
+// newTestServer starts a testServer that records each request before passing
+// it to handler, with its parameters in r.Form, and returns it with a Client
+// that submits its requests to it. The server is closed when the test ends.
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*testServer, *Client) {
+	srv := &testServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		form, _ := url.ParseQuery(string(body))
+		if r.Method == "GET" {
+			form = r.URL.Query()
+		}
+		srv.mu.Lock()
+		srv.paths = append(srv.paths, r.Method+" "+r.URL.Path)
+		srv.forms = append(srv.forms, form)
+		srv.mu.Unlock()
+		r.Form = form
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("sk_test_dummy")
+	c.URL = srv.URL
+	return srv, c
+}
+
+// newUploadServer starts a server for the file upload API of c, which
+// records each uploaded file as "<purpose> <filename> <contents>" and
+// responds with a File whose ID is file_<n>. The server is closed when the
+// test ends.
+func newUploadServer(t *testing.T, c *Client) *[]string {
+	uploads := new([]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/files" {
+			t.Errorf("Expected an upload to /v1/files, got %s", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Expected a multipart upload, got %v", err)
+			return
+		}
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Expected a file part, got %v", err)
+			return
+		}
+		contents, _ := ioutil.ReadAll(f)
+		*uploads = append(*uploads, fmt.Sprintf("%s %s %s", r.FormValue("purpose"), header.Filename, contents))
+		fmt.Fprintf(w, `{"id": "file_%d", "purpose": %q}`, len(*uploads), r.FormValue("purpose"))
+	}))
+	t.Cleanup(srv.Close)
+
+	c.UploadURL = srv.URL
+	return uploads
+}
+
+type countingTransport struct {
+	n int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// TestHTTPClient will test that requests are submitted with the configured
+// http.Client.
+func TestHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "gold"}`)
+	}))
+	defer srv.Close()
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	transport := &countingTransport{}
+	SetHTTPClient(&http.Client{Transport: transport})
+	defer SetHTTPClient(nil)
+
+	if _, err := Plans.Get(context.Background(), "gold"); err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+	if transport.n != 1 {
+		t.Errorf("Expected 1 request through the custom transport, got %d", transport.n)
+	}
+}
+
+// TestAPIVersion will test that requests are made with the API version of
+// the Client, unless overridden for the request.
+func TestAPIVersion(t *testing.T) {
+	var version string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		version = r.Header.Get("Stripe-Version")
+		fmt.Fprint(w, `{"id": "ch_1"}`)
+	})
+	ctx := context.Background()
+
+	if c.Charges.Get(ctx, "ch_1"); version != apiVersion {
+		t.Errorf("Expected default version %s, got %s", apiVersion, version)
+	}
+	c.APIVersion = "2015-01-01"
+	if c.Charges.Get(ctx, "ch_1"); version != "2015-01-01" {
+		t.Errorf("Expected client version 2015-01-01, got %s", version)
+	}
+	if c.Charges.Get(WithAPIVersion(ctx, "2016-01-01"), "ch_1"); version != "2016-01-01" {
+		t.Errorf("Expected request version 2016-01-01, got %s", version)
+	}
+}
+
+// TestStripeAccount will test that requests are made on behalf of the
+// connected account of the Client, unless overridden for the request.
+func TestStripeAccount(t *testing.T) {
+	var account string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		account = r.Header.Get("Stripe-Account")
+		fmt.Fprint(w, `{"id": "cus_1"}`)
+	})
+	ctx := context.Background()
+
+	if c.Customers.Get(ctx, "cus_1"); account != "" {
+		t.Errorf("Expected no Stripe-Account, got %s", account)
+	}
+	c.StripeAccount = "acct_1"
+	if c.Customers.Get(ctx, "cus_1"); account != "acct_1" {
+		t.Errorf("Expected client account acct_1, got %s", account)
+	}
+	if c.Customers.Get(WithStripeAccount(ctx, "acct_2"), "cus_1"); account != "acct_2" {
+		t.Errorf("Expected request account acct_2, got %s", account)
+	}
+}
+
+// TestInterceptors will test that interceptors are called with every request
+// and response, and that their errors fail the request.
+func TestInterceptors(t *testing.T) {
+	var trace string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace = r.Header.Get("X-Trace-Id")
+		fmt.Fprint(w, `{"id": "cus_1"}`)
+	}))
+	defer srv.Close()
+
+	var statuses []int
+	c := New("sk_test_dummy")
+	c.URL = srv.URL
+	c.RequestInterceptors = append(c.RequestInterceptors, func(r *http.Request) error {
+		r.Header.Set("X-Trace-Id", "trace_1")
+		return nil
+	})
+	c.ResponseInterceptors = append(c.ResponseInterceptors, func(r *http.Response) error {
+		statuses = append(statuses, r.StatusCode)
+		return nil
+	})
+
+	if _, err := c.Customers.Get(context.Background(), "cus_1"); err != nil {
+		t.Fatalf("Expected customer, got %v", err)
+	}
+	if trace != "trace_1" || len(statuses) != 1 || statuses[0] != 200 {
+		t.Errorf("Expected intercepted request and response, got %q %v", trace, statuses)
+	}
+
+	denied := errors.New("denied")
+	c.RequestInterceptors = append(c.RequestInterceptors, func(r *http.Request) error { return denied })
+	if _, err := c.Customers.Get(context.Background(), "cus_1"); err != denied {
+		t.Errorf("Expected interceptor error, got %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Errorf("Expected denied request not to be submitted")
+	}
+}
+
+// TestAppInfo will test that requests identify this library, and the
+// application when one is set, in the User-Agent headers.
+func TestAppInfo(t *testing.T) {
+	var ua, client string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ua, client = r.Header.Get("User-Agent"), r.Header.Get("X-Stripe-Client-User-Agent")
+		fmt.Fprint(w, `{"id": "cus_1"}`)
+	})
+	ctx := context.Background()
+
+	if c.Customers.Get(ctx, "cus_1"); ua != "Stripe/v1 GoBindings/"+clientVersion {
+		t.Errorf("Expected library User-Agent, got %s", ua)
+	}
+	if strings.Contains(client, "application") {
+		t.Errorf("Expected no application, got %s", client)
+	}
+
+	c.AppInfo = &AppInfo{Name: "MyPlugin", Version: "1.2.3", URL: "https://example.com"}
+	c.Customers.Get(ctx, "cus_1")
+	if want := "Stripe/v1 GoBindings/" + clientVersion + " MyPlugin/1.2.3 (https://example.com)"; ua != want {
+		t.Errorf("Expected User-Agent %s, got %s", want, ua)
+	}
+	info := struct {
+		Lang        string   `json:"lang"`
+		Application *AppInfo `json:"application"`
+	}{}
+	if err := json.Unmarshal([]byte(client), &info); err != nil || info.Lang != "go" || *info.Application != *c.AppInfo {
+		t.Errorf("Expected client User-Agent with application, got %s", client)
+	}
+}
@@ -0,0 +1,32 @@
+// Command stripe-seed provisions a test mode dataset in the Stripe account
+// of the STRIPE_API_KEY environment variable.
+//
+//	STRIPE_API_KEY=sk_test_... stripe-seed -prefix demo -customers 20
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cupcake/stripe/seed"
+)
+
+func main() {
+	opts := &seed.Options{Key: os.Getenv("STRIPE_API_KEY")}
+	flag.StringVar(&opts.Prefix, "prefix", "seed", "prefix of product and plan IDs and customer emails")
+	flag.IntVar(&opts.Customers, "customers", 8, "number of customers to create")
+	flag.IntVar(&opts.Disputes, "disputes", 2, "number of disputed charges to create")
+	flag.Parse()
+
+	data, err := seed.Run(context.Background(), opts)
+	if data != nil {
+		fmt.Printf("products: %d, plans: %d, customers: %d, subscriptions: %d, disputed charges: %d\n",
+			len(data.Products), len(data.Plans), len(data.Customers), len(data.Subscriptions), len(data.Disputed))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "stripe-seed:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent calls with the same key, so that only
+// the first caller does the work and the others share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a call that is in progress, or has completed once done is
+// closed.
+type flight struct {
+	done chan struct{}
+	resp *Response
+	body []byte
+	err  error
+}
+
+// do starts fn in the background and returns its result, unless a call with
+// the same key is already in flight, in which case it waits for and returns
+// that call's result instead. Every caller, including the one that started
+// fn, gives up when its own ctx is done, so fn must not depend on the ctx of
+// any one caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*Response, []byte, error)) (*Response, []byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f, ok := g.calls[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		g.calls[key] = f
+		go func() {
+			f.resp, f.body, f.err = fn()
+
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.resp, f.body, f.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// coalesceKey identifies the requests that can share a response: those for
+// the same URL, including its query string and credentials, and with the
+// same headers.
+func coalesceKey(req *http.Request) string {
+	key := req.Method + " " + req.URL.String()
+	for _, h := range []string{"Authorization", "Stripe-Account", "Stripe-Version"} {
+		key += "\n" + req.Header.Get(h)
+	}
+	return key
+}
@@ -0,0 +1,94 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCoalescing will test that identical GET requests made while one is in
+// flight share a single response.
+func TestCoalescing(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		fmt.Fprint(w, `{"id": "plan1", "amount": 1}`)
+	}))
+	defer srv.Close()
+
+	url := _default.URL
+	SetUrl(srv.URL)
+	SetCoalescing(true)
+	defer SetUrl(url)
+	defer SetCoalescing(false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			plan, err := Plans.Get(context.Background(), "plan1")
+			if err != nil || plan.Amount != 1 {
+				t.Errorf("Expected Plan plan1, got %+v, %v", plan, err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if hits != 1 {
+		t.Errorf("Expected 1 request, got %d", hits)
+	}
+}
+
+// TestCoalescingCanceled will test that the callers sharing a coalesced
+// request still get its response when the caller that started it gives up.
+func TestCoalescingCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, `{"id": "plan1", "amount": 1}`)
+	}))
+	defer srv.Close()
+
+	url := _default.URL
+	SetUrl(srv.URL)
+	SetCoalescing(true)
+	defer SetUrl(url)
+	defer SetCoalescing(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := Plans.Get(ctx, "plan1")
+		leader <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	follower := make(chan error)
+	go func() {
+		plan, err := Plans.Get(context.Background(), "plan1")
+		if err == nil && plan.Amount != 1 {
+			err = fmt.Errorf("unexpected Plan %+v", plan)
+		}
+		follower <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-leader; err != context.Canceled {
+		t.Errorf("Expected the leader to be canceled, got %v", err)
+	}
+	close(release)
+	if err := <-follower; err != nil {
+		t.Errorf("Expected Plan plan1 for the follower, got %v", err)
+	}
+}
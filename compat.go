@@ -0,0 +1,198 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// schemaVersion is the API version the types of this package are written
+// against. Responses in other versions are normalized to its shape.
+const schemaVersion = "2014-03-28"
+
+// migration describes a change to the shape of an object made in an API
+// version. toOld rewrites an object from the shape after the change to the
+// shape before it, and toNew does the opposite. Both must leave objects that
+// are already in the target shape untouched, and report whether they changed
+// anything.
+type migration struct {
+	version string
+	object  string
+	toOld   func(obj map[string]interface{}) bool
+	toNew   func(obj map[string]interface{}) bool
+}
+
+// migrations lists the known changes to the shape of objects, oldest first.
+//
+// see https://stripe.com/docs/upgrades
+var migrations = []migration{
+	{"2014-03-28", "list", nil, rename("count", "total_count")},
+	{"2014-05-19", "application_fee", listToArray("refunds"), nil},
+	{"2014-05-19", "charge", listToArray("refunds"), nil},
+	{"2014-12-08", "dispute", evidenceDueBy, nil},
+	{"2014-12-17", "charge", rename("statement_descriptor", "statement_description"), nil},
+	{"2014-12-17", "plan", rename("statement_descriptor", "statement_description"), nil},
+	{"2015-02-18", "charge", sourceToCard, nil},
+	{"2015-02-18", "customer", sourcesToCards, nil},
+	{"2018-02-05", "plan", rename("nickname", "name"), nil},
+	{"2019-03-14", "invoice", rename("created", "date"), nil},
+	{"2019-10-17", "customer", rename("balance", "account_balance"), nil},
+	{"2020-08-27", "line_item", priceToPlan, nil},
+	{"2020-08-27", "subscription", priceToPlan, nil},
+	{"2020-08-27", "subscription_item", priceToPlan, nil},
+}
+
+// rename returns a migration function that copies the value of the field
+// from to the field to, if to is not set.
+func rename(from, to string) func(map[string]interface{}) bool {
+	return func(obj map[string]interface{}) bool {
+		v, ok := obj[from]
+		if _, exists := obj[to]; !ok || exists {
+			return false
+		}
+		obj[to] = v
+		return true
+	}
+}
+
+// listToArray returns a migration function that replaces the list object in
+// the field with the array of its data.
+func listToArray(field string) func(map[string]interface{}) bool {
+	return func(obj map[string]interface{}) bool {
+		list, ok := obj[field].(map[string]interface{})
+		if !ok || list["object"] != "list" {
+			return false
+		}
+		data, ok := list["data"].([]interface{})
+		if !ok {
+			return false
+		}
+		obj[field] = data
+		return true
+	}
+}
+
+// evidenceDueBy restores the evidence deadline of a dispute from its
+// evidence details.
+func evidenceDueBy(obj map[string]interface{}) bool {
+	details, ok := obj["evidence_details"].(map[string]interface{})
+	if _, exists := obj["evidence_due_by"]; !ok || exists || details["due_by"] == nil {
+		return false
+	}
+	obj["evidence_due_by"] = details["due_by"]
+	return true
+}
+
+// sourceToCard restores the card of a charge from its payment source.
+func sourceToCard(obj map[string]interface{}) bool {
+	src, ok := obj["source"].(map[string]interface{})
+	if _, exists := obj["card"]; !ok || exists || src["object"] != "card" {
+		return false
+	}
+	obj["card"] = src
+	return true
+}
+
+// sourcesToCards restores the cards of a customer from its payment sources.
+func sourcesToCards(obj map[string]interface{}) bool {
+	changed := rename("default_source", "default_card")(obj)
+	if _, exists := obj["cards"]; !exists {
+		if sources, ok := obj["sources"].(map[string]interface{}); ok {
+			obj["cards"] = sources
+			changed = true
+		}
+	}
+	return changed
+}
+
+// priceToPlan restores the plan of a subscription or invoice line item from
+// its price.
+func priceToPlan(obj map[string]interface{}) bool {
+	price, ok := obj["price"].(map[string]interface{})
+	if plan, exists := obj["plan"]; !ok || (exists && plan != nil) {
+		return false
+	}
+	plan := map[string]interface{}{
+		"object":   "plan",
+		"id":       price["id"],
+		"name":     price["nickname"],
+		"amount":   price["unit_amount"],
+		"currency": price["currency"],
+		"created":  price["created"],
+		"livemode": price["livemode"],
+		"metadata": price["metadata"],
+
+		"billing_scheme":  price["billing_scheme"],
+		"tiers":           price["tiers"],
+		"tiers_mode":      price["tiers_mode"],
+		"transform_usage": price["transform_quantity"],
+		"product":         price["product"],
+	}
+	if recurring, ok := price["recurring"].(map[string]interface{}); ok {
+		plan["interval"] = recurring["interval"]
+		plan["interval_count"] = recurring["interval_count"]
+		plan["trial_period_days"] = recurring["trial_period_days"]
+	}
+	obj["plan"] = plan
+	return true
+}
+
+// normalize rewrites a JSON response in the given API version to the shape
+// of schemaVersion. An empty version, ie for a payload of unknown version,
+// applies every migration whose fields are present. The body is returned
+// unchanged if nothing applies.
+func normalize(body []byte, version string) []byte {
+	if version == schemaVersion {
+		return body
+	}
+	var raw interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return body
+	}
+	if !migrate(raw, version) {
+		return body
+	}
+	res, err := json.Marshal(raw)
+	if err != nil {
+		return body
+	}
+	return res
+}
+
+// migrate applies the migrations between version and schemaVersion to each
+// object in v, including nested objects, reporting whether any changed.
+func migrate(v interface{}, version string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			if migrate(child, version) {
+				changed = true
+			}
+		}
+		object, _ := v["object"].(string)
+		for _, m := range migrations {
+			if m.object != object {
+				continue
+			}
+			var fn func(map[string]interface{}) bool
+			switch {
+			case schemaVersion < m.version && (version == "" || version >= m.version):
+				fn = m.toOld
+			case schemaVersion >= m.version && (version == "" || version < m.version):
+				fn = m.toNew
+			}
+			if fn != nil && fn(v) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if migrate(child, version) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,92 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestNormalize will test that responses in newer and older API versions are
+// decoded into the package's types.
+func TestNormalize(t *testing.T) {
+	body := []byte(`{
+		"object": "customer",
+		"id": "cus_1",
+		"balance": -100,
+		"default_source": "card_1",
+		"sources": {"object": "list", "data": [{"object": "card", "id": "card_1"}]},
+		"subscriptions": {"object": "list", "data": [{
+			"object": "subscription",
+			"id": "sub_1",
+			"plan": null,
+			"price": {"id": "price_1", "unit_amount": 900, "currency": "usd", "recurring": {"interval": "month", "interval_count": 1}}
+		}]}
+	}`)
+	cust := &Customer{}
+	if err := json.Unmarshal(normalize(body, "2020-08-27"), cust); err != nil {
+		t.Fatalf("Unmarshal failed: %s", err)
+	}
+	if cust.Balance != -100 || cust.DefaultCard != "card_1" {
+		t.Errorf("Expected balance -100 and default card card_1, got %d and %s", cust.Balance, cust.DefaultCard)
+	}
+	if cust.Cards == nil || len(cust.Cards.Data) != 1 || cust.Cards.Data[0].ID != "card_1" {
+		t.Errorf("Expected cards from sources, got %+v", cust.Cards)
+	}
+	plan := cust.Subscriptions.Data[0].Plan
+	if plan == nil || plan.ID != "price_1" || plan.Amount != 900 || plan.Interval != IntervalMonth {
+		t.Errorf("Expected plan from price, got %+v", plan)
+	}
+
+	// a change made after the response's version is not applied
+	if string(normalize(body, "2015-02-18")) == string(body) {
+		t.Errorf("Expected sources to be normalized for 2015-02-18")
+	}
+	old := &Customer{}
+	if err := json.Unmarshal(normalize(body, "2015-02-18"), old); err != nil || old.Subscriptions.Data[0].Plan != nil {
+		t.Errorf("Expected no plan from price for 2015-02-18, got %v", err)
+	}
+
+	// an older list envelope
+	list := struct {
+		ListObject
+		Data []*Charge
+	}{}
+	if err := json.Unmarshal(normalize([]byte(`{"object": "list", "count": 3, "data": []}`), "2013-08-13"), &list); err != nil || list.TotalCount != 3 {
+		t.Errorf("Expected total count 3 from count, got %d %v", list.TotalCount, err)
+	}
+
+	// refunds of a charge as a list
+	ch := &Charge{}
+	if err := json.Unmarshal(normalize([]byte(`{"object": "charge", "id": "ch_1", "refunds": {"object": "list", "data": [{"object": "refund", "id": "re_1"}]}}`), "2014-05-19"), ch); err != nil || len(ch.Refunds) != 1 || ch.Refunds[0].ID != "re_1" {
+		t.Errorf("Expected refunds re_1 from list, got %v %v", ch.Refunds, err)
+	}
+
+	// the evidence deadline of a dispute from its details
+	dp := &Dispute{}
+	if err := json.Unmarshal(normalize([]byte(`{"object": "dispute", "id": "dp_1", "evidence_details": {"due_by": 1418000000}}`), "2014-12-08"), dp); err != nil || dp.EvidenceDueBy == nil || dp.EvidenceDueBy.Unix() != 1418000000 {
+		t.Errorf("Expected evidence due by 1418000000, got %v %v", dp.EvidenceDueBy, err)
+	}
+
+	// responses in the package's version are left alone
+	if got := normalize(body, schemaVersion); string(got) != string(body) {
+		t.Errorf("Expected body in %s to be unchanged", schemaVersion)
+	}
+}
+
+// TestUnmarshalDisputeEvidence will test that the evidence of a dispute is
+// decoded both as the string of the package's version and as an object.
+func TestUnmarshalDisputeEvidence(t *testing.T) {
+	ch := &Charge{}
+	data := `{"id": "ch_1", "dispute": {"charge": "ch_1", "evidence": "Shipped on time", "evidence_due_by": 1400000000}}`
+	if err := json.Unmarshal([]byte(data), ch); err != nil {
+		t.Fatalf("Unmarshal failed: %s", err)
+	}
+	dp := ch.Dispute
+	if dp == nil || dp.Evidence == nil || dp.Evidence.UncategorizedText != "Shipped on time" || dp.EvidenceDueBy == nil {
+		t.Errorf("Expected string evidence and its deadline, got %+v", dp)
+	}
+
+	dp = &Dispute{}
+	if err := json.Unmarshal([]byte(`{"evidence": {"receipt": "file_1"}}`), dp); err != nil || dp.Evidence == nil || dp.Evidence.Receipt != "file_1" {
+		t.Errorf("Expected receipt file_1 in evidence, got %+v %v", dp.Evidence, err)
+	}
+}
@@ -0,0 +1,81 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// FeePolicy describes the fee a Connect platform keeps from each charge it
+// makes on behalf of a connected account.
+type FeePolicy struct {
+	// The percentage of the amount kept, ie 2.5 for 2.5%.
+	Percent float64
+
+	// A fixed amount kept, in the smallest unit of the charge currency, ie 30
+	// for 30 cents, or 30 yen for zero-decimal currencies. A policy for
+	// charges in several currencies needs a fixed amount for each.
+	Fixed int64
+}
+
+// Fee returns the fee, in the smallest currency unit, for a charge of amount
+// in currency. The percentage of the amount is rounded to the nearest unit.
+func (p FeePolicy) Fee(amount int64, currency string) int64 {
+	return int64(math.Round(float64(amount)*p.Percent/100)) + p.Fixed
+}
+
+// Split returns the fee kept by the platform and the amount transferred to
+// the connected account for a charge of amount in currency. It fails if the
+// fee is negative or would exceed the amount.
+func (p FeePolicy) Split(amount int64, currency string) (fee, transfer int64, err error) {
+	fee = p.Fee(amount, currency)
+	if fee < 0 || fee > amount {
+		return 0, 0, fmt.Errorf("stripe: fee %d is not within the amount %d %s", fee, amount, currency)
+	}
+	return fee, amount - fee, nil
+}
+
+// CreateDestinationCharge creates a charge that is transferred to the given
+// connected account, less the fee given by policy.
+//
+// see https://stripe.com/docs/connect/destination-charges
+func CreateDestinationCharge(ctx context.Context, params *ChargeParams, account string, policy FeePolicy) (*Charge, error) {
+	return _default.CreateDestinationCharge(ctx, params, account, policy)
+}
+
+// CreateDestinationCharge is like the package-level CreateDestinationCharge,
+// using the Client.
+func (c *Client) CreateDestinationCharge(ctx context.Context, params *ChargeParams, account string, policy FeePolicy) (*Charge, error) {
+	fee, _, err := policy.Split(params.Amount, params.Currency)
+	if err != nil {
+		return nil, err
+	}
+	charge := *params
+	charge.Destination = account
+	charge.ApplicationFeeAmount = fee
+	return c.Charges.Create(ctx, &charge)
+}
+
+// TransferCharge transfers the proceeds of a charge made on the platform to
+// the given connected account, less the fee given by policy. The transfer is
+// tied to the charge, so it is made once the charge's funds are available.
+//
+// see https://stripe.com/docs/connect/charges-transfers
+func TransferCharge(ctx context.Context, charge *Charge, account string, policy FeePolicy) (*Transfer, error) {
+	return _default.TransferCharge(ctx, charge, account, policy)
+}
+
+// TransferCharge is like the package-level TransferCharge, using the Client.
+func (c *Client) TransferCharge(ctx context.Context, charge *Charge, account string, policy FeePolicy) (*Transfer, error) {
+	_, amount, err := policy.Split(charge.Amount, charge.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return c.Transfers.Create(ctx, &TransferParams{
+		Amount:            amount,
+		Currency:          charge.Currency,
+		Destination:       account,
+		SourceTransaction: charge.ID,
+		IdempotencyKey:    "transfer-" + charge.ID + "-" + account,
+	})
+}
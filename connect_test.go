@@ -0,0 +1,60 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestFeePolicy will test that fees are rounded to the smallest unit of the
+// charge currency.
+func TestFeePolicy(t *testing.T) {
+	policy := FeePolicy{Percent: 2.9, Fixed: 30}
+	tests := []struct {
+		amount   int64
+		currency string
+		fee      int64
+	}{
+		{1000, USD, 59},
+		{1010, USD, 59},
+		{1000, JPY, 59},
+		{1000, "JPY", 59},
+	}
+	for _, test := range tests {
+		fee, transfer, err := policy.Split(test.amount, test.currency)
+		if err != nil {
+			t.Errorf("Split %d %s failed: %s", test.amount, test.currency, err)
+		}
+		if fee != test.fee || fee+transfer != test.amount {
+			t.Errorf("Expected fee %d for %d %s, got %d and transfer %d", test.fee, test.amount, test.currency, fee, transfer)
+		}
+	}
+
+	if _, _, err := (FeePolicy{Fixed: 2000}).Split(1000, USD); err == nil {
+		t.Errorf("Expected error when the fee exceeds the amount")
+	}
+}
+
+// TestCreateDestinationCharge will test that the fee and destination are sent
+// with the charge.
+func TestCreateDestinationCharge(t *testing.T) {
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "ch_1"}`)
+	})
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	params := &ChargeParams{Amount: 1000, Currency: USD, Token: "tok_1"}
+	if _, err := CreateDestinationCharge(context.Background(), params, "acct_1", FeePolicy{Percent: 10}); err != nil {
+		t.Fatalf("CreateDestinationCharge failed: %s", err)
+	}
+	if form := srv.forms[0]; form.Get("transfer_data[destination]") != "acct_1" || form.Get("application_fee_amount") != "100" {
+		t.Errorf("Expected destination acct_1 and fee 100, got %v", form)
+	}
+	if params.Destination != "" {
+		t.Errorf("Expected params to be left unchanged")
+	}
+}
@@ -1,8 +1,8 @@
 package stripe
 
 import (
+	"context"
 	"net/url"
-	"strconv"
 )
 
 // Coupon Durations
@@ -12,13 +12,15 @@ const (
 	DurationRepeating = "repeating"
 )
 
-// Coupon represents percent-off discount you might want to apply to a customer.
+// Coupon represents a percent-off or amount-off discount you might want to apply to a customer.
 //
 // see https://stripe.com/docs/api#coupon_object
 type Coupon struct {
+	APIResource
 	ID               string            `json:"id"`
 	Duration         string            `json:"duration"`
-	AmountOff        int               `json:"amount_off,omitempty"`
+	AmountOff        int64             `json:"amount_off,omitempty"`
+	Currency         string            `json:"currency,omitempty"`
 	PercentOff       int               `json:"percent_off,omitempty"`
 	DurationInMonths int               `json:"duration_in_months,omitempty"`
 	MaxRedemptions   int               `json:"max_redemptions,omitempty"`
@@ -28,100 +30,92 @@ type Coupon struct {
 	Created          UnixTime          `json:"created"`
 	Metadata         map[string]string `json:"metadata"`
 	Valid            bool              `json:"valid"`
+	AppliesTo        *CouponAppliesTo  `json:"applies_to,omitempty"`
+}
+
+// CouponAppliesTo restricts a Coupon to the invoice items of the given
+// Products.
+type CouponAppliesTo struct {
+	Products []string `json:"products" form:"products"`
 }
 
 // CouponClient encapsulates operations for creating, updating, deleting and
 // querying coupons using the Stripe REST API.
-type CouponClient struct{}
+type CouponClient struct{ api }
 
 // CouponParams encapsulates options for creating a new Coupon.
 type CouponParams struct {
 	// (Optional) Unique string of your choice that will be used to identify
 	// this coupon when applying it a customer.
-	ID string
+	ID string `form:"id"`
 
 	// A positive integer between 1 and 100 that represents the discount the
 	// coupon will apply.
-	PercentOff int
+	PercentOff int `form:"percent_off"`
 
 	// Specifies how long the discount will be in effect. Can be forever, once,
 	// or repeating.
-	Duration string
+	Duration string `form:"duration"`
 
 	// A positive integer representing the amount to subtract from an invoice
 	// total (required if percent_off is not passed)
-	AmountOff int
+	AmountOff int64 `form:"amount_off"`
 
 	// Currency of the amount_off parameter (required if amount_off is passed)
-	Currency string
+	Currency string `form:"currency"`
 
 	// (Optional) If duration is repeating, a positive integer that specifies
 	// the number of months the discount will be in effect.
-	DurationInMonths int
+	DurationInMonths int `form:"duration_in_months"`
 
 	// (Optional) A positive integer specifying the number of times the coupon
 	// can be redeemed before it's no longer valid. For example, you might have
 	// a 50% off coupon that the first 20 readers of your blog can use.
-	MaxRedemptions int
+	MaxRedemptions int `form:"max_redemptions"`
 
 	// (Optional) UTC timestamp specifying the last time at which the coupon can
 	// be redeemed. After the redeem_by date, the coupon can no longer be
 	// applied to new customers.
-	RedeemBy *UnixTime
+	RedeemBy *UnixTime `form:"redeem_by"`
+
+	// (Optional) Restricts the coupon to the invoice items of these products.
+	AppliesTo *CouponAppliesTo `form:"applies_to"`
 
-	Metadata map[string]string
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
 }
 
 // Creates a new Coupon.
 //
 // see https://stripe.com/docs/api#create_coupon
-func (CouponClient) Create(params *CouponParams) (*Coupon, error) {
+func (c CouponClient) Create(ctx context.Context, params *CouponParams) (*Coupon, error) {
 	coupon := Coupon{}
-	values := url.Values{
-		"duration":    {params.Duration},
-		"percent_off": {strconv.Itoa(params.PercentOff)},
-	}
-
-	if len(params.ID) != 0 {
-		values.Add("id", params.ID)
-	}
-	if params.DurationInMonths != 0 {
-		values.Add("duration_in_months", strconv.Itoa(params.DurationInMonths))
-	}
-	if params.MaxRedemptions != 0 {
-		values.Add("max_redemptions", strconv.Itoa(params.MaxRedemptions))
-	}
-
-	if params.AmountOff != 0 {
-		values.Add("amount_off", strconv.Itoa(params.AmountOff))
-		values.Add("currency", params.Currency)
-	}
-	if params.RedeemBy != nil {
-		values.Add("redeem_by", strconv.FormatInt(params.RedeemBy.Unix(), 10))
-	}
-	appendMetadata(values, params.Metadata)
-
-	err := query("POST", "/coupons", values, &coupon)
+	err := c.query(ctx, "POST", "/coupons", formValues(params), &coupon)
 	return &coupon, err
 }
 
 // Retrieves the coupon with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_coupon
-func (CouponClient) Get(id string) (*Coupon, error) {
-	coupon := Coupon{}
+func (c CouponClient) Get(ctx context.Context, id string) (*Coupon, error) {
+	coupon := &Coupon{}
+	return coupon, c.GetInto(ctx, id, coupon)
+}
+
+// GetInto is like Get, but decodes the Coupon into v, which may be any
+// type with matching JSON fields.
+func (c CouponClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/coupons/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &coupon)
-	return &coupon, err
+	return c.query(ctx, "GET", path, nil, v)
 }
 
 // Deletes the coupon with the given ID.
 //
 // see https://stripe.com/docs/api#delete_coupon
-func (CouponClient) Delete(id string) (bool, error) {
+func (c CouponClient) Delete(ctx context.Context, id string) (bool, error) {
 	resp := DeleteResp{}
 	path := "/coupons/" + url.QueryEscape(id)
-	if err := query("DELETE", path, nil, &resp); err != nil {
+	if err := c.query(ctx, "DELETE", path, nil, &resp); err != nil {
 		return false, err
 	}
 	return resp.Deleted, nil
@@ -130,11 +124,26 @@ func (CouponClient) Delete(id string) (bool, error) {
 // Returns a list of your coupons at the specified range.
 //
 // see https://stripe.com/docs/api#list_coupons
-func (CouponClient) List(limit int, before, after string) ([]*Coupon, bool, error) {
+func (c CouponClient) List(ctx context.Context, limit int, before, after string) ([]*Coupon, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Coupon
 	}{}
-	err := query("GET", "/coupons", listParams(limit, before, after), &res)
+	err := c.query(ctx, "GET", "/coupons", listParams(limit, before, after), &res)
 	return res.Data, res.More, err
 }
+
+// Returns a page of Coupons, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_coupons
+func (c CouponClient) ListFiltered(ctx context.Context, params *ListParams) (*List[Coupon], error) {
+	res := &List[Coupon]{}
+	return res, c.query(ctx, "GET", "/coupons", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Coupon, newest first, fetching pages as
+// they are needed. The Limit of params, if any, sets the size of each page.
+func (c CouponClient) ListAll(ctx context.Context, params *ListParams) *Iter[Coupon] {
+	return newIter(ctx, c.backend(), "/coupons", params, func(cp *Coupon) string { return cp.ID })
+}
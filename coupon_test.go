@@ -1,7 +1,13 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -39,8 +45,8 @@ var (
 func TestCreateCoupon(t *testing.T) {
 
 	// Create the coupon, and defer its deletion
-	coupon, err := Coupons.Create(&c1)
-	defer Coupons.Delete(c1.ID)
+	coupon, err := Coupons.Create(context.Background(), &c1)
+	defer Coupons.Delete(context.Background(), c1.ID)
 
 	if coupon.ID != c1.ID {
 		t.Errorf("Expected Coupon ID %s, got %s", c1.ID, coupon.ID)
@@ -59,7 +65,7 @@ func TestCreateCoupon(t *testing.T) {
 	}
 
 	// Now try to re-create the existing coupon, which should throw an exception
-	coupon, err = Coupons.Create(&c1)
+	coupon, err = Coupons.Create(context.Background(), &c1)
 	if err == nil {
 		t.Error("Expected non-null Error when creating a duplicate coupon.")
 	} else if err.Error() != "Coupon already exists." {
@@ -74,11 +80,11 @@ func TestCreateCoupon(t *testing.T) {
 // retrieve a coupon that does not exist. This should yield a Not Found error.
 func TestRetrieveCoupon(t *testing.T) {
 	// create a request that we can retrieve, defer deletion in case test fails
-	Coupons.Create(&c2)
-	defer Coupons.Delete(c2.ID)
+	Coupons.Create(context.Background(), &c2)
+	defer Coupons.Delete(context.Background(), c2.ID)
 
 	// now let's retrieve the recently added coupon
-	coupon, err := Coupons.Get(c2.ID)
+	coupon, err := Coupons.Get(context.Background(), c2.ID)
 	if err != nil {
 		t.Errorf("Expected Coupon %s, got Error %s", c2.ID, err.Error())
 	}
@@ -96,7 +102,7 @@ func TestRetrieveCoupon(t *testing.T) {
 
 	// now let's try to retrieve a coupon that doesn't exist, and make sure
 	// we can handle the error
-	_, err = Coupons.Get("free for life")
+	_, err = Coupons.Get(context.Background(), "free for life")
 	if err == nil {
 		t.Error("Expected non-null Error when coupon not found.")
 	}
@@ -106,10 +112,10 @@ func TestRetrieveCoupon(t *testing.T) {
 // the JSON reponse, and that the deletion flag is captured as a boolean value.
 func TestDeleteCoupon(t *testing.T) {
 	// create a request that we can delete
-	Coupons.Create(&c1)
+	Coupons.Create(context.Background(), &c1)
 
 	// let's try to delete the coupon
-	ok, err := Coupons.Delete(c1.ID)
+	ok, err := Coupons.Delete(context.Background(), c1.ID)
 	if err != nil {
 		t.Errorf("Expected Coupon deletion, got Error %s", err.Error())
 	}
@@ -124,13 +130,13 @@ func TestDeleteCoupon(t *testing.T) {
 func TestListCoupon(t *testing.T) {
 
 	// create 2 dummy coupons that we can retrieve
-	Coupons.Create(&c1)
-	Coupons.Create(&c2)
-	defer Coupons.Delete(c1.ID)
-	defer Coupons.Delete(c2.ID)
+	Coupons.Create(context.Background(), &c1)
+	Coupons.Create(context.Background(), &c2)
+	defer Coupons.Delete(context.Background(), c1.ID)
+	defer Coupons.Delete(context.Background(), c2.ID)
 
 	// get the list from Stripe
-	coupons, _, err := Coupons.List(10, "", "")
+	coupons, _, err := Coupons.List(context.Background(), 10, "", "")
 	if err != nil {
 		t.Errorf("Expected Coupon List, got Error %s", err.Error())
 	}
@@ -140,3 +146,46 @@ func TestListCoupon(t *testing.T) {
 		t.Errorf("Expected 2 Coupons, got %d", len(coupons))
 	}
 }
+
+// TestCreateAmountOffCoupon will test that an amount-off Coupon is created
+// with its redemption limits and the products it applies to.
+func TestCreateAmountOffCoupon(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "SPRING", "amount_off": 500, "currency": "usd", "duration": "repeating",
+			"duration_in_months": 3, "max_redemptions": 100, "times_redeemed": 7, "valid": true,
+			"applies_to": {"products": ["prod_gold"]}}`)
+	})
+	redeemBy := UnixTime{time.Unix(1700000000, 0)}
+	coupon, err := c.Coupons.Create(context.Background(), &CouponParams{
+		ID:               "SPRING",
+		AmountOff:        500,
+		Currency:         "usd",
+		Duration:         DurationRepeating,
+		DurationInMonths: 3,
+		MaxRedemptions:   100,
+		RedeemBy:         &redeemBy,
+		AppliesTo:        &CouponAppliesTo{Products: []string{"prod_gold"}},
+		Metadata:         map[string]string{"campaign": "spring"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if coupon.TimesRedeemed != 7 || !coupon.Valid || coupon.Currency != "usd" ||
+		coupon.AppliesTo == nil || !reflect.DeepEqual(coupon.AppliesTo.Products, []string{"prod_gold"}) {
+		t.Errorf("Expected a valid usd Coupon redeemed 7 times for prod_gold, got %+v", coupon)
+	}
+	want := url.Values{
+		"id":                      {"SPRING"},
+		"amount_off":              {"500"},
+		"currency":                {"usd"},
+		"duration":                {"repeating"},
+		"duration_in_months":      {"3"},
+		"max_redemptions":         {"100"},
+		"redeem_by":               {"1700000000"},
+		"applies_to[products][0]": {"prod_gold"},
+		"metadata[campaign]":      {"spring"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
@@ -0,0 +1,106 @@
+package stripe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ZeroDecimalCurrencies lists the currencies whose amounts are given in whole
+// units rather than hundredths, ie 500 JPY rather than 5.00 USD.
+//
+// see https://stripe.com/docs/currencies#zero-decimal
+var ZeroDecimalCurrencies = map[string]bool{
+	"bif": true,
+	"clp": true,
+	"djf": true,
+	"gnf": true,
+	"jpy": true,
+	"kmf": true,
+	"krw": true,
+	"mga": true,
+	"pyg": true,
+	"rwf": true,
+	"ugx": true,
+	"vnd": true,
+	"vuv": true,
+	"xaf": true,
+	"xof": true,
+	"xpf": true,
+}
+
+// IsZeroDecimal reports whether amounts in currency are given in whole units.
+func IsZeroDecimal(currency string) bool {
+	return ZeroDecimalCurrencies[strings.ToLower(currency)]
+}
+
+// MinimumChargeAmounts lists the smallest amount, in the currency's smallest
+// unit, that Stripe will accept for a charge in each settlement currency.
+// Charges in currencies missing from the table are not checked. The table may
+// be modified to reflect a different account's limits.
+//
+// see https://stripe.com/docs/currencies#minimum-and-maximum-charge-amounts
+var MinimumChargeAmounts = map[string]int64{
+	"usd": 50,
+	"aed": 200,
+	"aud": 50,
+	"bgn": 100,
+	"brl": 50,
+	"cad": 50,
+	"chf": 50,
+	"czk": 1500,
+	"dkk": 250,
+	"eur": 50,
+	"gbp": 30,
+	"hkd": 400,
+	"huf": 17500,
+	"inr": 50,
+	"jpy": 50,
+	"mxn": 1000,
+	"myr": 200,
+	"nok": 300,
+	"nzd": 50,
+	"pln": 200,
+	"ron": 200,
+	"sek": 300,
+	"sgd": 50,
+	"thb": 1000,
+}
+
+// MaximumChargeAmounts lists per-currency exceptions to
+// DefaultMaximumChargeAmount.
+var MaximumChargeAmounts = map[string]int64{}
+
+// DefaultMaximumChargeAmount is the largest amount, in the currency's smallest
+// unit, that Stripe will accept for a charge. Zero disables the check.
+var DefaultMaximumChargeAmount int64 = 99999999
+
+// AmountError is returned, before any request is made, when a charge amount
+// falls outside the limits for its currency.
+type AmountError struct {
+	Amount   int64
+	Currency string
+	Min      int64
+	Max      int64
+}
+
+func (e *AmountError) Error() string {
+	if e.Amount < e.Min {
+		return fmt.Sprintf("stripe: amount %d %s is below the minimum of %d", e.Amount, e.Currency, e.Min)
+	}
+	return fmt.Sprintf("stripe: amount %d %s is above the maximum of %d", e.Amount, e.Currency, e.Max)
+}
+
+// ValidateAmount checks that amount is within the minimum and maximum charge
+// amounts for currency, returning an *AmountError if it is not.
+func ValidateAmount(currency string, amount int64) error {
+	currency = strings.ToLower(currency)
+	min := MinimumChargeAmounts[currency]
+	max, ok := MaximumChargeAmounts[currency]
+	if !ok {
+		max = DefaultMaximumChargeAmount
+	}
+	if amount < min || (max > 0 && amount > max) {
+		return &AmountError{Amount: amount, Currency: currency, Min: min, Max: max}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestValidateAmount will test that charge amounts are checked against the
+// per-currency limits, and that the limits can be overridden.
+func TestValidateAmount(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   int64
+		valid    bool
+	}{
+		{USD, 50, true},
+		{USD, 49, false},
+		{"USD", 49, false},
+		{GBP, 30, true},
+		{"huf", 17499, false},
+		{"xyz", 1, true},
+		{USD, 99999999, true},
+		{USD, 100000000, false},
+	}
+	for _, test := range tests {
+		err := ValidateAmount(test.currency, test.amount)
+		if test.valid && err != nil {
+			t.Errorf("Expected %d %s to be valid, got %s", test.amount, test.currency, err)
+		}
+		if !test.valid {
+			if _, ok := err.(*AmountError); !ok {
+				t.Errorf("Expected AmountError for %d %s, got %v", test.amount, test.currency, err)
+			}
+		}
+	}
+
+	MinimumChargeAmounts["xyz"] = 100
+	defer delete(MinimumChargeAmounts, "xyz")
+	if err := ValidateAmount("xyz", 1); err == nil {
+		t.Errorf("Expected overridden minimum for xyz to be enforced")
+	}
+}
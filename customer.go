@@ -1,32 +1,76 @@
 package stripe
 
 import (
+	"context"
 	"net/url"
-	"strconv"
+)
+
+// Tax Exemptions
+const (
+	TaxExemptNone    = "none"
+	TaxExemptExempt  = "exempt"
+	TaxExemptReverse = "reverse"
 )
 
 // Customer encapsulates details about a Customer registered in Stripe.
 //
 // see https://stripe.com/docs/api#customer_object
 type Customer struct {
-	ID            string            `json:"id"`
-	Description   string            `json:"description,omitempty"`
-	Email         string            `json:"email,omitempty"`
-	Created       UnixTime          `json:"created"`
-	Balance       int               `json:"account_balance,omitempty"`
-	Currency      string            `json:"currency"`
-	Delinquent    bool              `json:"delinquent,omitempty"`
-	Cards         *CardList         `json:"cards,omitempty"`
-	Discount      *Discount         `json:"discount,omitempty"`
-	Subscriptions *SubscriptionList `json:"subscriptions,omitempty"`
-	Livemode      bool              `json:"livemode"`
-	DefaultCard   string            `json:"default_card"`
-	Metadata      map[string]string `json:"metadata,omitempty"`
+	APIResource
+	ID               string            `json:"id"`
+	Description      string            `json:"description,omitempty"`
+	Email            string            `json:"email,omitempty"`
+	Created          UnixTime          `json:"created"`
+	Balance          int64             `json:"account_balance,omitempty"`
+	Currency         string            `json:"currency"`
+	Delinquent       bool              `json:"delinquent,omitempty"`
+	Cards            *CardList         `json:"cards,omitempty"`
+	Discount         *Discount         `json:"discount,omitempty"`
+	Subscriptions    *SubscriptionList `json:"subscriptions,omitempty"`
+	TaxIDs           *List[TaxID]      `json:"tax_ids,omitempty"`
+	Livemode         bool              `json:"livemode"`
+	DefaultCard      string            `json:"default_card"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Shipping         *Shipping         `json:"shipping,omitempty"`
+	InvoiceSettings  *InvoiceSettings  `json:"invoice_settings,omitempty"`
+	PreferredLocales []string          `json:"preferred_locales,omitempty"`
+	TaxExempt        string            `json:"tax_exempt,omitempty"`
+	Deleted          bool              `json:"deleted,omitempty"`
 }
 
+// Shipping holds the shipping details of a Customer.
+type Shipping struct {
+	Name    string   `json:"name" form:"name"`
+	Phone   string   `json:"phone,omitempty" form:"phone"`
+	Address *Address `json:"address,omitempty" form:"address"`
+}
+
+// InvoiceSettings holds the defaults applied to the invoices of a Customer.
+type InvoiceSettings struct {
+	DefaultPaymentMethod string                `json:"default_payment_method,omitempty" form:"default_payment_method"`
+	CustomFields         []*InvoiceCustomField `json:"custom_fields,omitempty" form:"custom_fields"`
+	Footer               string                `json:"footer,omitempty" form:"footer"`
+}
+
+// InvoiceCustomField is a name and value shown on the invoices of a
+// Customer, ie a purchase order number.
+type InvoiceCustomField struct {
+	Name  string `json:"name" form:"name"`
+	Value string `json:"value" form:"value"`
+}
+
+// ListObject holds the metadata of a page of a list response.
+//
+// see https://stripe.com/docs/api#pagination
 type ListObject struct {
-	Count int  `json:"total_count"`
-	More  bool `json:"has_more"`
+	// The total number of objects in the list, if Stripe includes it.
+	TotalCount int `json:"total_count"`
+
+	// Whether there are more objects after this page.
+	More bool `json:"has_more"`
+
+	// The URL of the list, ie /v1/charges.
+	URL string `json:"url"`
 }
 
 type SubscriptionList struct {
@@ -40,7 +84,7 @@ type CardList struct {
 }
 
 // Discount represents the actual application of a coupon to a particular
-// customer.
+// customer, or to one of their subscriptions when Subscription is set.
 //
 // see https://stripe.com/docs/api#discount_object
 type Discount struct {
@@ -54,173 +98,274 @@ type Discount struct {
 // CustomerParams encapsulates options for creating and updating Customers.
 type CustomerParams struct {
 	// (Optional) The customer's email address.
-	Email string
+	Email string `form:"email"`
 
 	// (Optional) An arbitrary string which you can attach to a customer object.
-	Description string
+	Description string `form:"description"`
 
 	// (Optional) Customer's Active Credit Card
-	Card *CardParams
+	Card *CardParams `form:"card"`
 
-	// (Optional) Customer's Active Credid Card, using a Card Token
-	Token string
+	// (Optional) Customer's Active Credid Card, using a Card Token. Ignored
+	// if Card is set.
+	Token string `form:"-"`
 
 	// (Optional) If you provide a coupon code, the customer will have a
 	// discount applied on all recurring charges.
-	Coupon string
+	Coupon string `form:"coupon"`
 
 	// (Optional) The identifier of the plan to subscribe the customer to. If
 	// provided, the returned customer object has a 'subscription' attribute
 	// describing the state of the customer's subscription.
-	Plan string
+	Plan string `form:"plan"`
 
 	// (Optional) The quantity you’d like to apply to the subscription you’re creating.
-	Quantity int
+	Quantity int `form:"quantity"`
 
 	// (Optional) timestamp representing the end of the trial period
 	// the customer will get before being charged for the first time.
-	TrialEnd *UnixTime
+	TrialEnd *UnixTime `form:"trial_end"`
 
 	// (Optional) Customer's account balance. Negative is credit, positive is added to the next invoice.
-	Balance *int
+	Balance *int64 `form:"account_balance"`
 
 	// (Optional) Customer's default card id.
-	DefaultCard string
+	DefaultCard string `form:"default_card"`
+
+	// (Optional) The customer's shipping name, phone and address.
+	Shipping *Shipping `form:"shipping"`
+
+	// (Optional) Default settings for the customer's invoices.
+	InvoiceSettings *InvoiceSettings `form:"invoice_settings"`
+
+	// (Optional) The customer's preferred languages, ie en or fr-CA, in order
+	// of preference, used for their invoices and receipts.
+	PreferredLocales []string `form:"preferred_locales"`
+
+	// (Optional) The customer's tax exemption, one of none, exempt or
+	// reverse.
+	TaxExempt string `form:"tax_exempt"`
 
 	// (Optional) Metadata.
-	Metadata map[string]string
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows a Create request to be safely
+	// retried without creating a duplicate customer.
+	IdempotencyKey string
+}
+
+// CustomerListParams encapsulates options for filtering a list of Customers.
+type CustomerListParams struct {
+	ListParams
+
+	// (Optional) Only return customers with this email address, matched
+	// case-sensitively.
+	Email string `form:"email"`
+
+	// (Optional) Only return customers created within this range.
+	Created *DateRange `form:"created"`
 }
 
 // CustomerClient encapsulates operations for creating, updating, deleting and
 // querying customers using the Stripe REST API.
-type CustomerClient struct{}
+type CustomerClient struct{ api }
 
 // Creates a new Customer.
 //
 // see https://stripe.com/docs/api#create_customer
-func (CustomerClient) Create(cust *CustomerParams) (*Customer, error) {
+func (c CustomerClient) Create(ctx context.Context, cust *CustomerParams) (*Customer, error) {
 	customer := Customer{}
-	params := make(url.Values)
-	appendCustomerParams(params, cust)
-
-	err := query("POST", "/customers", params, &customer)
+	if cust.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, cust.IdempotencyKey)
+	}
+	err := c.query(ctx, "POST", "/customers", customerValues(cust), &customer)
 	return &customer, err
 }
 
 // Retrieves a Customer with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_customer
-func (CustomerClient) Get(id string) (*Customer, error) {
-	customer := Customer{}
+func (c CustomerClient) Get(ctx context.Context, id string) (*Customer, error) {
+	customer := &Customer{}
+	return customer, c.GetInto(ctx, id, customer)
+}
+
+// GetInto is like Get, but decodes the Customer into v, which may be any
+// type with matching JSON fields.
+func (c CustomerClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/customers/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &customer)
-	return &customer, err
+	return c.query(ctx, "GET", path, nil, v)
 }
 
 // Updates a Customer with the given ID.
 //
 // see https://stripe.com/docs/api#update_customer
-func (CustomerClient) Update(id string, cust *CustomerParams) (*Customer, error) {
+func (c CustomerClient) Update(ctx context.Context, id string, cust *CustomerParams) (*Customer, error) {
 	customer := Customer{}
-	params := make(url.Values)
-	appendCustomerParams(params, cust)
-
-	err := query("POST", "/customers/"+url.QueryEscape(id), params, &customer)
+	err := c.query(ctx, "POST", "/customers/"+url.QueryEscape(id), customerValues(cust), &customer)
 	return &customer, err
 }
 
+// SetDefaultCard makes the card with the given ID, which must already be
+// attached to the Customer, the one used for future charges and invoices.
+//
+// see https://stripe.com/docs/api#update_customer
+func (c CustomerClient) SetDefaultCard(ctx context.Context, id, cardID string) (*Customer, error) {
+	return c.Update(ctx, id, &CustomerParams{DefaultCard: cardID})
+}
+
+// customerValues returns the form-encoded fields of params, with the Token
+// sent as the card only when no Card is given.
+func customerValues(params *CustomerParams) url.Values {
+	values := formValues(params)
+	if params != nil && params.Card == nil && params.Token != "" {
+		values.Set("card", params.Token)
+	}
+	return values
+}
+
 // Deletes a Customer (permanently) with the given ID.
 //
 // see https://stripe.com/docs/api#delete_customer
-func (CustomerClient) Delete(id string) (bool, error) {
+func (c CustomerClient) Delete(ctx context.Context, id string) (bool, error) {
 	resp := DeleteResp{}
-	err := query("DELETE", "/customers/"+url.QueryEscape(id), nil, &resp)
+	err := c.query(ctx, "DELETE", "/customers/"+url.QueryEscape(id), nil, &resp)
+	return resp.Deleted, err
+}
+
+// DeleteDiscount removes the Discount applied to the Customer with the given
+// ID. Discounts applied to the subscriptions of the Customer are not removed.
+//
+// see https://stripe.com/docs/api#delete_discount
+func (c CustomerClient) DeleteDiscount(ctx context.Context, id string) (bool, error) {
+	resp := DeleteResp{}
+	path := "/customers/" + url.QueryEscape(id) + "/discount"
+	err := c.query(ctx, "DELETE", path, nil, &resp)
 	return resp.Deleted, err
 }
 
 // Returns a list of your Customers at the specified range.
 //
 // see https://stripe.com/docs/api#list_customers
-func (CustomerClient) List(limit int, before, after string) ([]*Customer, bool, error) {
+func (c CustomerClient) List(ctx context.Context, limit int, before, after string) ([]*Customer, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Customer
 	}{}
-	err := query("GET", "/customers", listParams(limit, before, after), &res)
+	err := c.query(ctx, "GET", "/customers", listParams(limit, before, after), &res)
 	return res.Data, res.More, err
 }
 
-////////////////////////////////////////////////////////////////////////////////
-// Helper Function(s)
+// ListAll returns an Iter over every Customer matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c CustomerClient) ListAll(ctx context.Context, params *CustomerListParams) *Iter[Customer] {
+	return newIter(ctx, c.backend(), "/customers", params, func(cust *Customer) string { return cust.ID })
+}
 
-func appendCustomerParams(values url.Values, c *CustomerParams) {
-	// add optional parameters, if specified
-	if c.Email != "" {
-		values.Add("email", c.Email)
-	}
-	if c.Description != "" {
-		values.Add("description", c.Description)
-	}
-	if c.Coupon != "" {
-		values.Add("coupon", c.Coupon)
-	}
-	if c.Plan != "" {
-		values.Add("plan", c.Plan)
-	}
-	if c.TrialEnd != nil {
-		values.Add("trial_end", strconv.FormatInt(c.TrialEnd.Unix(), 10))
-	}
-	if c.Balance != nil {
-		values.Add("account_balance", strconv.Itoa(*c.Balance))
-	}
-	if c.DefaultCard != "" {
-		values.Add("default_card", c.DefaultCard)
-	}
-	appendMetadata(values, c.Metadata)
+// Returns a page of Customers matching the given filters, or of all your
+// Customers when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_customers
+func (c CustomerClient) ListFiltered(ctx context.Context, params *CustomerListParams) (*List[Customer], error) {
+	res := &List[Customer]{}
+	return res, c.query(ctx, "GET", "/customers", formValues(params), res)
+}
 
-	// add optional credit card details, if specified
-	if c.Card != nil {
-		appendCardParams(values, true, c.Card)
-	} else if c.Token != "" {
-		values.Add("card", c.Token)
-	}
+// Returns a list of the Customers with the given email address at the
+// specified range. Email addresses are matched case-sensitively.
+//
+// see https://stripe.com/docs/api#list_customers
+func (c CustomerClient) ListByEmail(ctx context.Context, email string, limit int, before, after string) ([]*Customer, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Customer
+	}{}
+	params := listParams(limit, before, after)
+	params.Add("email", email)
+	err := c.query(ctx, "GET", "/customers", params, &res)
+	return res.Data, res.More, err
 }
 
-func appendCardParams(values url.Values, nested bool, c *CardParams) {
-	p := func(s string) string {
-		if nested {
-			return "card[" + s + "]"
+// GetOrCreateByEmail returns the oldest Customer with the given email address,
+// creating one with params if there is none. The bool result reports whether
+// the Customer was created. A nil Customer is only returned with an error.
+//
+// Unless params has its own, the Customer is created with an idempotency key
+// derived from the email address and params, so concurrent calls for the same
+// address create a single Customer, and only the call that created it reports
+// so. A failed create can be retried with corrected params, and a Customer
+// that was deleted is created again rather than replayed.
+func (c CustomerClient) GetOrCreateByEmail(ctx context.Context, email string, params *CustomerParams) (*Customer, bool, error) {
+	find := func() (*Customer, error) {
+		res := struct {
+			ListObject
+			Data []*Customer
+		}{}
+		values := listParams(100, "", "")
+		values.Add("email", email)
+		if err := c.query(ctx, "GET", "/customers", values, &res); err != nil {
+			return nil, err
 		}
-		return s
-	}
-	if c.Number != "" {
-		values.Add(p("number"), c.Number)
-	}
-	if c.ExpMonth != 0 {
-		values.Add(p("exp_month"), strconv.Itoa(c.ExpMonth))
-	}
-	if c.ExpMonth != 0 {
-		values.Add(p("exp_year"), strconv.Itoa(c.ExpYear))
-	}
-	if c.Name != "" {
-		values.Add(p("name"), c.Name)
+		var oldest *Customer
+		for _, cust := range res.Data {
+			if oldest == nil || cust.Created.Before(oldest.Created.Time) {
+				oldest = cust
+			}
+		}
+		return oldest, nil
 	}
-	if c.CVC != "" {
-		values.Add(p("cvc"), c.CVC)
+
+	if cust, err := find(); err != nil || cust != nil {
+		return cust, false, err
 	}
-	if c.Address1 != "" {
-		values.Add(p("address_line1"), c.Address1)
+
+	create := CustomerParams{}
+	if params != nil {
+		create = *params
 	}
-	if c.Address2 != "" {
-		values.Add(p("address_line2"), c.Address2)
+	create.Email = email
+	values := customerValues(&create)
+	key := create.IdempotencyKey
+	if key == "" {
+		key = deriveIdempotencyKey("customer", email, values.Encode())
 	}
-	if c.AddressZip != "" {
-		values.Add(p("address_zip"), c.AddressZip)
+	cust := &Customer{}
+	err := c.query(WithIdempotencyKey(ctx, key), "POST", "/customers", values, cust)
+	for err == nil && cust.LastResponse != nil && cust.LastResponse.Header.Get("Idempotent-Replayed") == "true" {
+		// an earlier request created the customer, which may since have been
+		// deleted, in which case another is created with a key derived from
+		// the deleted one
+		if err = c.GetInto(ctx, cust.ID, cust); err != nil {
+			return nil, false, err
+		}
+		if !cust.Deleted {
+			return cust, false, nil
+		}
+		key = deriveIdempotencyKey("customer", key, cust.ID)
+		cust = &Customer{}
+		err = c.query(WithIdempotencyKey(ctx, key), "POST", "/customers", values, cust)
 	}
-	if c.AddressState != "" {
-		values.Add(p("address_state"), c.AddressState)
+	if IsErrorType(err, ErrorTypeIdempotency) {
+		// another request created the customer with different params, though
+		// it may not be listed by email yet, in which case the conflict is
+		// returned
+		found, ferr := find()
+		if ferr == nil && found == nil {
+			return nil, false, err
+		}
+		return found, false, ferr
 	}
-	if c.AddressCountry != "" {
-		values.Add(p("address_country"), c.AddressCountry)
+	if err != nil {
+		return nil, false, err
 	}
+	return cust, true, nil
+}
+
+// Stream sends every Customer matching the filters of params, which may be
+// nil, newest first, on the returned channel as pages are fetched in the
+// background. The Customer channel is closed when the list is exhausted or
+// ctx is done, after which the error channel reports any failure.
+func (c CustomerClient) Stream(ctx context.Context, params *CustomerListParams) (<-chan *Customer, <-chan error) {
+	return stream(ctx, c.backend(), "/customers", params, func(c *Customer) string { return c.ID })
 }
@@ -0,0 +1,110 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CustomerBalanceTransaction represents an adjustment of the balance of a
+// Customer, which is applied to their next invoices. A negative Amount is a
+// credit, and a positive Amount is a debit.
+//
+// see https://stripe.com/docs/api#customer_balance_transaction_object
+type CustomerBalanceTransaction struct {
+	APIResource
+	ID            string            `json:"id"`
+	Customer      string            `json:"customer"`
+	Amount        int64             `json:"amount"`
+	Currency      string            `json:"currency"`
+	Description   string            `json:"description,omitempty"`
+	EndingBalance int64             `json:"ending_balance"`
+	Invoice       string            `json:"invoice,omitempty"`
+	Type          string            `json:"type"`
+	Created       UnixTime          `json:"created"`
+	Livemode      bool              `json:"livemode"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// CustomerBalanceTransactionParams encapsulates options for adjusting the
+// balance of a Customer.
+type CustomerBalanceTransactionParams struct {
+	// The amount in cents to adjust the balance by. A negative amount credits
+	// the Customer, and a positive amount debits them.
+	Amount int64 `form:"amount"`
+
+	// Three-letter ISO currency code of the adjustment, which must match the
+	// currency of the Customer.
+	Currency string `form:"currency"`
+
+	// (Optional) An arbitrary string attached to the adjustment.
+	Description string `form:"description"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// CustomerBalanceTransactionClient encapsulates operations for adjusting and
+// querying the balance of a customer using the Stripe REST API.
+type CustomerBalanceTransactionClient struct{ api }
+
+func (c CustomerBalanceTransactionClient) path(customerID, txID string) string {
+	p := fmt.Sprintf("/customers/%s/balance_transactions", url.QueryEscape(customerID))
+	if txID != "" {
+		p += "/" + url.QueryEscape(txID)
+	}
+	return p
+}
+
+// Credits or debits the balance of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#create_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Create(ctx context.Context, customerID string, params *CustomerBalanceTransactionParams) (*CustomerBalanceTransaction, error) {
+	res := &CustomerBalanceTransaction{}
+	return res, c.query(ctx, "POST", c.path(customerID, ""), formValues(params), res)
+}
+
+// Retrieves the balance transaction with the given ID of a Customer.
+//
+// see https://stripe.com/docs/api#retrieve_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Get(ctx context.Context, customerID, txID string) (*CustomerBalanceTransaction, error) {
+	res := &CustomerBalanceTransaction{}
+	return res, c.query(ctx, "GET", c.path(customerID, txID), nil, res)
+}
+
+// Updates the description and metadata of a balance transaction of a
+// Customer. The amount of a balance transaction can not be changed.
+//
+// see https://stripe.com/docs/api#update_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Update(ctx context.Context, customerID, txID string, params *CustomerBalanceTransactionParams) (*CustomerBalanceTransaction, error) {
+	// only the description and metadata can be changed
+	values := formValues(struct {
+		Description string            `form:"description"`
+		Metadata    map[string]string `form:"metadata"`
+	}{params.Description, params.Metadata})
+
+	res := &CustomerBalanceTransaction{}
+	return res, c.query(ctx, "POST", c.path(customerID, txID), values, res)
+}
+
+// Returns a list of the balance transactions of the Customer with the given
+// ID.
+//
+// see https://stripe.com/docs/api#list_customer_balance_transactions
+func (c CustomerBalanceTransactionClient) List(ctx context.Context, customerID string, limit int, before, after string) ([]*CustomerBalanceTransaction, bool, error) {
+	res := struct {
+		ListObject
+		Data []*CustomerBalanceTransaction
+	}{}
+	err := c.query(ctx, "GET", c.path(customerID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the balance transactions of the Customer with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_customer_balance_transactions
+func (c CustomerBalanceTransactionClient) ListFiltered(ctx context.Context, customerID string, params *ListParams) (*List[CustomerBalanceTransaction], error) {
+	res := &List[CustomerBalanceTransaction]{}
+	return res, c.query(ctx, "GET", c.path(customerID, ""), formValues(params), res)
+}
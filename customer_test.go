@@ -1,6 +1,13 @@
 package stripe
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -59,8 +66,8 @@ var (
 // expected.
 func TestCreateCustomer(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, err := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, err := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 
 	if err != nil {
 		t.Errorf("Expected Customer, got Error %s", err.Error())
@@ -78,7 +85,7 @@ func TestCreateCustomer(t *testing.T) {
 func TestCreateCustomerToken(t *testing.T) {
 
 	// Create a Token for the credit card
-	token, _ := Tokens.Create(&token1)
+	token, _ := Tokens.Create(context.Background(), &token1)
 
 	// Create a Charge that uses a Token
 	cust := CustomerParams{
@@ -87,8 +94,8 @@ func TestCreateCustomerToken(t *testing.T) {
 	}
 
 	// Create the charge
-	resp, err := Customers.Create(&cust)
-	defer Customers.Delete(resp.ID)
+	resp, err := Customers.Create(context.Background(), &cust)
+	defer Customers.Delete(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected Create Customer, got Error %s", err.Error())
 	}
@@ -106,21 +113,21 @@ func TestCreateCustomerToken(t *testing.T) {
 func TestRetrieveCustomer(t *testing.T) {
 
 	// setup default plans and coupons, defer deletion
-	Plans.Create(&p1)
-	Coupons.Create(&c1)
-	defer Plans.Delete(p1.ID)
-	defer Coupons.Delete(c1.ID)
+	Plans.Create(context.Background(), &p1)
+	Coupons.Create(context.Background(), &c1)
+	defer Plans.Delete(context.Background(), p1.ID)
+	defer Coupons.Delete(context.Background(), c1.ID)
 
 	// Create the customer, and defer its deletion
-	resp, err := Customers.Create(&cust2)
-	defer Customers.Delete(resp.ID)
+	resp, err := Customers.Create(context.Background(), &cust2)
+	defer Customers.Delete(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected Customer, got Error %s", err.Error())
 		return
 	}
 
 	// Retrieve the Customer by ID
-	cust, err := Customers.Get(resp.ID)
+	cust, err := Customers.Get(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected Customer, got Error %s", err.Error())
 	}
@@ -156,11 +163,11 @@ func TestRetrieveCustomer(t *testing.T) {
 // parse the JSON reponse, and verify the updated name was returned.
 func TestUpdateCustomer(t *testing.T) {
 	// Create the Customer, and defer its deletion
-	resp, _ := Customers.Create(&cust1)
-	defer Customers.Delete(resp.ID)
+	resp, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), resp.ID)
 
-	balance := -100
-	cust, err := Customers.Update(resp.ID, &CustomerParams{Email: "joe@email.com", Balance: &balance})
+	balance := int64(-100)
+	cust, err := Customers.Update(context.Background(), resp.ID, &CustomerParams{Email: "joe@email.com", Balance: &balance})
 	if err != nil {
 		t.Errorf("Expected Customer update, got Error %s", err.Error())
 	}
@@ -177,11 +184,11 @@ func TestUpdateCustomer(t *testing.T) {
 // value.
 func TestDeleteCustomer(t *testing.T) {
 	// Create the Customer, and defer its deletion
-	resp, _ := Customers.Create(&cust1)
-	defer Customers.Delete(resp.ID)
+	resp, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), resp.ID)
 
 	// let's try to delete the customer
-	ok, err := Customers.Delete(resp.ID)
+	ok, err := Customers.Delete(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected Customer deletion, got Error %s", err.Error())
 	}
@@ -196,13 +203,13 @@ func TestDeleteCustomer(t *testing.T) {
 func TestListCustomers(t *testing.T) {
 
 	// create 2 dummy customers that we can retrieve
-	resp1, _ := Customers.Create(&cust1)
-	resp2, _ := Customers.Create(&cust3)
-	defer Customers.Delete(resp1.ID)
-	defer Customers.Delete(resp2.ID)
+	resp1, _ := Customers.Create(context.Background(), &cust1)
+	resp2, _ := Customers.Create(context.Background(), &cust3)
+	defer Customers.Delete(context.Background(), resp1.ID)
+	defer Customers.Delete(context.Background(), resp2.ID)
 
 	// get the list from Stripe
-	customers, _, err := Customers.List(2, "", "")
+	customers, _, err := Customers.List(context.Background(), 2, "", "")
 	if err != nil {
 		t.Errorf("Expected Customer List, got Error %s", err.Error())
 	}
@@ -212,3 +219,164 @@ func TestListCustomers(t *testing.T) {
 		t.Errorf("Expected 2 Customers, got %d", len(customers))
 	}
 }
+
+// TestGetOrCreateByEmail will test that an existing customer is returned, that
+// a missing one is created with an idempotency key derived from the email and
+// params, that a replayed creation is not reported as created unless the
+// customer was deleted, and that a customer created concurrently is found
+// after an idempotency conflict, or the conflict returned if it is not found.
+func TestGetOrCreateByEmail(t *testing.T) {
+	listed := map[string]bool{}
+	deleted := map[string]bool{}
+	created := map[string]string{}
+	var keys []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		email := r.Form.Get("email")
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/v1/customers/"):
+			id := strings.TrimPrefix(r.URL.Path, "/v1/customers/")
+			fmt.Fprintf(w, `{"id": "%s", "deleted": %t}`, id, deleted[id])
+		case r.Method == "GET" && (email == "old@example.com" || listed[email]):
+			fmt.Fprint(w, `{"data": [{"id": "cus_2", "created": 2}, {"id": "cus_1", "created": 1}]}`)
+		case r.Method == "GET":
+			fmt.Fprint(w, `{"data": []}`)
+		case email == "race@example.com" || email == "lag@example.com":
+			// a concurrent request won the race with other params
+			listed["race@example.com"] = true
+			w.WriteHeader(400)
+			fmt.Fprint(w, `{"error": {"type": "idempotency_error", "message": "conflict"}}`)
+		default:
+			key := r.Header.Get("Idempotency-Key")
+			keys = append(keys, key)
+			if id, ok := created[key]; ok {
+				w.Header().Set("Idempotent-Replayed", "true")
+				fmt.Fprintf(w, `{"id": "%s"}`, id)
+				return
+			}
+			created[key] = fmt.Sprintf("cus_new%d", len(created)+1)
+			fmt.Fprintf(w, `{"id": "%s"}`, created[key])
+		}
+	})
+
+	ctx := context.Background()
+	cust, ok, err := c.Customers.GetOrCreateByEmail(ctx, "old@example.com", nil)
+	if err != nil || ok || cust.ID != "cus_1" {
+		t.Errorf("Expected oldest existing customer cus_1, got %v %v %v", cust, ok, err)
+	}
+
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "new@example.com", nil)
+	if err != nil || !ok || cust.ID != "cus_new1" {
+		t.Errorf("Expected created customer cus_new1, got %v %v %v", cust, ok, err)
+	}
+	if len(keys) != 1 || !strings.HasPrefix(keys[0], "customer-") {
+		t.Errorf("Expected derived idempotency key, got %v", keys)
+	}
+
+	// a concurrent call with the same params is replayed
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "new@example.com", nil)
+	if err != nil || ok || cust.ID != "cus_new1" {
+		t.Errorf("Expected replayed customer cus_new1 not to be created, got %v %v %v", cust, ok, err)
+	}
+
+	// a call with other params, ie after a failed create, is not replayed
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "new@example.com", &CustomerParams{Token: "tok_2"})
+	if err != nil || !ok || cust.ID != "cus_new2" {
+		t.Errorf("Expected created customer cus_new2, got %v %v %v", cust, ok, err)
+	}
+
+	// a deleted customer is created again
+	deleted["cus_new1"] = true
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "new@example.com", nil)
+	if err != nil || !ok || cust.ID != "cus_new3" {
+		t.Errorf("Expected created customer cus_new3, got %v %v %v", cust, ok, err)
+	}
+	if len(keys) != 5 || keys[4] == keys[3] || keys[3] != keys[0] || keys[2] == keys[0] {
+		t.Errorf("Expected keys by params and deleted customer, got %v", keys)
+	}
+
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "race@example.com", nil)
+	if err != nil || ok || cust.ID != "cus_1" {
+		t.Errorf("Expected concurrently created customer cus_1, got %v %v %v", cust, ok, err)
+	}
+
+	// the concurrently created customer is not listed by email yet
+	cust, ok, err = c.Customers.GetOrCreateByEmail(ctx, "lag@example.com", nil)
+	if !IsErrorType(err, ErrorTypeIdempotency) || ok || cust != nil {
+		t.Errorf("Expected idempotency error, got %v %v %v", cust, ok, err)
+	}
+}
+
+// TestCreateTaxID will test that a tax ID is added to a Customer, and that
+// the tax IDs of a Customer are decoded.
+func TestCreateTaxID(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "txi_1", "customer": "cus_1", "type": "eu_vat", "value": "DE123456789", "verification": {"status": "pending"}}`)
+	})
+	tax, err := c.TaxIDs.Create(context.Background(), "cus_1", TaxIDEUVAT, "DE123456789")
+	if err != nil || tax.ID != "txi_1" || tax.Verification == nil || tax.Verification.Status != TaxIDPending {
+		t.Fatalf("Expected pending Tax ID txi_1, got %+v %v", tax, err)
+	}
+	if want := []string{"POST /v1/customers/cus_1/tax_ids"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	if want := (url.Values{"type": {"eu_vat"}, "value": {"DE123456789"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	cust := &Customer{}
+	data := `{"id": "cus_1", "tax_ids": {"object": "list", "data": [{"id": "txi_1", "type": "eu_vat", "value": "DE123456789"}]}}`
+	if err := json.Unmarshal([]byte(data), cust); err != nil || len(cust.TaxIDs.Data) != 1 || cust.TaxIDs.Data[0].Value != "DE123456789" {
+		t.Errorf("Expected Customer with Tax ID DE123456789, got %v", err)
+	}
+}
+
+// TestCreditCustomerBalance will test that the balance of a Customer is
+// credited, and that only the description and metadata of the adjustment are
+// updated.
+func TestCreditCustomerBalance(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "cbtxn_1", "customer": "cus_1", "amount": -500, "currency": "usd", "ending_balance": -500, "type": "adjustment"}`)
+	})
+	ctx := context.Background()
+	tx, err := c.CustomerBalanceTransactions.Create(ctx, "cus_1", &CustomerBalanceTransactionParams{Amount: -500, Currency: "usd"})
+	if err != nil || tx.ID != "cbtxn_1" || tx.EndingBalance != -500 {
+		t.Fatalf("Expected credit cbtxn_1, got %+v %v", tx, err)
+	}
+	if want := (url.Values{"amount": {"-500"}, "currency": {"usd"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	_, err = c.CustomerBalanceTransactions.Update(ctx, "cus_1", "cbtxn_1", &CustomerBalanceTransactionParams{Amount: 100, Description: "Goodwill"})
+	if want := (url.Values{"description": {"Goodwill"}}); err != nil || !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v %v", want, srv.forms[1], err)
+	}
+
+	want := []string{"POST /v1/customers/cus_1/balance_transactions", "POST /v1/customers/cus_1/balance_transactions/cbtxn_1"}
+	if !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestDeleteDiscount will test that the Discount of a Customer and of one of
+// its Subscriptions are deleted.
+func TestDeleteDiscount(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"deleted": true}`)
+	})
+	ctx := context.Background()
+	if ok, err := c.Customers.DeleteDiscount(ctx, "cus_1"); err != nil || !ok {
+		t.Fatalf("Expected Customer Discount deleted, got %v %v", ok, err)
+	}
+	if ok, err := c.Subscriptions.DeleteDiscount(ctx, "", "sub_1"); err != nil || !ok {
+		t.Fatalf("Expected Subscription Discount deleted, got %v %v", ok, err)
+	}
+	if want := []string{"DELETE /v1/customers/cus_1/discount", "DELETE /v1/subscriptions/sub_1/discount"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+
+	cust := &Customer{}
+	data := `{"id": "cus_1", "discount": {"customer": "cus_1", "start": 1700000000, "coupon": {"id": "SPRING", "percent_off": 20}}}`
+	if err := json.Unmarshal([]byte(data), cust); err != nil || cust.Discount == nil || cust.Discount.Coupon.ID != "SPRING" {
+		t.Errorf("Expected Customer with Discount SPRING, got %+v %v", cust.Discount, err)
+	}
+}
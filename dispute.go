@@ -0,0 +1,227 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// Dispute Statuses
+const (
+	DisputeWarningNeedsResponse = "warning_needs_response"
+	DisputeWarningUnderReview   = "warning_under_review"
+	DisputeWarningClosed        = "warning_closed"
+	DisputeNeedsResponse        = "needs_response"
+	DisputeUnderReview          = "under_review"
+	DisputeWon                  = "won"
+	DisputeLost                 = "lost"
+)
+
+// Dispute Evidence fields that accept the ID of a File uploaded with the
+// dispute_evidence purpose.
+const (
+	EvidenceCancellationPolicy           = "cancellation_policy"
+	EvidenceCustomerCommunication        = "customer_communication"
+	EvidenceCustomerSignature            = "customer_signature"
+	EvidenceDuplicateChargeDocumentation = "duplicate_charge_documentation"
+	EvidenceReceipt                      = "receipt"
+	EvidenceRefundPolicy                 = "refund_policy"
+	EvidenceServiceDocumentation         = "service_documentation"
+	EvidenceShippingDocumentation        = "shipping_documentation"
+	EvidenceUncategorizedFile            = "uncategorized_file"
+)
+
+// Dispute represents a charge that the cardholder has disputed with their
+// bank.
+//
+// see https://stripe.com/docs/api#dispute_object
+type Dispute struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Charge             string            `json:"charge"`
+	Livemode           bool              `json:"livemode"`
+	Amount             int64             `json:"amount"`
+	Created            UnixTime          `json:"created"`
+	Currency           string            `json:"currency"`
+	Reason             string            `json:"reason"`
+	Status             string            `json:"status"`
+	BalanceTransaction string            `json:"balance_transaction,omitempty"`
+	Evidence           *DisputeEvidence  `json:"evidence,omitempty"`
+	EvidenceDueBy      *UnixTime         `json:"evidence_due_by,omitempty"`
+	EvidenceDetails    *EvidenceDetails  `json:"evidence_details,omitempty"`
+	IsChargeRefundable bool              `json:"is_charge_refundable"`
+	Protected          bool              `json:"is_protected,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// DisputeEvidence holds the evidence submitted to the card issuer to
+// challenge a Dispute. Fields ending in a File type (Receipt,
+// CustomerCommunication, etc) hold the ID of an uploaded File.
+type DisputeEvidence struct {
+	AccessActivityLog            string `json:"access_activity_log,omitempty" form:"access_activity_log"`
+	BillingAddress               string `json:"billing_address,omitempty" form:"billing_address"`
+	CancellationPolicy           string `json:"cancellation_policy,omitempty" form:"cancellation_policy"`
+	CancellationPolicyDisclosure string `json:"cancellation_policy_disclosure,omitempty" form:"cancellation_policy_disclosure"`
+	CancellationRebuttal         string `json:"cancellation_rebuttal,omitempty" form:"cancellation_rebuttal"`
+	CustomerCommunication        string `json:"customer_communication,omitempty" form:"customer_communication"`
+	CustomerEmailAddress         string `json:"customer_email_address,omitempty" form:"customer_email_address"`
+	CustomerName                 string `json:"customer_name,omitempty" form:"customer_name"`
+	CustomerPurchaseIP           string `json:"customer_purchase_ip,omitempty" form:"customer_purchase_ip"`
+	CustomerSignature            string `json:"customer_signature,omitempty" form:"customer_signature"`
+	DuplicateChargeDocumentation string `json:"duplicate_charge_documentation,omitempty" form:"duplicate_charge_documentation"`
+	DuplicateChargeExplanation   string `json:"duplicate_charge_explanation,omitempty" form:"duplicate_charge_explanation"`
+	DuplicateChargeID            string `json:"duplicate_charge_id,omitempty" form:"duplicate_charge_id"`
+	ProductDescription           string `json:"product_description,omitempty" form:"product_description"`
+	Receipt                      string `json:"receipt,omitempty" form:"receipt"`
+	RefundPolicy                 string `json:"refund_policy,omitempty" form:"refund_policy"`
+	RefundPolicyDisclosure       string `json:"refund_policy_disclosure,omitempty" form:"refund_policy_disclosure"`
+	RefundRefusalExplanation     string `json:"refund_refusal_explanation,omitempty" form:"refund_refusal_explanation"`
+	ServiceDate                  string `json:"service_date,omitempty" form:"service_date"`
+	ServiceDocumentation         string `json:"service_documentation,omitempty" form:"service_documentation"`
+	ShippingAddress              string `json:"shipping_address,omitempty" form:"shipping_address"`
+	ShippingCarrier              string `json:"shipping_carrier,omitempty" form:"shipping_carrier"`
+	ShippingDate                 string `json:"shipping_date,omitempty" form:"shipping_date"`
+	ShippingDocumentation        string `json:"shipping_documentation,omitempty" form:"shipping_documentation"`
+	ShippingTrackingNumber       string `json:"shipping_tracking_number,omitempty" form:"shipping_tracking_number"`
+	UncategorizedFile            string `json:"uncategorized_file,omitempty" form:"uncategorized_file"`
+	UncategorizedText            string `json:"uncategorized_text,omitempty" form:"uncategorized_text"`
+}
+
+// UnmarshalJSON decodes evidence either as an object or, as in API versions
+// before 2014-12-08, as a single string, which is kept as UncategorizedText.
+func (e *DisputeEvidence) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*e = DisputeEvidence{}
+		return json.Unmarshal(data, &e.UncategorizedText)
+	}
+	type evidence DisputeEvidence
+	return json.Unmarshal(data, (*evidence)(e))
+}
+
+// EvidenceDetails holds information about the deadline and submission
+// status of a Dispute's evidence.
+type EvidenceDetails struct {
+	DueBy           *UnixTime `json:"due_by,omitempty"`
+	HasEvidence     bool      `json:"has_evidence"`
+	PastDue         bool      `json:"past_due"`
+	SubmissionCount int       `json:"submission_count"`
+}
+
+// DisputeParams encapsulates options for updating a Dispute.
+type DisputeParams struct {
+	// (Optional) Evidence to upload to respond to the dispute. Fields that are
+	// left empty are not changed.
+	Evidence *DisputeEvidence `form:"evidence"`
+
+	// (Optional) Whether to immediately submit the evidence to the bank. When
+	// false, the evidence is staged and can be changed until it is
+	// submitted. Default is true.
+	Submit *bool `form:"submit"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// EvidenceFile is a document to upload and attach to a Dispute as evidence.
+type EvidenceFile struct {
+	// The evidence field the file is attached to, such as receipt or
+	// customer_communication.
+	Field string
+
+	// The name of the file, including its extension (e.g. receipt.pdf).
+	Filename string
+
+	// The contents of the file.
+	Reader io.Reader
+}
+
+// DisputeClient encapsulates operations for updating, closing and querying
+// disputes using the Stripe REST API.
+type DisputeClient struct{ api }
+
+// Retrieves the Dispute with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_dispute
+func (c DisputeClient) Get(ctx context.Context, id string) (*Dispute, error) {
+	res := &Dispute{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Dispute into v, which may be any
+// type with matching JSON fields.
+func (c DisputeClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/disputes/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the Dispute with the given ID, typically to submit evidence.
+//
+// see https://stripe.com/docs/api#update_dispute
+func (c DisputeClient) Update(ctx context.Context, id string, params *DisputeParams) (*Dispute, error) {
+	res := &Dispute{}
+	return res, c.query(ctx, "POST", "/disputes/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// UploadEvidence uploads each of the given files with the dispute_evidence
+// purpose, and attaches the resulting File IDs to the corresponding evidence
+// fields of the Dispute with the given ID. When submit is false the evidence
+// is staged, and can be changed until it is submitted.
+//
+// Every file must have contents, which is checked before any are uploaded.
+// If an upload fails, the Dispute is not updated and any files that were
+// already uploaded are left unattached.
+func (c DisputeClient) UploadEvidence(ctx context.Context, id string, files []*EvidenceFile, submit bool) (*Dispute, error) {
+	for _, f := range files {
+		if f.Reader == nil {
+			return nil, fmt.Errorf("stripe: evidence file %q has no contents", f.Filename)
+		}
+	}
+
+	values := make(url.Values)
+	for _, f := range files {
+		file, err := c.backend().Files.Create(ctx, &FileParams{
+			Purpose:  PurposeDisputeEvidence,
+			Filename: f.Filename,
+			Reader:   f.Reader,
+		})
+		if err != nil {
+			return nil, err
+		}
+		values.Set(fmt.Sprintf("evidence[%s]", f.Field), file.ID)
+	}
+	values.Add("submit", strconv.FormatBool(submit))
+
+	res := &Dispute{}
+	return res, c.query(ctx, "POST", "/disputes/"+url.QueryEscape(id), values, res)
+}
+
+// Closes the Dispute with the given ID, accepting it as lost.
+//
+// see https://stripe.com/docs/api#close_dispute
+func (c DisputeClient) Close(ctx context.Context, id string) (*Dispute, error) {
+	res := &Dispute{}
+	path := fmt.Sprintf("/disputes/%s/close", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, nil, res)
+}
+
+// Returns a list of your Disputes at the specified range.
+//
+// see https://stripe.com/docs/api#list_disputes
+func (c DisputeClient) List(ctx context.Context, limit int, before, after string) ([]*Dispute, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Dispute
+	}{}
+	err := c.query(ctx, "GET", "/disputes", listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Disputes, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_disputes
+func (c DisputeClient) ListFiltered(ctx context.Context, params *ListParams) (*List[Dispute], error) {
+	res := &List[Dispute]{}
+	return res, c.query(ctx, "GET", "/disputes", formValues(params), res)
+}
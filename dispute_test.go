@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestUploadEvidence will test that evidence files are uploaded with the
+// dispute_evidence purpose, and attached to the Dispute's evidence fields.
+func TestUploadEvidence(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "dp_1"}`)
+	})
+	uploads := newUploadServer(t, c)
+
+	files := []*EvidenceFile{
+		{Field: "receipt", Filename: "receipt.pdf", Reader: strings.NewReader("receipt")},
+		{Field: "customer_communication", Filename: "email.txt", Reader: strings.NewReader("email")},
+	}
+	dispute, err := c.Disputes.UploadEvidence(context.Background(), "dp_1", files, true)
+	if err != nil || dispute.ID != "dp_1" {
+		t.Fatalf("Expected Dispute dp_1, got %v %v", dispute, err)
+	}
+
+	if want := []string{"dispute_evidence receipt.pdf receipt", "dispute_evidence email.txt email"}; !reflect.DeepEqual(*uploads, want) {
+		t.Errorf("Expected uploads %v, got %v", want, *uploads)
+	}
+	if want := []string{"POST /v1/disputes/dp_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Fatalf("Expected requests %v, got %v", want, srv.paths)
+	}
+	want := url.Values{
+		"evidence[receipt]":                {"file_1"},
+		"evidence[customer_communication]": {"file_2"},
+		"submit":                           {"true"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestUploadEvidenceWithoutContents will test that evidence files without
+// contents are rejected before anything is uploaded.
+func TestUploadEvidenceWithoutContents(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "dp_1"}`)
+	})
+	uploads := newUploadServer(t, c)
+
+	files := []*EvidenceFile{
+		{Field: "receipt", Filename: "receipt.pdf", Reader: strings.NewReader("receipt")},
+		{Field: "customer_communication", Filename: "email.txt"},
+	}
+	if _, err := c.Disputes.UploadEvidence(context.Background(), "dp_1", files, false); err == nil {
+		t.Errorf("Expected an error for a file without contents")
+	}
+	if len(*uploads) != 0 || len(srv.paths) != 0 {
+		t.Errorf("Expected no requests, got %v %v", *uploads, srv.paths)
+	}
+	if _, err := c.Files.Create(context.Background(), &FileParams{Purpose: PurposeDisputeEvidence, Filename: "email.txt"}); err == nil {
+		t.Errorf("Expected an error for a file without contents")
+	}
+}
@@ -0,0 +1,149 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Dunning retries payment of past due invoices on a schedule, closing them
+// once too many attempts have failed. Run is stateless, relying on the attempt
+// count and date of each invoice, so it is meant to be called periodically
+// (ie from a daily cron job).
+//
+// This API version has no void or uncollectible invoice states; an invoice
+// that is given up on is closed, which forgives the amount due.
+type Dunning struct {
+	// The time after the invoice date at which each payment attempt is due.
+	// An invoice that has been attempted n times is retried once
+	// Schedule[n-1] has passed, ie {3 * 24 * time.Hour, 5 * 24 * time.Hour}
+	// retries three and then five days after the invoice date. Invoices
+	// attempted more times than Schedule has entries are retried on every
+	// Run once its last entry has passed, until MaxAttempts is reached.
+	Schedule []time.Duration
+
+	// (Optional) The number of failed attempts after which the invoice is
+	// closed. Default is one more than the length of Schedule. A negative
+	// value never closes invoices.
+	MaxAttempts int
+
+	// (Optional) Only invoices dated within MaxAge of the Run are checked, ie
+	// 30 * 24 * time.Hour to leave invoices older than a month alone. Default
+	// is to check every invoice on the account, which is slow for accounts
+	// with many invoices.
+	MaxAge time.Duration
+
+	// (Optional) Only the invoices of the customer with this ID are checked.
+	Customer string
+
+	// (Optional) The Client used to make requests. Default is the client of
+	// the package-level APIs.
+	Client *Client
+
+	// (Optional) Called when a retried invoice is paid.
+	OnPaid func(*Invoice)
+
+	// (Optional) Called when payment of an invoice fails.
+	OnFailed func(*Invoice, error)
+
+	// (Optional) Called when an invoice is closed after too many failures.
+	OnClosed func(*Invoice)
+
+	now func() time.Time
+}
+
+// Run checks every unpaid, open invoice that has been attempted at least
+// once, within MaxAge and of Customer if set, retrying payment if it is due. Failures to pay or close an invoice are
+// reported to the callbacks; Run only returns an error if the invoices cannot
+// be listed or ctx is done.
+func (d *Dunning) Run(ctx context.Context) error {
+	max := d.MaxAttempts
+	if max == 0 {
+		max = len(d.Schedule) + 1
+	}
+	now := time.Now
+	if d.now != nil {
+		now = d.now
+	}
+	c := d.Client
+	if c == nil {
+		c = _default
+	}
+
+	params := &InvoiceListParams{Customer: d.Customer}
+	if d.MaxAge > 0 {
+		params.Date = Since(now().Add(-d.MaxAge))
+	}
+
+	err := listAll(ctx, c, "/invoices", formValues(params), func(inv *Invoice) string { return inv.ID }, func(inv *Invoice) error {
+		if inv.Paid || inv.Closed || !inv.Attempted {
+			return nil
+		}
+		if max > 0 && inv.AttemptCount >= max {
+			return d.close(ctx, c, inv)
+		}
+		if !d.due(inv, now()) {
+			return nil
+		}
+
+		paid := &Invoice{}
+		err := c.query(ctx, "POST", fmt.Sprintf("/invoices/%s/pay", url.QueryEscape(inv.ID)), nil, paid)
+		if err == nil {
+			if d.OnPaid != nil {
+				d.OnPaid(paid)
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.OnFailed != nil {
+			d.OnFailed(inv, err)
+		}
+
+		// only a declined payment counts as an attempt
+		if IsCardError(err) && max > 0 && inv.AttemptCount+1 >= max {
+			return d.close(ctx, c, inv)
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// due reports whether the next payment attempt of the invoice is due, using
+// the last entry of the schedule once the invoice has been attempted more
+// times than the schedule has entries.
+func (d *Dunning) due(inv *Invoice, now time.Time) bool {
+	if len(d.Schedule) == 0 {
+		return true
+	}
+	i := inv.AttemptCount - 1
+	if i >= len(d.Schedule) {
+		i = len(d.Schedule) - 1
+	}
+	return !now.Before(inv.Date.Add(d.Schedule[i]))
+}
+
+// close closes the invoice, forgiving the amount due.
+func (d *Dunning) close(ctx context.Context, c *Client, inv *Invoice) error {
+	closed := true
+	res := &Invoice{}
+	values := formValues(&InvoiceParams{Closed: &closed})
+	if err := c.query(ctx, "POST", "/invoices/"+url.QueryEscape(inv.ID), values, res); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.OnFailed != nil {
+			d.OnFailed(inv, err)
+		}
+		return nil
+	}
+	if d.OnClosed != nil {
+		d.OnClosed(res)
+	}
+	return nil
+}
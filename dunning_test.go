@@ -0,0 +1,137 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDunning will test that past due invoices are retried when due, and
+// closed once they have failed too many times.
+func TestDunning(t *testing.T) {
+	now := time.Date(2014, 6, 10, 0, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+	date := now.Unix() - 4*day
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/invoices":
+			fmt.Fprintf(w, `{"data": [
+				{"id": "in_paid", "paid": true, "attempted": true, "attempt_count": 1, "date": %d},
+				{"id": "in_new", "attempted": false, "date": %d},
+				{"id": "in_due", "attempted": true, "attempt_count": 1, "date": %d},
+				{"id": "in_later", "attempted": true, "attempt_count": 2, "date": %d},
+				{"id": "in_declined", "attempted": true, "attempt_count": 2, "date": %d},
+				{"id": "in_done", "attempted": true, "attempt_count": 3, "date": %d}
+			]}`, date, date, date, date, date-2*day, date)
+		case "/v1/invoices/in_due/pay":
+			fmt.Fprint(w, `{"id": "in_due", "paid": true}`)
+		case "/v1/invoices/in_declined/pay":
+			w.WriteHeader(402)
+			fmt.Fprint(w, `{"error": {"type": "card_error", "message": "declined"}}`)
+		case "/v1/invoices/in_declined", "/v1/invoices/in_done":
+			fmt.Fprintf(w, `{"id": "%s", "closed": true}`, r.URL.Path[len("/v1/invoices/"):])
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	var paid, failed, closed []string
+	d := &Dunning{
+		Schedule: []time.Duration{3 * 24 * time.Hour, 5 * 24 * time.Hour},
+		OnPaid:   func(inv *Invoice) { paid = append(paid, inv.ID) },
+		OnFailed: func(inv *Invoice, err error) { failed = append(failed, inv.ID) },
+		OnClosed: func(inv *Invoice) { closed = append(closed, inv.ID) },
+		now:      func() time.Time { return now },
+	}
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %s", err)
+	}
+	if fmt.Sprint(paid) != "[in_due]" {
+		t.Errorf("Expected [in_due] paid, got %v", paid)
+	}
+	if fmt.Sprint(failed) != "[in_declined]" {
+		t.Errorf("Expected [in_declined] failed, got %v", failed)
+	}
+	if fmt.Sprint(closed) != "[in_declined in_done]" {
+		t.Errorf("Expected [in_declined in_done] closed, got %v", closed)
+	}
+}
+
+// TestDunningMaxAttempts will test that invoices attempted more times than
+// the schedule has entries are retried on its last entry, and that only
+// declined payments count towards MaxAttempts.
+func TestDunningMaxAttempts(t *testing.T) {
+	now := time.Date(2014, 6, 10, 0, 0, 0, 0, time.UTC)
+	date := now.Add(-6 * 24 * time.Hour).Unix()
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/invoices":
+			fmt.Fprintf(w, `{"data": [
+				{"id": "in_declined", "attempted": true, "attempt_count": 4, "date": %d},
+				{"id": "in_outage", "attempted": true, "attempt_count": 4, "date": %d}
+			]}`, date, date)
+		case "/v1/invoices/in_declined/pay":
+			w.WriteHeader(402)
+			fmt.Fprint(w, `{"error": {"type": "card_error", "message": "declined"}}`)
+		case "/v1/invoices/in_outage/pay":
+			w.WriteHeader(500)
+			fmt.Fprint(w, `{"error": {"type": "api_error", "message": "outage"}}`)
+		case "/v1/invoices/in_declined":
+			fmt.Fprint(w, `{"id": "in_declined", "closed": true}`)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	})
+	var failed, closed []string
+	d := &Dunning{
+		Schedule:    []time.Duration{3 * 24 * time.Hour, 5 * 24 * time.Hour},
+		MaxAttempts: 5,
+		Client:      c,
+		OnFailed:    func(inv *Invoice, err error) { failed = append(failed, inv.ID) },
+		OnClosed:    func(inv *Invoice) { closed = append(closed, inv.ID) },
+		now:         func() time.Time { return now },
+	}
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %s", err)
+	}
+	if fmt.Sprint(failed) != "[in_declined in_outage]" {
+		t.Errorf("Expected [in_declined in_outage] failed, got %v", failed)
+	}
+	if fmt.Sprint(closed) != "[in_declined]" {
+		t.Errorf("Expected [in_declined] closed, got %v", closed)
+	}
+}
+
+// TestDunningFilters will test that only the invoices within MaxAge and of
+// the Customer are listed.
+func TestDunningFilters(t *testing.T) {
+	now := time.Date(2014, 6, 10, 0, 0, 0, 0, time.UTC)
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": []}`)
+	})
+	d := &Dunning{
+		MaxAge:   30 * 24 * time.Hour,
+		Customer: "cus_1",
+		Client:   c,
+		now:      func() time.Time { return now },
+	}
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %s", err)
+	}
+	want := url.Values{"customer": {"cus_1"}, "date[gte]": {fmt.Sprint(now.AddDate(0, 0, -30).Unix())}, "limit": {"100"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
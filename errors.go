@@ -0,0 +1,116 @@
+package stripe
+
+import "errors"
+
+// ErrorType is the category of an Error returned by the Stripe API.
+//
+// see https://stripe.com/docs/api#errors
+type ErrorType string
+
+// Error Types returned by the Stripe API.
+const (
+	ErrorTypeAPI            ErrorType = "api_error"
+	ErrorTypeAPIConnection  ErrorType = "api_connection_error"
+	ErrorTypeAuthentication ErrorType = "authentication_error"
+	ErrorTypeCard           ErrorType = "card_error"
+	ErrorTypeIdempotency    ErrorType = "idempotency_error"
+	ErrorTypeInvalidRequest ErrorType = "invalid_request_error"
+	ErrorTypeRateLimit      ErrorType = "rate_limit_error"
+)
+
+// ErrorCode is a short string describing why a request failed, most often
+// set on card errors.
+//
+// see https://stripe.com/docs/error-codes
+type ErrorCode string
+
+// Error Codes returned by the Stripe API.
+const (
+	CodeCardDeclined       ErrorCode = "card_declined"
+	CodeExpiredCard        ErrorCode = "expired_card"
+	CodeIncorrectCVC       ErrorCode = "incorrect_cvc"
+	CodeIncorrectNumber    ErrorCode = "incorrect_number"
+	CodeIncorrectZip       ErrorCode = "incorrect_zip"
+	CodeInvalidCVC         ErrorCode = "invalid_cvc"
+	CodeInvalidExpiryMonth ErrorCode = "invalid_expiry_month"
+	CodeInvalidExpiryYear  ErrorCode = "invalid_expiry_year"
+	CodeInvalidNumber      ErrorCode = "invalid_number"
+	CodeMissing            ErrorCode = "missing"
+	CodeProcessingError    ErrorCode = "processing_error"
+	CodeRateLimit          ErrorCode = "rate_limit"
+	CodeResourceMissing    ErrorCode = "resource_missing"
+)
+
+// DeclineCode is the reason given by the card issuer when a card is
+// declined, set on errors with the code card_declined.
+//
+// see https://stripe.com/docs/declines/codes
+type DeclineCode string
+
+// Decline Codes returned by the Stripe API.
+const (
+	DeclineCallIssuer           DeclineCode = "call_issuer"
+	DeclineCardNotSupported     DeclineCode = "card_not_supported"
+	DeclineCurrencyNotSupported DeclineCode = "currency_not_supported"
+	DeclineDoNotHonor           DeclineCode = "do_not_honor"
+	DeclineDuplicateTransaction DeclineCode = "duplicate_transaction"
+	DeclineExpiredCard          DeclineCode = "expired_card"
+	DeclineFraudulent           DeclineCode = "fraudulent"
+	DeclineGenericDecline       DeclineCode = "generic_decline"
+	DeclineIncorrectCVC         DeclineCode = "incorrect_cvc"
+	DeclineInsufficientFunds    DeclineCode = "insufficient_funds"
+	DeclineLostCard             DeclineCode = "lost_card"
+	DeclinePickupCard           DeclineCode = "pickup_card"
+	DeclineProcessingError      DeclineCode = "processing_error"
+	DeclineStolenCard           DeclineCode = "stolen_card"
+	DeclineTryAgainLater        DeclineCode = "try_again_later"
+)
+
+// AsError returns the Error returned by the Stripe API that caused err, if
+// any, including the Error wrapped by a RateLimitError.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) && e != nil {
+		return e, true
+	}
+	return nil, false
+}
+
+// IsErrorType reports whether err was returned by the Stripe API with the
+// given type.
+func IsErrorType(err error, t ErrorType) bool {
+	e, ok := AsError(err)
+	return ok && e.Detail.Type == t
+}
+
+// IsErrorCode reports whether err was returned by the Stripe API with the
+// given code.
+func IsErrorCode(err error, code ErrorCode) bool {
+	e, ok := AsError(err)
+	return ok && e.Detail.Code == code
+}
+
+// IsCardError reports whether err is a card error, ie the card could not be
+// charged.
+func IsCardError(err error) bool {
+	return IsErrorType(err, ErrorTypeCard)
+}
+
+// IsInvalidRequest reports whether err is an invalid request error, ie the
+// request had invalid parameters or referred to a missing object.
+func IsInvalidRequest(err error) bool {
+	return IsErrorType(err, ErrorTypeInvalidRequest)
+}
+
+// IsCardDeclined reports whether err is a card error because the card was
+// declined. The reason, when given by the issuer, is in Detail.DeclineCode.
+func IsCardDeclined(err error) bool {
+	return IsCardError(err) && IsErrorCode(err, CodeCardDeclined)
+}
+
+// IsDeclineCode reports whether err is a declined card with the given
+// decline code.
+func IsDeclineCode(err error, code DeclineCode) bool {
+	e, ok := AsError(err)
+	return ok && IsCardDeclined(err) && e.Detail.DeclineCode == code
+}
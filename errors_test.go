@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestErrorCodes will test that the type, code and decline code of an error
+// are decoded, and that the helpers match them, including through a
+// RateLimitError.
+func TestErrorCodes(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/limited") {
+			w.WriteHeader(429)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "code": "rate_limit"}}`)
+			return
+		}
+		w.WriteHeader(402)
+		fmt.Fprint(w, `{"error": {"type": "card_error", "code": "card_declined", "decline_code": "insufficient_funds", "message": "Your card has insufficient funds."}}`)
+	})
+
+	_, err := c.Charges.Get(context.Background(), "ch_1")
+	if e, ok := AsError(err); !ok || e.Detail.DeclineCode != DeclineInsufficientFunds {
+		t.Errorf("Expected decline code insufficient_funds, got %v", err)
+	}
+	if !IsCardError(err) || !IsCardDeclined(err) || !IsDeclineCode(err, DeclineInsufficientFunds) {
+		t.Errorf("Expected declined card error, got %v", err)
+	}
+	if IsInvalidRequest(err) || IsDeclineCode(err, DeclineFraudulent) {
+		t.Errorf("Expected only card declined helpers to match, got %v", err)
+	}
+
+	_, err = c.Charges.Get(context.Background(), "limited")
+	if !IsInvalidRequest(err) || !IsErrorCode(err, CodeRateLimit) {
+		t.Errorf("Expected rate limit error to match its code, got %v", err)
+	}
+	if IsCardDeclined(fmt.Errorf("declined")) {
+		t.Errorf("Expected other errors not to match")
+	}
+}
+
+// TestErrorBody will test that an error response that is not a Stripe error
+// object, ie an HTML page from a proxy, is returned with its status and the
+// start of its body.
+func TestErrorBody(t *testing.T) {
+	body := "<html>" + strings.Repeat("x", 2*maxErrorBody) + "</html>"
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(502)
+		if strings.HasSuffix(r.URL.Path, "/html") {
+			fmt.Fprint(w, body)
+		}
+	})
+
+	_, err := c.Charges.Get(context.Background(), "html")
+	e, ok := AsError(err)
+	if !ok || e.Code != 502 || e.Body != body[:maxErrorBody]+"..." {
+		t.Fatalf("Expected 502 Error with truncated body, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "stripe: unexpected 502 Bad Gateway response: <html>xxx") {
+		t.Errorf("Expected message with status and body, got %q", err.Error())
+	}
+
+	_, err = c.Charges.Get(context.Background(), "empty")
+	if err == nil || err.Error() != "stripe: unexpected 502 Bad Gateway response with an empty body" {
+		t.Errorf("Expected message for empty body, got %v", err)
+	}
+}
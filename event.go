@@ -0,0 +1,131 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Event Types sent by Stripe (not the full list).
+//
+// see https://stripe.com/docs/api#event_types
+const (
+	EventChargeSucceeded             = "charge.succeeded"
+	EventChargeFailed                = "charge.failed"
+	EventChargeRefunded              = "charge.refunded"
+	EventChargeDisputeCreated        = "charge.dispute.created"
+	EventCustomerCreated             = "customer.created"
+	EventCustomerUpdated             = "customer.updated"
+	EventCustomerDeleted             = "customer.deleted"
+	EventCustomerSubscriptionCreated = "customer.subscription.created"
+	EventCustomerSubscriptionUpdated = "customer.subscription.updated"
+	EventCustomerSubscriptionDeleted = "customer.subscription.deleted"
+	EventInvoiceCreated              = "invoice.created"
+	EventInvoicePaymentSucceeded     = "invoice.payment_succeeded"
+	EventInvoicePaymentFailed        = "invoice.payment_failed"
+)
+
+// Event represents a change to an object in your Stripe account, as sent to
+// webhook endpoints.
+//
+// see https://stripe.com/docs/api#event_object
+type Event struct {
+	APIResource
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Created         UnixTime   `json:"created"`
+	Livemode        bool       `json:"livemode"`
+	Data            *EventData `json:"data"`
+	PendingWebhooks int        `json:"pending_webhooks"`
+	Request         string     `json:"request,omitempty"`
+	APIVersion      string     `json:"api_version,omitempty"`
+}
+
+// EventData holds the object an Event is about, as it was at the time of the
+// event, and the previous values of any attributes that changed.
+type EventData struct {
+	Object             json.RawMessage        `json:"object"`
+	PreviousAttributes map[string]interface{} `json:"previous_attributes,omitempty"`
+}
+
+// GetObject decodes the object the Event is about into v, which is typically
+// a pointer to the type matching the event, ie *Charge for charge.succeeded.
+// Events are sent in the account's API version, so the object is first
+// normalized to the shape of the package's types. An Event without data, ie
+// one decoded from a truncated payload, is reported as an error.
+func (e *Event) GetObject(v interface{}) error {
+	if e.Data == nil {
+		return fmt.Errorf("stripe: event %q has no data", e.ID)
+	}
+	return json.Unmarshal(normalize(e.Data.Object, e.APIVersion), v)
+}
+
+// EventListParams encapsulates options for filtering a list of Events.
+type EventListParams struct {
+	ListParams
+
+	// (Optional) Only return events of this type, ie charge.succeeded. A
+	// trailing wildcard matches a group of types, ie charge.*.
+	Type string `form:"type"`
+
+	// (Optional) Only return events of any of these types. Only one of Type
+	// and Types may be set.
+	Types []string `form:"types"`
+
+	// (Optional) Only return events created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// EventClient encapsulates operations for querying events using the Stripe
+// REST API, ie to reconcile webhooks that were missed.
+type EventClient struct{ api }
+
+// Retrieves the Event with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_event
+func (c EventClient) Get(ctx context.Context, id string) (*Event, error) {
+	res := &Event{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Event into v, which may be any type
+// with matching JSON fields.
+func (c EventClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/events/"+url.QueryEscape(id), nil, v)
+}
+
+// Returns a list of Events matching the given filters, or all recent Events
+// when params is nil. Stripe keeps events for 30 days.
+//
+// see https://stripe.com/docs/api#list_events
+func (c EventClient) List(ctx context.Context, params *EventListParams) ([]*Event, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Events matching the given filters, or of all your
+// Events when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_events
+func (c EventClient) ListFiltered(ctx context.Context, params *EventListParams) (*List[Event], error) {
+	res := &List[Event]{}
+	return res, c.query(ctx, "GET", "/events", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Event matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c EventClient) ListAll(ctx context.Context, params *EventListParams) *Iter[Event] {
+	return newIter(ctx, c.backend(), "/events", params, func(e *Event) string { return e.ID })
+}
+
+// ListAllSince returns every Event created at or after t, oldest first,
+// fetching as many pages as needed, ie to replay the webhooks missed during
+// an outage.
+func (c EventClient) ListAllSince(ctx context.Context, t time.Time) ([]*Event, error) {
+	return listAllSince(ctx, c.backend(), "/events", "created", t,
+		func(e *Event) string { return e.ID },
+		func(e *Event) time.Time { return e.Created.Time })
+}
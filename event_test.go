@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestListEvents will test that Events are listed with their type and
+// creation time filters, and that their objects can be decoded.
+func TestListEvents(t *testing.T) {
+	var query string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Path == "/v1/events/evt_1" {
+			fmt.Fprint(w, `{"id": "evt_1", "type": "charge.succeeded", "data": {"object": {"id": "ch_1"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"has_more": false, "data": [{"id": "evt_2", "type": "charge.failed", "data": {"object": {"id": "ch_2"}}}]}`)
+	})
+	ctx := context.Background()
+
+	event, err := c.Events.Get(ctx, "evt_1")
+	if err != nil || event.Type != EventChargeSucceeded {
+		t.Fatalf("Expected Event evt_1, got %v %v", event, err)
+	}
+
+	params := &EventListParams{Type: "charge.*", Created: Since(time.Unix(1400000000, 0))}
+	events, more, err := c.Events.List(ctx, params)
+	if err != nil || more || len(events) != 1 {
+		t.Fatalf("Expected a page of Events, got %v %v %v", events, more, err)
+	}
+	if want := "created%5Bgte%5D=1400000000&type=charge.%2A"; query != want {
+		t.Errorf("Expected query %s, got %s", want, query)
+	}
+	charge := &Charge{}
+	if err := events[0].GetObject(charge); err != nil || charge.ID != "ch_2" {
+		t.Errorf("Expected Charge ch_2, got %v %v", charge.ID, err)
+	}
+}
+
+// TestListAllEventsSince will test that every Event created since a time is
+// fetched across pages and returned oldest first.
+func TestListAllEventsSince(t *testing.T) {
+	var queries []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("starting_after") == "" {
+			fmt.Fprint(w, `{"has_more": true, "data": [{"id": "evt_3", "created": 1400000003}, {"id": "evt_2", "created": 1400000002}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"has_more": false, "data": [{"id": "evt_1", "created": 1400000001}]}`)
+	})
+	events, err := c.Events.ListAllSince(context.Background(), time.Unix(1400000000, 0))
+	if err != nil || len(events) != 3 {
+		t.Fatalf("Expected 3 Events, got %d %v", len(events), err)
+	}
+	for i, e := range events {
+		if want := fmt.Sprintf("evt_%d", i+1); e.ID != want {
+			t.Errorf("Expected Event %d to be %s, got %s", i, want, e.ID)
+		}
+	}
+	if len(queries) != 2 || !strings.Contains(queries[0], "created%5Bgte%5D=1400000000") || !strings.Contains(queries[1], "starting_after=evt_2") {
+		t.Errorf("Expected two pages since 1400000000, got %v", queries)
+	}
+}
+
+// TestGetObjectWithoutData will test that decoding the object of an Event
+// without data returns an error rather than panicking.
+func TestGetObjectWithoutData(t *testing.T) {
+	event := &Event{ID: "evt_1"}
+	if err := event.GetObject(&Charge{}); err == nil {
+		t.Errorf("Expected an error for an Event without data")
+	}
+}
@@ -0,0 +1,82 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Expandable is a reference to another Stripe object. By default Stripe only
+// returns the ID of the referenced object, but when the field is expanded
+// using the expand[] parameter the full object is returned in its place.
+//
+// see https://stripe.com/docs/api#expanding_objects
+type Expandable[T any] struct {
+	id    string
+	value *T
+}
+
+// ID returns the ID of the referenced object, whether or not it was
+// expanded. It is empty if there is no referenced object.
+func (e Expandable[T]) ID() string {
+	return e.id
+}
+
+// Value returns the referenced object if it was expanded, or nil otherwise.
+func (e Expandable[T]) Value() *T {
+	return e.value
+}
+
+// Expanded reports whether the referenced object was expanded.
+func (e Expandable[T]) Expanded() bool {
+	return e.value != nil
+}
+
+func (e *Expandable[T]) UnmarshalJSON(data []byte) error {
+	e.id, e.value = "", nil
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, &e.id)
+	}
+
+	// the reference was expanded into the full object
+	obj := struct {
+		ID string `json:"id"`
+	}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	value := new(T)
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+	e.id, e.value = obj.ID, value
+	return nil
+}
+
+func (e Expandable[T]) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.value != nil:
+		return json.Marshal(e.value)
+	case e.id != "":
+		return json.Marshal(e.id)
+	}
+	return []byte("null"), nil
+}
+
+// resolve returns the referenced object, retrieving it from path/ID with c
+// and caching it if the reference was not expanded. It returns nil if there
+// is no referenced object.
+func (e *Expandable[T]) resolve(ctx context.Context, c *Client, path string) (*T, error) {
+	if e.value != nil || e.id == "" {
+		return e.value, nil
+	}
+	value := new(T)
+	if err := c.query(ctx, "GET", path+"/"+url.QueryEscape(e.id), nil, value); err != nil {
+		return nil, err
+	}
+	e.value = value
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestUnmarshalExpandable will test that a reference is decoded whether it is
+// a bare ID or an expanded object.
+func TestUnmarshalExpandable(t *testing.T) {
+	charge := Charge{}
+	data := `{"id": "ch_1", "customer": "cus_1", "invoice": null}`
+	if err := json.Unmarshal([]byte(data), &charge); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if charge.Customer.ID() != "cus_1" || charge.Customer.Expanded() {
+		t.Errorf("Expected unexpanded Customer cus_1, got %s", charge.Customer.ID())
+	}
+	if charge.Invoice.ID() != "" || charge.Invoice.Value() != nil {
+		t.Errorf("Expected no Invoice, got %s", charge.Invoice.ID())
+	}
+
+	data = `{"id": "ch_1", "customer": {"id": "cus_1", "email": "test1@test.com"}}`
+	if err := json.Unmarshal([]byte(data), &charge); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if charge.Customer.ID() != "cus_1" || !charge.Customer.Expanded() {
+		t.Errorf("Expected expanded Customer cus_1, got %s", charge.Customer.ID())
+		return
+	}
+	if charge.Customer.Value().Email != "test1@test.com" {
+		t.Errorf("Expected Customer Email test1@test.com, got %s", charge.Customer.Value().Email)
+	}
+
+	// an expanded reference should encode back to the full object
+	b, _ := json.Marshal(charge.Customer)
+	ref := Expandable[Customer]{}
+	if err := json.Unmarshal(b, &ref); err != nil || ref.Value().Email != "test1@test.com" {
+		t.Errorf("Expected expanded Customer to round trip, got %s", b)
+	}
+}
+
+// TestWithExpand will test that the fields to expand are sent with a request,
+// and that the expanded objects are returned without further requests.
+func TestWithExpand(t *testing.T) {
+	var expand [][]string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			values, _ = url.ParseQuery(string(body))
+		}
+		expand = append(expand, values["expand[]"])
+		fmt.Fprint(w, `{"id": "ch_1", "customer": {"id": "cus_1"}, "balance_transaction": {"id": "txn_1", "fee": 42}}`)
+	})
+	ctx := WithExpand(context.Background(), "customer", "balance_transaction")
+
+	charge, err := c.Charges.Get(ctx, "ch_1")
+	if err != nil {
+		t.Fatalf("Expected Charge, got Error %s", err.Error())
+	}
+	txn, err := charge.GetBalanceTransaction(context.Background())
+	if err != nil || txn.ID != "txn_1" || txn.Fee != 42 {
+		t.Errorf("Expected expanded BalanceTransaction txn_1, got %v %v", txn, err)
+	}
+	if cust, err := charge.GetCustomer(context.Background()); err != nil || cust.ID != "cus_1" {
+		t.Errorf("Expected expanded Customer cus_1, got %v %v", cust, err)
+	}
+
+	params := &ChargeParams{Amount: 400, Currency: USD, Customer: "cus_1"}
+	if _, err := c.Charges.Create(WithExpand(context.Background(), "customer"), params); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+	}
+	want := [][]string{{"customer", "balance_transaction"}, {"customer"}}
+	if !reflect.DeepEqual(expand, want) {
+		t.Errorf("Expected expand[] %v, got %v", want, expand)
+	}
+}
@@ -0,0 +1,239 @@
+package stripe
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Export Formats
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+)
+
+// ExportOptions encapsulates options for exporting objects created within a
+// range of time.
+type ExportOptions struct {
+	// The creation time from which, inclusive, objects are exported.
+	Start time.Time
+
+	// The creation time until which, exclusive, objects are exported.
+	End time.Time
+
+	// (Optional) Either ExportCSV or ExportNDJSON. Default is ExportCSV.
+	Format string
+
+	// (Optional) The columns to export, in order. Default is every column of
+	// the export, see ChargeColumns, RefundColumns and PayoutColumns.
+	Columns []string
+}
+
+// The columns of each export, in their default order.
+var (
+	ChargeColumns = columnNames(chargeColumns)
+	RefundColumns = columnNames(balanceColumns)
+
+	// Each row of a payout export is a balance transaction included in a
+	// payout, preceded by the payout's details.
+	PayoutColumns = columnNames(payoutColumns)
+)
+
+// exportColumn is a named value exported from each object of type T.
+type exportColumn[T any] struct {
+	name  string
+	value func(*T) interface{}
+}
+
+var chargeColumns = []exportColumn[Charge]{
+	{"id", func(c *Charge) interface{} { return c.ID }},
+	{"created", func(c *Charge) interface{} { return c.Created.UTC().Format(time.RFC3339) }},
+	{"amount", func(c *Charge) interface{} { return c.Amount }},
+	{"amount_refunded", func(c *Charge) interface{} { return c.AmountRefunded }},
+	{"currency", func(c *Charge) interface{} { return c.Currency }},
+	{"paid", func(c *Charge) interface{} { return c.Paid }},
+	{"refunded", func(c *Charge) interface{} { return c.Refunded }},
+	{"customer", func(c *Charge) interface{} { return c.Customer.ID() }},
+	{"description", func(c *Charge) interface{} { return c.Description }},
+	{"failure_code", func(c *Charge) interface{} { return c.FailureCode }},
+	{"balance_transaction", func(c *Charge) interface{} { return c.BalanceTransaction.ID() }},
+}
+
+var balanceColumns = []exportColumn[BalanceTransaction]{
+	{"id", func(t *BalanceTransaction) interface{} { return t.ID }},
+	{"source", func(t *BalanceTransaction) interface{} { return t.Source }},
+	{"type", func(t *BalanceTransaction) interface{} { return t.Type }},
+	{"created", func(t *BalanceTransaction) interface{} { return t.Created.UTC().Format(time.RFC3339) }},
+	{"available_on", func(t *BalanceTransaction) interface{} { return t.AvailableOn.UTC().Format(time.RFC3339) }},
+	{"amount", func(t *BalanceTransaction) interface{} { return t.Amount }},
+	{"fee", func(t *BalanceTransaction) interface{} { return t.Fee }},
+	{"net", func(t *BalanceTransaction) interface{} { return t.Net }},
+	{"currency", func(t *BalanceTransaction) interface{} { return t.Currency }},
+	{"status", func(t *BalanceTransaction) interface{} { return t.Status }},
+	{"description", func(t *BalanceTransaction) interface{} { return t.Description }},
+}
+
+// payoutRow is a balance transaction included in a payout.
+type payoutRow struct {
+	payout *Payout
+	tx     *BalanceTransaction
+}
+
+var payoutColumns = append([]exportColumn[payoutRow]{
+	{"payout", func(r *payoutRow) interface{} { return r.payout.ID }},
+	{"payout_arrival_date", func(r *payoutRow) interface{} { return r.payout.ArrivalDate.UTC().Format(time.RFC3339) }},
+	{"payout_amount", func(r *payoutRow) interface{} { return r.payout.Amount }},
+	{"payout_status", func(r *payoutRow) interface{} { return r.payout.Status }},
+}, wrapColumns(balanceColumns, func(r *payoutRow) *BalanceTransaction { return r.tx })...)
+
+// ExportCharges writes every Charge created within the range of opts to w,
+// newest first, as pages are fetched.
+func (c *Client) ExportCharges(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	e, err := newExporter(w, opts, chargeColumns)
+	if err != nil {
+		return err
+	}
+	err = listAll(ctx, c, "/charges", exportRange(opts), func(c *Charge) string { return c.ID }, e.write)
+	return e.close(err)
+}
+
+// ExportRefunds writes the balance transaction of every refund created within
+// the range of opts to w, newest first, as pages are fetched.
+func (c *Client) ExportRefunds(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	e, err := newExporter(w, opts, balanceColumns)
+	if err != nil {
+		return err
+	}
+	values := exportRange(opts)
+	values.Add("type", TxRefund)
+	err = listAll(ctx, c, "/balance_transactions", values, func(t *BalanceTransaction) string { return t.ID }, e.write)
+	return e.close(err)
+}
+
+// ExportPayouts writes, for every Payout created within the range of opts, the
+// balance transactions it pays out to w, so the payout can be reconciled
+// against the charges, refunds and fees that make it up.
+func (c *Client) ExportPayouts(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	e, err := newExporter(w, opts, payoutColumns)
+	if err != nil {
+		return err
+	}
+	err = listAll(ctx, c, "/payouts", exportRange(opts), func(p *Payout) string { return p.ID }, func(p *Payout) error {
+		values := url.Values{"payout": {p.ID}}
+		return listAll(ctx, c, "/balance_transactions", values, func(t *BalanceTransaction) string { return t.ID }, func(t *BalanceTransaction) error {
+			return e.write(&payoutRow{payout: p, tx: t})
+		})
+	})
+	return e.close(err)
+}
+
+// ExportCharges is like Client.ExportCharges, using the default client.
+func ExportCharges(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	return _default.ExportCharges(ctx, w, opts)
+}
+
+// ExportRefunds is like Client.ExportRefunds, using the default client.
+func ExportRefunds(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	return _default.ExportRefunds(ctx, w, opts)
+}
+
+// ExportPayouts is like Client.ExportPayouts, using the default client.
+func ExportPayouts(ctx context.Context, w io.Writer, opts *ExportOptions) error {
+	return _default.ExportPayouts(ctx, w, opts)
+}
+
+// exportRange returns the list parameters for the creation range of opts.
+func exportRange(opts *ExportOptions) url.Values {
+	values := make(url.Values)
+	start, end := UnixTime{opts.Start}, UnixTime{opts.End}
+	appendForm(values, "created", &DateRange{GTE: &start, LT: &end})
+	return values
+}
+
+func columnNames[T any](cols []exportColumn[T]) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.name
+	}
+	return names
+}
+
+// wrapColumns adapts the columns of T to rows of R that contain a T.
+func wrapColumns[R, T any](cols []exportColumn[T], get func(*R) *T) []exportColumn[R] {
+	res := make([]exportColumn[R], 0, len(cols))
+	for _, col := range cols {
+		value := col.value
+		res = append(res, exportColumn[R]{col.name, func(r *R) interface{} { return value(get(r)) }})
+	}
+	return res
+}
+
+// exporter writes the selected columns of objects of type T as CSV or NDJSON.
+type exporter[T any] struct {
+	cols []exportColumn[T]
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newExporter[T any](w io.Writer, opts *ExportOptions, all []exportColumn[T]) (*exporter[T], error) {
+	e := &exporter[T]{cols: all}
+	if len(opts.Columns) > 0 {
+		e.cols = nil
+		for _, name := range opts.Columns {
+			found := false
+			for _, col := range all {
+				if col.name == name {
+					e.cols = append(e.cols, col)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("stripe: unknown export column %q", name)
+			}
+		}
+	}
+
+	switch opts.Format {
+	case "", ExportCSV:
+		e.csv = csv.NewWriter(w)
+		if err := e.csv.Write(columnNames(e.cols)); err != nil {
+			return nil, err
+		}
+	case ExportNDJSON:
+		e.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("stripe: unknown export format %q", opts.Format)
+	}
+	return e, nil
+}
+
+func (e *exporter[T]) write(obj *T) error {
+	if e.json != nil {
+		row := make(map[string]interface{}, len(e.cols))
+		for _, col := range e.cols {
+			row[col.name] = col.value(obj)
+		}
+		return e.json.Encode(row)
+	}
+	row := make([]string, len(e.cols))
+	for i, col := range e.cols {
+		row[i] = fmt.Sprint(col.value(obj))
+	}
+	return e.csv.Write(row)
+}
+
+// close flushes any buffered output, returning err if it is not nil.
+func (e *exporter[T]) close(err error) error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err == nil {
+			err = e.csv.Error()
+		}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExport will test that exports write the selected columns as CSV or
+// NDJSON, and that payout exports include each payout's transactions.
+func TestExport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/v1/charges":
+			if q.Get("created[gte]") != "100" || q.Get("created[lt]") != "200" {
+				t.Errorf("Expected created range [100, 200), got %v", q)
+			}
+			fmt.Fprint(w, `{"data": [{"id": "ch_2", "amount": 500, "customer": "cus_1"}, {"id": "ch_1", "amount": 300, "description": "a, b"}]}`)
+		case r.URL.Path == "/v1/payouts":
+			fmt.Fprint(w, `{"data": [{"id": "po_1", "amount": 700, "status": "paid"}]}`)
+		case r.URL.Path == "/v1/balance_transactions" && q.Get("payout") == "po_1":
+			fmt.Fprint(w, `{"data": [{"id": "txn_1", "type": "charge", "net": 485}, {"id": "txn_2", "type": "charge", "net": 215}]}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	ctx := context.Background()
+	opts := &ExportOptions{Start: time.Unix(100, 0), End: time.Unix(200, 0), Columns: []string{"id", "amount", "description"}}
+	buf := new(bytes.Buffer)
+	if err := ExportCharges(ctx, buf, opts); err != nil {
+		t.Fatalf("ExportCharges failed: %s", err)
+	}
+	if want := "id,amount,description\nch_2,500,\nch_1,300,\"a, b\"\n"; buf.String() != want {
+		t.Errorf("Expected CSV %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	opts.Format = ExportNDJSON
+	opts.Columns = []string{"id", "customer"}
+	if err := ExportCharges(ctx, buf, opts); err != nil {
+		t.Fatalf("ExportCharges failed: %s", err)
+	}
+	if want := "{\"customer\":\"cus_1\",\"id\":\"ch_2\"}\n{\"customer\":\"\",\"id\":\"ch_1\"}\n"; buf.String() != want {
+		t.Errorf("Expected NDJSON %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	opts.Format = ""
+	opts.Columns = []string{"payout", "payout_amount", "id", "net"}
+	if err := ExportPayouts(ctx, buf, opts); err != nil {
+		t.Fatalf("ExportPayouts failed: %s", err)
+	}
+	if want := "payout,payout_amount,id,net\npo_1,700,txn_1,485\npo_1,700,txn_2,215\n"; buf.String() != want {
+		t.Errorf("Expected CSV %q, got %q", want, buf.String())
+	}
+
+	opts.Columns = []string{"bogus"}
+	if err := ExportRefunds(ctx, buf, opts); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("Expected unknown column error, got %v", err)
+	}
+}
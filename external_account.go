@@ -0,0 +1,203 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// External Account Types
+const (
+	ExternalAccountBankAccount = "bank_account"
+	ExternalAccountCard        = "card"
+)
+
+// ExternalAccount represents a bank account or debit card attached to a
+// connected account, to which the account's payouts are sent. Depending on
+// Object, exactly one of BankAccount or Card will be set.
+//
+// see https://stripe.com/docs/api#account_external_accounts
+type ExternalAccount struct {
+	APIResource
+	ID          string
+	Object      string
+	BankAccount *BankAccount
+	Card        *Card
+}
+
+func (e *ExternalAccount) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		ID     string `json:"id"`
+		Object string `json:"object"`
+	}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	e.ID = obj.ID
+	e.Object = obj.Object
+
+	switch obj.Object {
+	case ExternalAccountBankAccount:
+		e.BankAccount = &BankAccount{}
+		return json.Unmarshal(data, e.BankAccount)
+	case ExternalAccountCard:
+		e.Card = &Card{}
+		return json.Unmarshal(data, e.Card)
+	}
+	return nil
+}
+
+// DefaultForCurrency reports whether this is the default external account
+// for its currency.
+func (e *ExternalAccount) DefaultForCurrency() bool {
+	switch {
+	case e.BankAccount != nil:
+		return e.BankAccount.DefaultForCurrency
+	case e.Card != nil:
+		return e.Card.DefaultForCurrency
+	}
+	return false
+}
+
+// ExternalAccountParams encapsulates options for creating and updating
+// External Accounts.
+type ExternalAccountParams struct {
+	// (Optional) A bank account or debit card Token. Either Token, BankAccount
+	// or Card is required when creating an External Account.
+	Token string
+
+	// (Optional) Raw bank account details.
+	BankAccount *BankAccountParams
+
+	// (Optional) Raw debit card details. When updating, only the name,
+	// expiration and address fields may be changed.
+	Card *CardParams
+
+	// (Optional) When true, this becomes the default external account for its
+	// currency.
+	DefaultForCurrency *bool `form:"default_for_currency"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// ExternalAccountClient encapsulates operations for creating, updating,
+// deleting and querying the External Accounts of a connected account using
+// the Stripe REST API.
+type ExternalAccountClient struct{ api }
+
+func (c ExternalAccountClient) path(accountID, externalAccountID string) string {
+	p := fmt.Sprintf("/accounts/%s/external_accounts", url.QueryEscape(accountID))
+	if externalAccountID != "" {
+		p += "/" + url.QueryEscape(externalAccountID)
+	}
+	return p
+}
+
+// Creates a new External Account for the given connected account.
+//
+// see https://stripe.com/docs/api#account_create_bank_account
+func (c ExternalAccountClient) Create(ctx context.Context, accountID string, params *ExternalAccountParams) (*ExternalAccount, error) {
+	values := formValues(params)
+	switch {
+	case params.Token != "":
+		values.Add("external_account", params.Token)
+	case params.BankAccount != nil:
+		values.Add("external_account[object]", ExternalAccountBankAccount)
+		appendForm(values, "external_account", params.BankAccount)
+	case params.Card != nil:
+		values.Add("external_account[object]", ExternalAccountCard)
+		appendForm(values, "external_account", params.Card)
+	}
+
+	res := &ExternalAccount{}
+	return res, c.query(ctx, "POST", c.path(accountID, ""), values, res)
+}
+
+// Retrieves the External Account with the given ID.
+//
+// see https://stripe.com/docs/api#account_retrieve_bank_account
+func (c ExternalAccountClient) Get(ctx context.Context, accountID, externalAccountID string) (*ExternalAccount, error) {
+	res := &ExternalAccount{}
+	return res, c.GetInto(ctx, accountID, externalAccountID, res)
+}
+
+// GetInto is like Get, but decodes the External Account into v, which may be any
+// type with matching JSON fields.
+func (c ExternalAccountClient) GetInto(ctx context.Context, accountID, externalAccountID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(accountID, externalAccountID), nil, v)
+}
+
+// Updates the External Account with the given ID. Account and routing
+// numbers can not be changed; delete the External Account and create a new
+// one instead.
+//
+// see https://stripe.com/docs/api#account_update_bank_account
+func (c ExternalAccountClient) Update(ctx context.Context, accountID, externalAccountID string, params *ExternalAccountParams) (*ExternalAccount, error) {
+	values := formValues(params)
+	if params.BankAccount != nil {
+		if params.BankAccount.AccountHolderName != "" {
+			values.Add("account_holder_name", params.BankAccount.AccountHolderName)
+		}
+		if params.BankAccount.AccountHolderType != "" {
+			values.Add("account_holder_type", params.BankAccount.AccountHolderType)
+		}
+	}
+	if card := params.Card; card != nil {
+		// only the name, expiration and address of a card can be changed
+		appendForm(values, "", struct {
+			Name           string `form:"name"`
+			ExpMonth       int    `form:"exp_month"`
+			ExpYear        int    `form:"exp_year"`
+			Address1       string `form:"address_line1"`
+			Address2       string `form:"address_line2"`
+			AddressCountry string `form:"address_country"`
+			AddressState   string `form:"address_state"`
+			AddressZip     string `form:"address_zip"`
+		}{card.Name, card.ExpMonth, card.ExpYear, card.Address1, card.Address2, card.AddressCountry, card.AddressState, card.AddressZip})
+	}
+
+	res := &ExternalAccount{}
+	return res, c.query(ctx, "POST", c.path(accountID, externalAccountID), values, res)
+}
+
+// SetDefault makes the External Account with the given ID the default
+// payout destination for its currency.
+//
+// see https://stripe.com/docs/api#account_update_bank_account
+func (c ExternalAccountClient) SetDefault(ctx context.Context, accountID, externalAccountID string) (*ExternalAccount, error) {
+	values := url.Values{"default_for_currency": {"true"}}
+	res := &ExternalAccount{}
+	return res, c.query(ctx, "POST", c.path(accountID, externalAccountID), values, res)
+}
+
+// Deletes the External Account with the given ID. The default External
+// Account for a currency can not be deleted.
+//
+// see https://stripe.com/docs/api#account_delete_bank_account
+func (c ExternalAccountClient) Delete(ctx context.Context, accountID, externalAccountID string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", c.path(accountID, externalAccountID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the External Accounts of the given connected account.
+//
+// see https://stripe.com/docs/api#account_list_bank_accounts
+func (c ExternalAccountClient) List(ctx context.Context, accountID string, limit int, before, after string) ([]*ExternalAccount, bool, error) {
+	res := struct {
+		ListObject
+		Data []*ExternalAccount
+	}{}
+	err := c.query(ctx, "GET", c.path(accountID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the external accounts of the Account with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#account_list_bank_accounts
+func (c ExternalAccountClient) ListFiltered(ctx context.Context, accountID string, params *ListParams) (*List[ExternalAccount], error) {
+	res := &List[ExternalAccount]{}
+	return res, c.query(ctx, "GET", c.path(accountID, ""), formValues(params), res)
+}
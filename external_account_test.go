@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestUnmarshalExternalAccount will test that an External Account is decoded
+// into either a Bank Account or a Card, depending on its object type.
+func TestUnmarshalExternalAccount(t *testing.T) {
+	accounts := []*ExternalAccount{}
+	data := `[
+		{"id": "ba_1", "object": "bank_account", "last4": "6789", "default_for_currency": true},
+		{"id": "card_1", "object": "card", "last4": "4242"}
+	]`
+	if err := json.Unmarshal([]byte(data), &accounts); err != nil {
+		t.Errorf("Expected External Accounts, got Error %s", err.Error())
+		return
+	}
+
+	if accounts[0].BankAccount == nil || accounts[0].Card != nil {
+		t.Errorf("Expected Bank Account for %s", accounts[0].ID)
+	} else if accounts[0].BankAccount.Last4 != "6789" {
+		t.Errorf("Expected Bank Account Last4 6789, got %s", accounts[0].BankAccount.Last4)
+	}
+	if !accounts[0].DefaultForCurrency() {
+		t.Errorf("Expected Bank Account to be default for currency")
+	}
+
+	if accounts[1].Card == nil || accounts[1].BankAccount != nil {
+		t.Errorf("Expected Card for %s", accounts[1].ID)
+	} else if accounts[1].Card.Last4 != "4242" {
+		t.Errorf("Expected Card Last4 4242, got %s", accounts[1].Card.Last4)
+	}
+}
+
+// TestCreateExternalAccount will test that an External Account is added from
+// either a Token or raw bank account details nested under external_account.
+func TestCreateExternalAccount(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "ba_1", "object": "bank_account", "last4": "6789", "default_for_currency": true}`)
+	})
+	ctx := context.Background()
+	isDefault := true
+	ea, err := c.ExternalAccounts.Create(ctx, "acct_1", &ExternalAccountParams{Token: "btok_1", DefaultForCurrency: &isDefault})
+	if err != nil || ea.BankAccount == nil || !ea.DefaultForCurrency() {
+		t.Fatalf("Expected default Bank Account, got %+v %v", ea, err)
+	}
+	if want := (url.Values{"external_account": {"btok_1"}, "default_for_currency": {"true"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	_, err = c.ExternalAccounts.Create(ctx, "acct_1", &ExternalAccountParams{BankAccount: &BankAccountParams{
+		Country:       "US",
+		Currency:      "usd",
+		AccountNumber: "000123456789",
+		RoutingNumber: "110000000",
+	}})
+	want := url.Values{
+		"external_account[object]":         {"bank_account"},
+		"external_account[country]":        {"US"},
+		"external_account[currency]":       {"usd"},
+		"external_account[account_number]": {"000123456789"},
+		"external_account[routing_number]": {"110000000"},
+	}
+	if err != nil || !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v %v", want, srv.forms[1], err)
+	}
+}
+
+// TestUpdateExternalAccount will test that only the name, expiration and
+// address of a debit card are sent when its External Account is updated.
+func TestUpdateExternalAccount(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "card_1", "object": "card", "last4": "4242"}`)
+	})
+	_, err := c.ExternalAccounts.Update(context.Background(), "acct_1", "card_1", &ExternalAccountParams{
+		Card: &CardParams{
+			Name:       "Jenny Rosen",
+			Number:     "4000056655665556",
+			ExpMonth:   6,
+			ExpYear:    2030,
+			CVC:        "123",
+			AddressZip: "94103",
+		},
+		Metadata: map[string]string{"order": "1"},
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	want := url.Values{
+		"name":            {"Jenny Rosen"},
+		"exp_month":       {"6"},
+		"exp_year":        {"2030"},
+		"address_zip":     {"94103"},
+		"metadata[order]": {"1"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+	if want := []string{"POST /v1/accounts/acct_1/external_accounts/card_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
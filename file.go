@@ -0,0 +1,106 @@
+package stripe
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// File Purposes
+const (
+	PurposeBusinessLogo           = "business_logo"
+	PurposeCustomerSignature      = "customer_signature"
+	PurposeDisputeEvidence        = "dispute_evidence"
+	PurposeIdentityDocument       = "identity_document"
+	PurposeAdditionalVerification = "additional_verification"
+	PurposeTaxDocumentUserUpload  = "tax_document_user_upload"
+)
+
+// File represents a document that was uploaded to Stripe, such as dispute
+// evidence or an identity document.
+//
+// see https://stripe.com/docs/api#file_object
+type File struct {
+	APIResource
+	ID        string    `json:"id"`
+	Created   UnixTime  `json:"created"`
+	ExpiresAt *UnixTime `json:"expires_at,omitempty"`
+	Filename  string    `json:"filename"`
+	Purpose   string    `json:"purpose"`
+	Size      int       `json:"size"`
+	Title     string    `json:"title,omitempty"`
+	Type      string    `json:"type"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// FileParams encapsulates options for uploading a new File.
+type FileParams struct {
+	// The purpose of the uploaded file, such as dispute_evidence or
+	// identity_document.
+	Purpose string `form:"purpose"`
+
+	// The name of the file, including its extension (e.g. receipt.pdf),
+	// which Stripe uses to determine the file type.
+	Filename string
+
+	// The contents of the file.
+	Reader io.Reader
+}
+
+// FileClient encapsulates operations for uploading and querying files using
+// the Stripe REST API.
+type FileClient struct{ api }
+
+// Uploads a new File.
+//
+// see https://stripe.com/docs/api#create_file
+func (c FileClient) Create(ctx context.Context, params *FileParams) (*File, error) {
+	res := &File{}
+	return res, c.upload(ctx, "/files", formValues(params), params.Filename, params.Reader, res)
+}
+
+// Retrieves the File with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_file
+func (c FileClient) Get(ctx context.Context, id string) (*File, error) {
+	res := &File{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the File into v, which may be any
+// type with matching JSON fields.
+func (c FileClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/files/"+url.QueryEscape(id), nil, v)
+}
+
+// Returns a list of your Files with the given purpose, or all of your Files
+// if purpose is empty.
+//
+// see https://stripe.com/docs/api#list_files
+func (c FileClient) List(ctx context.Context, purpose string, limit int, before, after string) ([]*File, bool, error) {
+	res := struct {
+		ListObject
+		Data []*File
+	}{}
+	params := listParams(limit, before, after)
+	if purpose != "" {
+		params.Add("purpose", purpose)
+	}
+	err := c.query(ctx, "GET", "/files", params, &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Files with the given purpose, or of all your Files when
+// purpose is empty, with the pagination options of params, along with the
+// metadata of the list.
+//
+// see https://stripe.com/docs/api#list_files
+func (c FileClient) ListFiltered(ctx context.Context, purpose string, params *ListParams) (*List[File], error) {
+	values := formValues(params)
+	if purpose != "" {
+		values.Set("purpose", purpose)
+	}
+
+	res := &List[File]{}
+	return res, c.query(ctx, "GET", "/files", values, res)
+}
@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// FileLink represents a publicly accessible URL for downloading a File,
+// which can optionally expire.
+//
+// see https://stripe.com/docs/api#file_link_object
+type FileLink struct {
+	APIResource
+	ID        string            `json:"id"`
+	Created   UnixTime          `json:"created"`
+	Expired   bool              `json:"expired"`
+	ExpiresAt *UnixTime         `json:"expires_at,omitempty"`
+	File      string            `json:"file"`
+	URL       string            `json:"url"`
+	Livemode  bool              `json:"livemode"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// FileLinkParams encapsulates options for creating and updating File Links.
+type FileLinkParams struct {
+	// The ID of the File to link to. Only required when creating a link.
+	File string `form:"file"`
+
+	// (Optional) The time at which the link expires. If not set, the link
+	// never expires.
+	ExpiresAt *UnixTime `form:"expires_at"`
+
+	// (Optional) When updating, expire the link immediately. Overrides
+	// ExpiresAt.
+	ExpireNow bool
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// FileLinkListParams encapsulates options for filtering a list of File Links.
+type FileLinkListParams struct {
+	ListParams
+
+	// (Optional) Only return links for the File with the given ID.
+	File string `form:"file"`
+
+	// (Optional) Filter links by their expiration status.
+	Expired *bool `form:"expired"`
+
+	// (Optional) Only return links created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// FileLinkClient encapsulates operations for creating, updating and querying
+// file links using the Stripe REST API.
+type FileLinkClient struct{ api }
+
+// Creates a new File Link for the given File.
+//
+// see https://stripe.com/docs/api#create_file_link
+func (c FileLinkClient) Create(ctx context.Context, params *FileLinkParams) (*FileLink, error) {
+	res := &FileLink{}
+	return res, c.query(ctx, "POST", "/file_links", fileLinkValues(params), res)
+}
+
+// Retrieves the File Link with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_file_link
+func (c FileLinkClient) Get(ctx context.Context, id string) (*FileLink, error) {
+	res := &FileLink{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the File Link into v, which may be any
+// type with matching JSON fields.
+func (c FileLinkClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/file_links/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the expiration or metadata of the File Link with the given ID.
+// Expired links can not be updated.
+//
+// see https://stripe.com/docs/api#update_file_link
+func (c FileLinkClient) Update(ctx context.Context, id string, params *FileLinkParams) (*FileLink, error) {
+	res := &FileLink{}
+	return res, c.query(ctx, "POST", "/file_links/"+url.QueryEscape(id), fileLinkValues(params), res)
+}
+
+// Returns a list of File Links matching the given filters, or all of your
+// File Links when params is nil.
+//
+// see https://stripe.com/docs/api#list_file_links
+func (c FileLinkClient) List(ctx context.Context, params *FileLinkListParams) ([]*FileLink, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of File Links matching the given filters, or of all your
+// File Links when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_file_links
+func (c FileLinkClient) ListFiltered(ctx context.Context, params *FileLinkListParams) (*List[FileLink], error) {
+	res := &List[FileLink]{}
+	return res, c.query(ctx, "GET", "/file_links", formValues(params), res)
+}
+
+func fileLinkValues(params *FileLinkParams) url.Values {
+	values := formValues(params)
+	if params.ExpireNow {
+		values.Set("expires_at", "now")
+	}
+	return values
+}
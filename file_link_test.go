@@ -0,0 +1,52 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestFileLinks will test that File Links are created with their expiration,
+// expired immediately when updated with ExpireNow, and listed by their File.
+func TestFileLinks(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"has_more": false, "data": [{"id": "link_1", "file": "file_1"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "link_1", "file": "file_1", "url": "https://files.stripe.com/links/link_1"}`)
+	})
+	ctx := context.Background()
+
+	expiresAt := &UnixTime{time.Unix(1400000000, 0)}
+	link, err := c.FileLinks.Create(ctx, &FileLinkParams{File: "file_1", ExpiresAt: expiresAt, Metadata: map[string]string{"order": "6735"}})
+	if err != nil || link.ID != "link_1" || link.URL == "" {
+		t.Fatalf("Expected File Link link_1, got %v %v", link, err)
+	}
+	if _, err := c.FileLinks.Update(ctx, "link_1", &FileLinkParams{ExpiresAt: expiresAt, ExpireNow: true}); err != nil {
+		t.Fatalf("Expected File Link link_1 to be expired, got %v", err)
+	}
+	expired := false
+	links, more, err := c.FileLinks.List(ctx, &FileLinkListParams{File: "file_1", Expired: &expired})
+	if err != nil || more || len(links) != 1 {
+		t.Fatalf("Expected a page of File Links, got %v %v %v", links, more, err)
+	}
+
+	if want := []string{"POST /v1/file_links", "POST /v1/file_links/link_1", "GET /v1/file_links"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Fatalf("Expected requests %v, got %v", want, srv.paths)
+	}
+	forms := []url.Values{
+		{"file": {"file_1"}, "expires_at": {"1400000000"}, "metadata[order]": {"6735"}},
+		{"expires_at": {"now"}},
+		{"file": {"file_1"}, "expired": {"false"}},
+	}
+	for i, want := range forms {
+		if !reflect.DeepEqual(srv.forms[i], want) {
+			t.Errorf("Expected %v, got %v", want, srv.forms[i])
+		}
+	}
+}
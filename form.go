@@ -0,0 +1,130 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// formValues returns the form-encoded fields of the params struct v, see
+// appendForm.
+func formValues(v interface{}) url.Values {
+	values := make(url.Values)
+	appendForm(values, "", v)
+	return values
+}
+
+// appendForm adds the fields of the params struct v to values. Each field is
+// named by its form tag, nested under prefix (ie card[number]) if one is
+// given, and fields without a tag are not sent. The fields of embedded
+// structs without a tag are added as if they belonged to v.
+//
+// Fields are sent only if they are set: strings and numbers when they are
+// not zero, bools when true, and pointers when they are not nil, so that a
+// pointer can be used to send a zero value. Fields tagged with the always
+// option, ie `form:"amount,always"`, are sent even if zero. Nested structs
+// are sent as key[field], maps as key[name], and slices as key[0], key[1]
+// and so on. A UnixTime is sent as seconds since the epoch.
+func appendForm(values url.Values, prefix string, v interface{}) {
+	rv := reflect.ValueOf(v)
+	if v == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return
+	}
+	appendFormValue(values, prefix, reflect.Indirect(rv))
+}
+
+var unixTimeType = reflect.TypeOf(UnixTime{})
+
+func appendFormValue(values url.Values, key string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		switch {
+		case v.IsNil():
+		case isFormScalar(v.Elem()):
+			// a pointer sends the value it points to, even if zero
+			values.Add(key, formScalar(v.Elem()))
+		default:
+			appendFormValue(values, key, v.Elem())
+		}
+
+	case reflect.Struct:
+		if v.Type() == unixTimeType {
+			if t := v.Interface().(UnixTime); !t.IsZero() {
+				values.Add(key, strconv.FormatInt(t.Unix(), 10))
+			}
+			return
+		}
+		appendFormStruct(values, key, v)
+
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			appendFormValue(values, formKey(key, fmt.Sprint(k.Interface())), v.MapIndex(k))
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			appendFormValue(values, formKey(key, strconv.Itoa(i)), v.Index(i))
+		}
+
+	default:
+		if !v.IsZero() {
+			values.Add(key, formScalar(v))
+		}
+	}
+}
+
+func appendFormStruct(values url.Values, prefix string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("form"), ",")
+		switch {
+		case name == "" && f.Anonymous:
+			appendFormValue(values, prefix, v.Field(i))
+		case name == "" || name == "-":
+			// not sent
+		case opts == "always" && isFormScalar(v.Field(i)):
+			values.Add(formKey(prefix, name), formScalar(v.Field(i)))
+		default:
+			appendFormValue(values, formKey(prefix, name), v.Field(i))
+		}
+	}
+}
+
+// formKey returns the key of the field name nested under prefix.
+func formKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+func isFormScalar(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func formScalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return v.String()
+}
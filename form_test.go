@@ -0,0 +1,104 @@
+package stripe
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestFormValues will test that params structs are encoded by their form
+// tags, including nested structs, maps, embedded structs and pointers to
+// zero values, and that unset and untagged fields are left out.
+func TestFormValues(t *testing.T) {
+	balance := int64(0)
+	trialEnd := UnixTime{time.Unix(1400000000, 0)}
+	got := formValues(&CustomerParams{
+		Email:          "joe@example.com",
+		Card:           &CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: 2030},
+		Balance:        &balance,
+		TrialEnd:       &trialEnd,
+		Metadata:       map[string]string{"order": "123"},
+		IdempotencyKey: "key",
+	})
+	want := url.Values{
+		"email":           {"joe@example.com"},
+		"card[number]":    {"4242424242424242"},
+		"card[exp_month]": {"1"},
+		"card[exp_year]":  {"2030"},
+		"account_balance": {"0"},
+		"trial_end":       {"1400000000"},
+		"metadata[order]": {"123"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got = formValues(&TransferListParams{
+		ListParams: ListParams{Limit: 10},
+		Created:    Since(time.Unix(1400000000, 0)),
+	})
+	want = url.Values{"limit": {"10"}, "created[gte]": {"1400000000"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// a free plan must still send its amount
+	got = formValues(&PlanParams{ID: "free", Interval: "month"})
+	want = url.Values{"id": {"free"}, "interval": {"month"}, "amount": {"0"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// a weekly plan billed every two weeks
+	got = formValues(&PlanParams{ID: "biweekly", Amount: 500, Interval: IntervalWeek, IntervalCount: 2})
+	want = url.Values{"id": {"biweekly"}, "amount": {"500"}, "interval": {"week"}, "interval_count": {"2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// metadata is sent with nested params too
+	got = formValues(&SubscriptionParams{
+		Plan:     "gold",
+		Card:     &CardParams{Number: "4242424242424242", Metadata: map[string]string{"source": "web"}},
+		Metadata: map[string]string{"order": "123"},
+	})
+	want = url.Values{
+		"plan":                   {"gold"},
+		"card[number]":           {"4242424242424242"},
+		"card[metadata][source]": {"web"},
+		"metadata[order]":        {"123"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// slices of nested structs are sent by index
+	got = formValues(&CustomerParams{
+		Shipping: &Shipping{Name: "Jenny Rosen", Address: &Address{Line1: "1 Main St", Country: "US"}},
+		InvoiceSettings: &InvoiceSettings{
+			CustomFields: []*InvoiceCustomField{{Name: "PO", Value: "1234"}},
+			Footer:       "Thanks",
+		},
+		PreferredLocales: []string{"fr", "en"},
+		TaxExempt:        TaxExemptReverse,
+	})
+	want = url.Values{
+		"shipping[name]":                            {"Jenny Rosen"},
+		"shipping[address][line1]":                  {"1 Main St"},
+		"shipping[address][country]":                {"US"},
+		"invoice_settings[custom_fields][0][name]":  {"PO"},
+		"invoice_settings[custom_fields][0][value]": {"1234"},
+		"invoice_settings[footer]":                  {"Thanks"},
+		"preferred_locales[0]":                      {"fr"},
+		"preferred_locales[1]":                      {"en"},
+		"tax_exempt":                                {"reverse"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got := formValues((*ChargeParams)(nil)); len(got) != 0 {
+		t.Errorf("Expected no values for nil params, got %v", got)
+	}
+}
@@ -0,0 +1,26 @@
+package stripe
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResponseTooLargeError is returned when a response body is larger than the
+// maximum set with SetMaxResponseSize.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("stripe: response body exceeds %d bytes", e.Limit)
+}
+
+// SlowResponseError is returned when the headers of a response did not
+// arrive within the timeout set with SetResponseHeaderTimeout.
+type SlowResponseError struct {
+	Timeout time.Duration
+}
+
+func (e *SlowResponseError) Error() string {
+	return fmt.Sprintf("stripe: no response headers within %s", e.Timeout)
+}
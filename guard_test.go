@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestResponseGuards will test that oversized and slow responses are
+// abandoned with typed errors, and that a body which streams slowly after
+// its headers is not.
+func TestResponseGuards(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/plans/slow":
+			time.Sleep(200 * time.Millisecond)
+			fmt.Fprint(w, `{"id": "slow"}`)
+		case "/v1/plans/slow_body":
+			// send the headers in time, but the body slowly
+			fmt.Fprint(w, `{"id": "slow_body"`)
+			w.(http.Flusher).Flush()
+			time.Sleep(150 * time.Millisecond)
+			fmt.Fprint(w, `}`)
+		default:
+			// stream the response, so no content length is sent
+			fmt.Fprintf(w, `{"id": "big", "name": "%s"`, strings.Repeat("x", 4096))
+			w.(http.Flusher).Flush()
+			fmt.Fprint(w, `}`)
+		}
+	}))
+	defer srv.Close()
+
+	url := _default.URL
+	SetUrl(srv.URL)
+	SetMaxResponseSize(1024)
+	SetResponseHeaderTimeout(50 * time.Millisecond)
+	defer SetUrl(url)
+	defer SetMaxResponseSize(0)
+	defer SetResponseHeaderTimeout(0)
+
+	if _, err := Plans.Get(context.Background(), "big"); err == nil {
+		t.Errorf("Expected ResponseTooLargeError, got nil")
+	} else if _, ok := err.(*ResponseTooLargeError); !ok {
+		t.Errorf("Expected ResponseTooLargeError, got %T %s", err, err)
+	}
+
+	if _, err := Plans.Get(context.Background(), "slow"); err == nil {
+		t.Errorf("Expected SlowResponseError, got nil")
+	} else if _, ok := err.(*SlowResponseError); !ok {
+		t.Errorf("Expected SlowResponseError, got %T %s", err, err)
+	}
+
+	if plan, err := Plans.Get(context.Background(), "slow_body"); err != nil || plan.ID != "slow_body" {
+		t.Errorf("Expected Plan slow_body, whose headers arrived in time, got %v", err)
+	}
+
+	SetMaxResponseSize(0)
+	if plan, err := Plans.Get(context.Background(), "big"); err != nil || plan.ID != "big" {
+		t.Errorf("Expected Plan big without a size limit, got %v", err)
+	}
+}
+
+// TestTimeout will test that a request, including its retries, is canceled
+// once the timeout elapses.
+func TestTimeout(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+		fmt.Fprint(w, `{"error": {"type": "api_error"}}`)
+	})
+	c.RetryPolicy = &Backoff{Base: 20 * time.Millisecond}
+	c.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := c.Plans.Get(context.Background(), "gold")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected request to be canceled after 100ms, took %s", elapsed)
+	}
+}
@@ -1,8 +1,10 @@
 package stripe
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // Invoice represents statements of what a customer owes for a particular
@@ -11,28 +13,30 @@ import (
 //
 // see https://stripe.com/docs/api#invoice_object
 type Invoice struct {
+	APIResource
 	ID                 string            `json:"id"`
-	AmountDue          int               `json:"amount_due"`
+	AmountDue          int64             `json:"amount_due"`
 	AttemptCount       int               `json:"attempt_count"`
 	Attempted          bool              `json:"attempted"`
 	Closed             bool              `json:"closed"`
 	Paid               bool              `json:"paid"`
 	PeriodEnd          UnixTime          `json:"period_end"`
 	PeriodStart        UnixTime          `json:"period_start"`
-	Subtotal           int               `json:"subtotal"`
-	Total              int               `json:"total"`
+	Subtotal           int64             `json:"subtotal"`
+	Total              int64             `json:"total"`
 	Currency           string            `json:"currency"`
 	Charge             string            `json:"charge,omitempty"`
 	Customer           string            `json:"customer"`
 	Date               UnixTime          `json:"date"`
 	Discount           *Discount         `json:"discount,omitempty"`
 	Lines              *InvoiceLines     `json:"lines"`
-	StartingBalance    int               `json:"starting_balance"`
-	EndingBalance      int               `json:"ending_balance"`
+	StartingBalance    int64             `json:"starting_balance"`
+	EndingBalance      int64             `json:"ending_balance"`
 	NextPaymentAttempt *UnixTime         `json:"next_payment_attempt,omitempty"`
 	Livemode           bool              `json:"livemode"`
 	Metadata           map[string]string `json:"metadata"`
-	Description        string            `json:"omitempty"`
+	Description        string            `json:"description,omitempty"`
+	Subscription       string            `json:"subscription,omitempty"`
 }
 
 // InvoiceLines represents an individual line items that is part of an invoice.
@@ -44,7 +48,7 @@ type InvoiceLines struct {
 type InvoiceLineItem struct {
 	ID          string            `json:"id"`
 	Livemode    bool              `json:"livemode"`
-	Amount      int               `json:"amount"`
+	Amount      int64             `json:"amount"`
 	Currency    string            `json:"currency"`
 	Period      Period            `json:"period"`
 	Proration   bool              `json:"proration"`
@@ -62,72 +66,152 @@ type Period struct {
 
 type InvoiceParams struct {
 	// The customer ID to invoice
-	Customer string
+	Customer string `form:"customer"`
 
 	// (Optional) Invoice description
-	Description string
+	Description string `form:"description"`
 
 	// (Optional) Invoice metadata
-	Metadata map[string]string
+	Metadata map[string]string `form:"metadata"`
 
 	// (Optional) The ID of the subscription to invoice. If not set, the created
 	// invoice will include all pending invoice items for the customer.
-	Subscription string
+	Subscription string `form:"subscription"`
 
 	// (Optional) Boolean representing whether an invoice is closed or not.
-	Closed *bool
+	Closed *bool `form:"closed"`
+}
+
+// InvoiceListParams encapsulates options for filtering a list of Invoices.
+type InvoiceListParams struct {
+	ListParams
+
+	// (Optional) Only return invoices for the customer with this ID.
+	Customer string `form:"customer"`
+
+	// (Optional) Only return invoices dated within this range. Invoices of
+	// this API version have a date rather than a creation time.
+	Date *DateRange `form:"date"`
 }
 
 // InvoiceClient encapsulates operations for querying invoices using the Stripe
 // REST API.
-type InvoiceClient struct{}
+type InvoiceClient struct{ api }
 
 // Retrieves the invoice with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_invoice
-func (InvoiceClient) Get(id string) (*Invoice, error) {
+func (c InvoiceClient) Get(ctx context.Context, id string) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("GET", "/invoices/"+url.QueryEscape(id), nil, res)
+	return res, c.GetInto(ctx, id, res)
 }
 
-func (InvoiceClient) Create(params *InvoiceParams) (*Invoice, error) {
+// GetInto is like Get, but decodes the Invoice into v, which may be any
+// type with matching JSON fields.
+func (c InvoiceClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/invoices/"+url.QueryEscape(id), nil, v)
+}
+
+func (c InvoiceClient) Create(ctx context.Context, params *InvoiceParams) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("POST", "/invoices", invoiceValues(params), res)
+	return res, c.query(ctx, "POST", "/invoices", formValues(params), res)
 }
 
-func (InvoiceClient) Update(id string, params *InvoiceParams) (*Invoice, error) {
+func (c InvoiceClient) Update(ctx context.Context, id string, params *InvoiceParams) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("POST", "/invoices/"+url.QueryEscape(id), invoiceValues(params), res)
+	return res, c.query(ctx, "POST", "/invoices/"+url.QueryEscape(id), formValues(params), res)
 }
 
-func (InvoiceClient) Pay(id string) (*Invoice, error) {
+func (c InvoiceClient) Pay(ctx context.Context, id string) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("POST", fmt.Sprintf("/invoices/%s/pay", url.QueryEscape(id)), nil, res)
+	return res, c.query(ctx, "POST", fmt.Sprintf("/invoices/%s/pay", url.QueryEscape(id)), nil, res)
+}
+
+// UpcomingInvoiceParams encapsulates options for previewing the upcoming
+// invoice of a customer if one of their subscriptions were changed.
+type UpcomingInvoiceParams struct {
+	// (Optional) The identifier of the subscription to change. If not set,
+	// the preview is of a new subscription to Plan.
+	Subscription string `form:"subscription"`
+
+	// (Optional) The code of a coupon to preview applying to the customer.
+	Coupon string `form:"coupon"`
+
+	// (Optional) The identifier of the plan to preview switching to.
+	Plan string `form:"subscription_plan"`
+
+	// (Optional) The quantity to preview for the subscription.
+	Quantity int `form:"subscription_quantity"`
+
+	// (Optional) Whether to preview prorating the change. Default is true.
+	Prorate *bool `form:"subscription_prorate"`
+
+	// (Optional) The time at which the change is prorated. Passing the same
+	// time when making the change ensures the preview is accurate.
+	ProrationDate *UnixTime `form:"subscription_proration_date"`
+
+	// (Optional) The end of the trial period to preview for the
+	// subscription.
+	TrialEnd *UnixTime `form:"subscription_trial_end"`
 }
 
-// Retrieves the upcoming invoice the given customer ID.
+// Retrieves the upcoming invoice for the given customer ID, as it would be if
+// the subscription were changed as described by params, if not nil.
 //
 // see https://stripe.com/docs/api#retrieve_customer_invoice
-func (InvoiceClient) Upcoming(customerID string) (*Invoice, error) {
+func (c InvoiceClient) Upcoming(ctx context.Context, customerID string, params *UpcomingInvoiceParams) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("GET", "/invoices/upcoming", url.Values{"customer": {customerID}}, res)
+	return res, c.query(ctx, "GET", "/invoices/upcoming", upcomingValues(customerID, params), res)
 }
 
 // Returns a list of Invoices at the specified range.
 //
 // see https://stripe.com/docs/api#list_customer_invoices
-func (c InvoiceClient) List(limit int, before, after string) ([]*Invoice, bool, error) {
-	return c.list("", limit, before, after)
+func (c InvoiceClient) List(ctx context.Context, limit int, before, after string) ([]*Invoice, bool, error) {
+	return c.list(ctx, "", limit, before, after)
+}
+
+// ListAll returns an Iter over every Invoice matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c InvoiceClient) ListAll(ctx context.Context, params *InvoiceListParams) *Iter[Invoice] {
+	return newIter(ctx, c.backend(), "/invoices", params, func(inv *Invoice) string { return inv.ID })
+}
+
+// Returns a page of Invoices matching the given filters, or of all your
+// Invoices when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_customer_invoices
+func (c InvoiceClient) ListFiltered(ctx context.Context, params *InvoiceListParams) (*List[Invoice], error) {
+	res := &List[Invoice]{}
+	return res, c.query(ctx, "GET", "/invoices", formValues(params), res)
 }
 
 // Returns a list of Invoices with the given Customer ID.
 //
 // see https://stripe.com/docs/api#list_customer_invoices
-func (c InvoiceClient) CustomerList(id string, limit int, before, after string) ([]*Invoice, bool, error) {
-	return c.list(id, limit, before, after)
+func (c InvoiceClient) CustomerList(ctx context.Context, id string, limit int, before, after string) ([]*Invoice, bool, error) {
+	return c.list(ctx, id, limit, before, after)
+}
+
+// ListAllSince returns every Invoice dated at or after t, oldest first,
+// fetching as many pages as needed. Invoices of this API version have a date
+// rather than a creation time.
+func (c InvoiceClient) ListAllSince(ctx context.Context, t time.Time) ([]*Invoice, error) {
+	return listAllSince(ctx, c.backend(), "/invoices", "date", t,
+		func(inv *Invoice) string { return inv.ID },
+		func(inv *Invoice) time.Time { return inv.Date.Time })
+}
+
+// Stream sends every Invoice matching the filters of params, which may be
+// nil, newest first, on the returned channel as pages are fetched in the
+// background. The Invoice channel is closed when the list is exhausted or
+// ctx is done, after which the error channel reports any failure.
+func (c InvoiceClient) Stream(ctx context.Context, params *InvoiceListParams) (<-chan *Invoice, <-chan error) {
+	return stream(ctx, c.backend(), "/invoices", params, func(inv *Invoice) string { return inv.ID })
 }
 
-func (InvoiceClient) list(id string, limit int, before, after string) ([]*Invoice, bool, error) {
+func (c InvoiceClient) list(ctx context.Context, id string, limit int, before, after string) ([]*Invoice, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Invoice
@@ -137,24 +221,12 @@ func (InvoiceClient) list(id string, limit int, before, after string) ([]*Invoic
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/invoices", params, &res)
+	err := c.query(ctx, "GET", "/invoices", params, &res)
 	return res.Data, res.More, err
 }
 
-func invoiceValues(inv *InvoiceParams) url.Values {
-	values := make(url.Values)
-	if inv.Customer != "" {
-		values.Add("customer", inv.Customer)
-	}
-	if inv.Description != "" {
-		values.Add("description", inv.Description)
-	}
-	if inv.Subscription != "" {
-		values.Add("subscription", inv.Subscription)
-	}
-	if inv.Closed != nil {
-		values.Add("closed", fmt.Sprintf("%t", *inv.Closed))
-	}
-	appendMetadata(values, inv.Metadata)
+func upcomingValues(customerID string, params *UpcomingInvoiceParams) url.Values {
+	values := formValues(params)
+	values.Set("customer", customerID)
 	return values
 }
@@ -1,8 +1,10 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
 	"net/url"
-	"strconv"
+	"sync"
 )
 
 // InvoiceItem represents a charge (or credit) that should be applied to the
@@ -10,8 +12,9 @@ import (
 //
 // see https://stripe.com/docs/api#invoiceitem_object
 type InvoiceItem struct {
+	APIResource
 	ID           string            `json:"id"`
-	Amount       int               `json:"amount"`
+	Amount       int64             `json:"amount"`
 	Currency     string            `json:"currency"`
 	Customer     string            `json:"customer"`
 	Date         UnixTime          `json:"date"`
@@ -19,6 +22,7 @@ type InvoiceItem struct {
 	Invoice      string            `json:"invoice,omitempty"`
 	Subscription string            `json:"subscription,omitempty"`
 	Proration    bool              `json:"proration"`
+	TaxRates     []*TaxRate        `json:"tax_rates,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
 	Livemode     bool              `json:"livemode"`
 }
@@ -27,99 +31,178 @@ type InvoiceItem struct {
 type InvoiceItemParams struct {
 	// The ID of the customer who will be billed when this invoice item is
 	// billed.
-	Customer string
+	Customer string `form:"customer"`
 
 	// The integer amount in cents of the charge to be applied to the upcoming
 	// invoice. If you want to apply a credit to the customer's account, pass a
 	// negative amount.
-	Amount int
+	Amount int64 `form:"amount"`
 
 	// 3-letter ISO code for currency.
-	Currency string
+	Currency string `form:"currency"`
 
 	// (Optional) An arbitrary string which you can attach to the invoice item.
 	// The description is displayed in the invoice for easy tracking.
-	Description string
+	Description string `form:"description"`
 
 	// (Optional) The ID of an existing invoice to add this invoice item to.
 	// When left blank, the invoice item will be added to the next upcoming
 	// scheduled invoice.
-	Invoice string
+	Invoice string `form:"invoice"`
 
 	// (Optional) The ID of a subscription to add this invoice item to.
-	Subscription string
+	Subscription string `form:"subscription"`
 
-	Metadata map[string]string
+	// (Optional) The IDs of the TaxRates applied to the invoice item.
+	TaxRates []string `form:"tax_rates"`
+
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows a Create request to be safely
+	// retried without adding the item twice.
+	IdempotencyKey string
 }
 
 // InvoiceItemClient encapsulates operations for creating, updating, deleting
 // and querying invoices using the Stripe REST API.
-type InvoiceItemClient struct{}
+type InvoiceItemClient struct{ api }
 
 // Create adds an arbitrary charge or credit to the customer's upcoming invoice.
 //
 // see https://stripe.com/docs/api#invoiceitem_object
-func (InvoiceItemClient) Create(params *InvoiceItemParams) (*InvoiceItem, error) {
+func (c InvoiceItemClient) Create(ctx context.Context, params *InvoiceItemParams) (*InvoiceItem, error) {
 	item := InvoiceItem{}
-	values := url.Values{
-		"amount":   {strconv.Itoa(params.Amount)},
-		"currency": {params.Currency},
-		"customer": {params.Customer},
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
 	}
+	err := c.query(ctx, "POST", "/invoiceitems", formValues(params), &item)
+	return &item, err
+}
+
+// BatchOptions configures the creation of many objects at once.
+type BatchOptions struct {
+	// (Optional) The maximum number of objects created at the same time. Keep
+	// this low enough to stay within your account's rate limits. Default is
+	// 4.
+	Workers int
+
+	// (Optional) A prefix for the idempotency key of each object, which is
+	// followed by the object's index in the batch. Objects that have their
+	// own key keep it. Use a prefix unique to the batch (ie an import job ID)
+	// so the whole batch can be safely retried.
+	KeyPrefix string
+}
+
+// BatchError is returned when some of the objects in a batch could not be
+// created. The result of each object reports its own error.
+type BatchError struct {
+	Failed int
+	Total  int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("stripe: %d of %d objects in batch failed", e.Failed, e.Total)
+}
 
-	// add optional parameters
-	if params.Description != "" {
-		values.Add("description", params.Description)
+// InvoiceItemResult is the outcome of creating a single Invoice Item in a
+// batch.
+type InvoiceItemResult struct {
+	Params *InvoiceItemParams
+	Item   *InvoiceItem
+	Err    error
+}
+
+// CreateBatch creates many Invoice Items concurrently, ie from a monthly usage
+// import, returning the result of each in the same order as items. If any
+// Invoice Item cannot be created the rest are still attempted, and a
+// *BatchError is returned along with the results.
+func (c InvoiceItemClient) CreateBatch(ctx context.Context, items []*InvoiceItemParams, opts *BatchOptions) ([]*InvoiceItemResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
 	}
-	if params.Invoice != "" {
-		values.Add("invoice", params.Invoice)
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 4
 	}
-	if params.Subscription != "" {
-		values.Add("subscription", params.Subscription)
+
+	results := make([]*InvoiceItemResult, len(items))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, params := range items {
+		if opts.KeyPrefix != "" && params.IdempotencyKey == "" {
+			keyed := *params
+			keyed.IdempotencyKey = fmt.Sprintf("%s-%d", opts.KeyPrefix, i)
+			params = &keyed
+		}
+		results[i] = &InvoiceItemResult{Params: items[i]}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res *InvoiceItemResult, params *InvoiceItemParams) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if res.Err = ctx.Err(); res.Err != nil {
+				return
+			}
+			if res.Item, res.Err = c.Create(ctx, params); res.Err != nil {
+				res.Item = nil
+			}
+		}(results[i], params)
 	}
-	appendMetadata(values, params.Metadata)
+	wg.Wait()
 
-	err := query("POST", "/invoiceitems", values, &item)
-	return &item, err
+	failed := 0
+	for _, res := range results {
+		if res.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, &BatchError{Failed: failed, Total: len(items)}
+	}
+	return results, nil
 }
 
 // Retrieves the Invoice Item with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_invoiceitem
-func (InvoiceItemClient) Get(id string) (*InvoiceItem, error) {
-	item := InvoiceItem{}
+func (c InvoiceItemClient) Get(ctx context.Context, id string) (*InvoiceItem, error) {
+	item := &InvoiceItem{}
+	return item, c.GetInto(ctx, id, item)
+}
+
+// GetInto is like Get, but decodes the Invoice Item into v, which may be any
+// type with matching JSON fields.
+func (c InvoiceItemClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/invoiceitems/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &item)
-	return &item, err
+	return c.query(ctx, "GET", path, nil, v)
 }
 
 // Update changes the amount or description of an Invoice Item on an upcoming
 // invoice, using the given Invoice Item ID.
 //
 // see https://stripe.com/docs/api#update_invoiceitem
-func (InvoiceItemClient) Update(id string, params *InvoiceItemParams) (*InvoiceItem, error) {
+func (c InvoiceItemClient) Update(ctx context.Context, id string, params *InvoiceItemParams) (*InvoiceItem, error) {
 	item := InvoiceItem{}
-	values := make(url.Values)
 
-	if params.Description != "" {
-		values.Add("description", params.Description)
-	}
-	if params.Amount != 0 {
-		values.Add("invoice", strconv.Itoa(params.Amount))
-	}
-	appendMetadata(values, params.Metadata)
+	// only the amount, description and metadata can be changed
+	values := formValues(struct {
+		Amount      int64             `form:"amount"`
+		Description string            `form:"description"`
+		Metadata    map[string]string `form:"metadata"`
+	}{params.Amount, params.Description, params.Metadata})
 
-	err := query("POST", "/invoiceitems/"+url.QueryEscape(id), values, &item)
+	err := c.query(ctx, "POST", "/invoiceitems/"+url.QueryEscape(id), values, &item)
 	return &item, err
 }
 
 // Removes an Invoice Item with the given ID.
 //
 // see https://stripe.com/docs/api#delete_invoiceitem
-func (InvoiceItemClient) Delete(id string) (bool, error) {
+func (c InvoiceItemClient) Delete(ctx context.Context, id string) (bool, error) {
 	resp := DeleteResp{}
 	path := "/invoiceitems/" + url.QueryEscape(id)
-	if err := query("DELETE", path, nil, &resp); err != nil {
+	if err := c.query(ctx, "DELETE", path, nil, &resp); err != nil {
 		return false, err
 	}
 	return resp.Deleted, nil
@@ -128,23 +211,42 @@ func (InvoiceItemClient) Delete(id string) (bool, error) {
 // Returns a list of Invoice Items.
 //
 // see https://stripe.com/docs/api#list_invoiceitems
-func (c InvoiceItemClient) List(limit int, before, after string) ([]*InvoiceItem, error) {
-	return c.list("", limit, before, after)
+func (c InvoiceItemClient) List(ctx context.Context, limit int, before, after string) ([]*InvoiceItem, bool, error) {
+	return c.list(ctx, "", limit, before, after)
+}
+
+// Returns a page of Invoice Items, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_invoiceitems
+func (c InvoiceItemClient) ListFiltered(ctx context.Context, params *ListParams) (*List[InvoiceItem], error) {
+	res := &List[InvoiceItem]{}
+	return res, c.query(ctx, "GET", "/invoiceitems", formValues(params), res)
 }
 
 // Returns a list of Invoice Items for the specified Customer ID.
 //
 // see https://stripe.com/docs/api#list_invoiceitems
-func (c InvoiceItemClient) CustomerList(id string, limit int, before, after string) ([]*InvoiceItem, error) {
-	return c.list(id, limit, before, after)
+func (c InvoiceItemClient) CustomerList(ctx context.Context, id string, limit int, before, after string) ([]*InvoiceItem, bool, error) {
+	return c.list(ctx, id, limit, before, after)
 }
 
-func (InvoiceItemClient) list(id string, limit int, before, after string) ([]*InvoiceItem, error) {
-	res := struct{ Data []*InvoiceItem }{}
+func (c InvoiceItemClient) list(ctx context.Context, id string, limit int, before, after string) ([]*InvoiceItem, bool, error) {
+	res := struct {
+		ListObject
+		Data []*InvoiceItem
+	}{}
 	params := listParams(limit, before, after)
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/invoiceitems", params, &res)
-	return res.Data, err
+	err := c.query(ctx, "GET", "/invoiceitems", params, &res)
+	return res.Data, res.More, err
+}
+
+// ListAll returns an Iter over every Invoice Item, newest first, fetching
+// pages as they are needed. The Limit of params, if any, sets the size of
+// each page.
+func (c InvoiceItemClient) ListAll(ctx context.Context, params *ListParams) *Iter[InvoiceItem] {
+	return newIter(ctx, c.backend(), "/invoiceitems", params, func(ii *InvoiceItem) string { return ii.ID })
 }
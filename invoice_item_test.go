@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// TestCreateBatch will test that a batch of invoice items is created with
+// per-item idempotency keys, reporting the items that failed.
+func TestCreateBatch(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]string{}
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Form.Get("customer")] = r.Header.Get("Idempotency-Key")
+		mu.Unlock()
+		if r.Form.Get("customer") == "cus_bad" {
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"error": {"message": "No such customer"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id": "ii_%s", "customer": "%s"}`, r.Form.Get("customer"), r.Form.Get("customer"))
+	})
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	items := []*InvoiceItemParams{
+		{Customer: "cus_1", Amount: 100, Currency: USD},
+		{Customer: "cus_bad", Amount: 100, Currency: USD},
+		{Customer: "cus_2", Amount: 100, Currency: USD, IdempotencyKey: "own"},
+	}
+	results, err := InvoiceItems.CreateBatch(context.Background(), items, &BatchOptions{Workers: 2, KeyPrefix: "june"})
+	if berr, ok := err.(*BatchError); !ok || berr.Failed != 1 || berr.Total != 3 {
+		t.Fatalf("Expected BatchError with 1 of 3 failed, got %v", err)
+	}
+	if results[0].Item.ID != "ii_cus_1" || results[2].Item.ID != "ii_cus_2" {
+		t.Errorf("Expected results in order, got %v and %v", results[0].Item, results[2].Item)
+	}
+	if results[1].Err == nil || results[1].Item != nil || results[1].Params != items[1] {
+		t.Errorf("Expected failure for cus_bad, got %+v", results[1])
+	}
+	if keys["cus_1"] != "june-0" || keys["cus_bad"] != "june-1" || keys["cus_2"] != "own" {
+		t.Errorf("Expected per-item idempotency keys, got %v", keys)
+	}
+}
+
+// TestListInvoiceItems will test that the Invoice Items of a customer are
+// listed with whether there are more to page through.
+func TestListInvoiceItems(t *testing.T) {
+	var customer string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		customer = r.FormValue("customer")
+		fmt.Fprint(w, `{"has_more": true, "data": [{"id": "ii_1", "amount": 100}]}`)
+	})
+	items, more, err := c.InvoiceItems.CustomerList(context.Background(), "cus_1", 1, "", "")
+	if err != nil || !more || len(items) != 1 || items[0].Amount != 100 {
+		t.Errorf("Expected a page of Invoice Items with more, got %v %v %v", items, more, err)
+	}
+	if customer != "cus_1" {
+		t.Errorf("Expected items of cus_1, got %q", customer)
+	}
+}
@@ -0,0 +1,112 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestUnmarshalInvoice will test that an Invoice is decoded with its lines,
+// totals, description and subscription.
+func TestUnmarshalInvoice(t *testing.T) {
+	data := `{
+		"id": "in_1",
+		"subtotal": 2000,
+		"total": 1500,
+		"paid": true,
+		"attempted": true,
+		"description": "March usage",
+		"subscription": "sub_1",
+		"discount": {"coupon": {"id": "25OFF", "percent_off": 25}},
+		"lines": {"data": [{"id": "ii_1", "amount": 2000, "period": {"start": 1400000000, "end": 1402592000}}]}
+	}`
+	inv := Invoice{}
+	if err := json.Unmarshal([]byte(data), &inv); err != nil {
+		t.Fatalf("Expected Invoice, got Error %s", err.Error())
+	}
+	if inv.Subtotal != 2000 || inv.Total != 1500 || !inv.Paid || !inv.Attempted {
+		t.Errorf("Expected paid Invoice totals, got %+v", inv)
+	}
+	if inv.Description != "March usage" || inv.Subscription != "sub_1" {
+		t.Errorf("Expected description and subscription, got %q %q", inv.Description, inv.Subscription)
+	}
+	if inv.Discount == nil || len(inv.Lines.Data) != 1 || inv.Lines.Data[0].Period.Start.Unix() != 1400000000 {
+		t.Errorf("Expected discount and line period, got %+v %+v", inv.Discount, inv.Lines)
+	}
+}
+
+// TestUpcomingInvoice will test that the change to preview, if any, is sent
+// with the request for the upcoming invoice.
+func TestUpcomingInvoice(t *testing.T) {
+	var query url.Values
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"customer": "cus_1", "total": 1250, "lines": {"data": [{"proration": true, "amount": -750}, {"amount": 2000}]}}`)
+	})
+	prorate := true
+	date := UnixTime{time.Unix(1400000000, 0)}
+	inv, err := c.Invoices.Upcoming(context.Background(), "cus_1", &UpcomingInvoiceParams{
+		Subscription:  "sub_1",
+		Plan:          "gold",
+		Quantity:      2,
+		Prorate:       &prorate,
+		ProrationDate: &date,
+	})
+	if err != nil || inv.Total != 1250 || !inv.Lines.Data[0].Proration {
+		t.Fatalf("Expected upcoming Invoice with proration, got %v %v", inv, err)
+	}
+	want := url.Values{
+		"customer":                    {"cus_1"},
+		"subscription":                {"sub_1"},
+		"subscription_plan":           {"gold"},
+		"subscription_quantity":       {"2"},
+		"subscription_prorate":        {"true"},
+		"subscription_proration_date": {"1400000000"},
+	}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("Expected query %v, got %v", want, query)
+	}
+
+	if _, err := c.Invoices.Upcoming(context.Background(), "cus_1", nil); err != nil {
+		t.Fatalf("Upcoming failed: %s", err)
+	}
+	if want := (url.Values{"customer": {"cus_1"}}); !reflect.DeepEqual(query, want) {
+		t.Errorf("Expected query %v, got %v", want, query)
+	}
+}
+
+// TestListInvoicesSince will test that invoices are listed since a time by
+// their date, as invoices of this API version have no creation time.
+func TestListInvoicesSince(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"has_more": false, "data": [{"id": "in_2", "date": 1400000002}, {"id": "in_1", "date": 1400000001}]}`)
+	})
+	invoices, err := c.Invoices.ListAllSince(context.Background(), time.Unix(1400000000, 0))
+	if err != nil || len(invoices) != 2 || invoices[0].ID != "in_1" || invoices[1].Date.Unix() != 1400000002 {
+		t.Fatalf("Expected in_1 and in_2 oldest first, got %v %v", invoices, err)
+	}
+	if want := (url.Values{"date[gte]": {"1400000000"}, "limit": {"100"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestListInvoicesByDate will test that invoice lists are filtered by the
+// date of the invoices.
+func TestListInvoicesByDate(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"has_more": false, "data": [{"id": "in_1", "date": 1400000001}]}`)
+	})
+	params := &InvoiceListParams{Customer: "cus_1", Date: Since(time.Unix(1400000000, 0))}
+	res, err := c.Invoices.ListFiltered(context.Background(), params)
+	if err != nil || len(res.Data) != 1 {
+		t.Fatalf("Expected a page of Invoices, got %v %v", res, err)
+	}
+	if want := (url.Values{"customer": {"cus_1"}, "date[gte]": {"1400000000"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
@@ -0,0 +1,317 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// the page size used when fetching every page of a list
+const maxLimit = 100
+
+// List is a single page of a list response, with its metadata.
+//
+// see https://stripe.com/docs/api#pagination
+type List[T any] struct {
+	APIResource
+	ListObject
+	Data []*T `json:"data"`
+}
+
+// setClient sets the Client of every object in the List.
+func (l *List[T]) setClient(c *Client) {
+	for _, obj := range l.Data {
+		setClient(obj, c)
+	}
+}
+
+// SearchResult is a single page of a search response. Search results are
+// paged with NextPage rather than object IDs.
+//
+// see https://stripe.com/docs/search
+type SearchResult[T any] struct {
+	APIResource
+	Data []*T `json:"data"`
+
+	// Whether there are more results after this page.
+	More bool `json:"has_more"`
+
+	// The cursor to pass to fetch the next page, if More is true.
+	NextPage string `json:"next_page,omitempty"`
+
+	// The URL of the search, ie /v1/prices/search.
+	URL string `json:"url"`
+}
+
+// setClient sets the Client of every object in the SearchResult.
+func (r *SearchResult[T]) setClient(c *Client) {
+	for _, obj := range r.Data {
+		setClient(obj, c)
+	}
+}
+
+// listAll fetches every page of the list at path, calling fn with each
+// object in the order they are returned by Stripe, which is newest first.
+// The id function returns an object's ID, which is used as the cursor for
+// the next page.
+func listAll[T any](ctx context.Context, c *Client, path string, values url.Values, id func(*T) string, fn func(*T) error) error {
+	params := make(url.Values)
+	for k, v := range values {
+		params[k] = v
+	}
+	if params.Get("limit") == "" {
+		params.Set("limit", strconv.Itoa(maxLimit))
+	}
+
+	for {
+		res := List[T]{}
+		if err := c.query(ctx, "GET", path, params, &res); err != nil {
+			return err
+		}
+		for _, obj := range res.Data {
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+		if !res.More || len(res.Data) == 0 {
+			return nil
+		}
+		params.Set("starting_after", id(res.Data[len(res.Data)-1]))
+	}
+}
+
+// listAllSince fetches every object in the list at path that was created at
+// or after t, returning them oldest first. The creation time of the objects
+// is filtered on as field, ie created.
+func listAllSince[T any](ctx context.Context, c *Client, path, field string, t time.Time, id func(*T) string, created func(*T) time.Time) ([]*T, error) {
+	return listRange(ctx, c, path, field, Since(t), id, created)
+}
+
+// listRange fetches every object in the list at path whose creation time,
+// filtered on as field, is within r, returning them oldest first.
+func listRange[T any](ctx context.Context, c *Client, path, field string, r *DateRange, id func(*T) string, created func(*T) time.Time) ([]*T, error) {
+	values := make(url.Values)
+	appendForm(values, field, r)
+
+	var all []*T
+	err := listAll(ctx, c, path, values, id, func(obj *T) error {
+		all = append(all, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// reverse the newest first order, then make sure objects created in the
+	// same second keep that order
+	for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+		all[i], all[j] = all[j], all[i]
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return created(all[i]).Before(created(all[j]))
+	})
+	return all, nil
+}
+
+// ParallelOptions configures a list that is fetched as several shards of its
+// creation time range at once, for backfills that would otherwise take a
+// long time to page through.
+type ParallelOptions struct {
+	// The creation time from which, inclusive, objects are listed.
+	Start time.Time
+
+	// The creation time until which, exclusive, objects are listed.
+	End time.Time
+
+	// (Optional) The number of equal shards the time range is split into.
+	// Default is 16.
+	Shards int
+
+	// (Optional) The maximum number of shards fetched at the same time. Keep
+	// this low enough to stay within your account's rate limits. Default is
+	// 4.
+	Workers int
+}
+
+// listParallel fetches the shards of the creation time range described by
+// opts concurrently, returning every object oldest first. If any shard
+// fails, the others are canceled and the first error is returned.
+func listParallel[T any](ctx context.Context, c *Client, path string, opts *ParallelOptions, id func(*T) string, created func(*T) time.Time) ([]*T, error) {
+	shards, workers := opts.Shards, opts.Workers
+	if shards <= 0 {
+		shards = 16
+	}
+	if workers <= 0 {
+		workers = 4
+	}
+
+	// timestamps have a resolution of one second, and so do shard boundaries
+	start, end := opts.Start.Unix(), opts.End.Unix()
+	if span := end - start; span < int64(shards) {
+		shards = int(span)
+	}
+	if shards <= 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]*T, shards)
+	errs := make(chan error, shards)
+	sem := make(chan struct{}, workers)
+	for i := 0; i < shards; i++ {
+		lo := start + (end-start)*int64(i)/int64(shards)
+		hi := start + (end-start)*int64(i+1)/int64(shards)
+		r := Between(time.Unix(lo, 0), time.Unix(hi, 0))
+
+		go func(i int) {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+			var err error
+			results[i], err = listRange(ctx, c, path, "created", r, id, created)
+			// report the error before canceling the other shards, so that it
+			// is received ahead of their context.Canceled errors
+			errs <- err
+			if err != nil {
+				cancel()
+			}
+		}(i)
+	}
+
+	var first error
+	for i := 0; i < shards; i++ {
+		if err := <-errs; err != nil && first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return nil, first
+	}
+
+	// the shards are in chronological order, as is each shard
+	var all []*T
+	for _, res := range results {
+		all = append(all, res...)
+	}
+	return all, nil
+}
+
+// stream fetches every page of the list at path, filtered by the list params
+// struct params, in the background, sending each object on the returned
+// channel as pages arrive. The channel buffers up to a page of objects, so
+// the next page is fetched while the consumer works through the last one,
+// but no further: at most two pages are held in memory at once. The object
+// channel is closed once the list is exhausted, ctx is done or a request
+// fails, after which the error channel delivers the error, if any, and is
+// closed.
+func stream[T any](ctx context.Context, c *Client, path string, params interface{}, id func(*T) string) (<-chan *T, <-chan error) {
+	values := formValues(params)
+	size, _ := strconv.Atoi(values.Get("limit"))
+	if size <= 0 || size > maxLimit {
+		size = maxLimit
+	}
+	objs := make(chan *T, size)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(objs)
+		err := listAll(ctx, c, path, values, id, func(obj *T) error {
+			select {
+			case objs <- obj:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() != nil {
+			// report the cancellation rather than the request it aborted
+			err = ctx.Err()
+		}
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return objs, errs
+}
+
+// Iter iterates over every object in a list, fetching the next page as the
+// previous one is exhausted:
+//
+//	it := stripe.Charges.ListAll(ctx, nil)
+//	for it.Next() {
+//		charge := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Objects are returned in the order they are listed by Stripe, which is
+// newest first. An Iter must not be used from multiple goroutines at once.
+type Iter[T any] struct {
+	ctx    context.Context
+	c      *Client
+	path   string
+	params url.Values
+	id     func(*T) string
+
+	page  []*T
+	cur   *T
+	more  bool
+	begun bool
+	err   error
+}
+
+// newIter returns an Iter over the list at path, filtered by the list params
+// struct params, which may be nil. The id function returns an object's ID,
+// which is used as the cursor for the next page.
+func newIter[T any](ctx context.Context, c *Client, path string, params interface{}, id func(*T) string) *Iter[T] {
+	values := formValues(params)
+	if values.Get("limit") == "" {
+		values.Set("limit", strconv.Itoa(maxLimit))
+	}
+	return &Iter[T]{ctx: ctx, c: c, path: path, params: values, id: id}
+}
+
+// Next advances to the next object, which is then returned by Value. It
+// returns false when the list is exhausted or a request fails, after which
+// Err reports the failure, if any.
+func (it *Iter[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 && (!it.begun || it.more) {
+		if it.cur != nil {
+			it.params.Set("starting_after", it.id(it.cur))
+		}
+		res := List[T]{}
+		if it.err = it.c.query(it.ctx, "GET", it.path, it.params, &res); it.err != nil {
+			return false
+		}
+		it.page, it.more, it.begun = res.Data, res.More && len(res.Data) > 0, true
+	}
+	if len(it.page) == 0 {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current object, or nil if Next has not been called or
+// returned false.
+func (it *Iter[T]) Value() *T {
+	return it.cur
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
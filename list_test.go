@@ -0,0 +1,308 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// serveCharges starts a server that lists n fake charges, newest first, in
+// pages of at most limit objects, and points the client at it. The returned
+// function restores the client and stops the server.
+func serveCharges(n int) func() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.FormValue("limit"))
+		start := n
+		if after := r.FormValue("starting_after"); after != "" {
+			fmt.Sscanf(after, "ch_%d", &start)
+		}
+		since, _ := strconv.ParseInt(r.FormValue("created[gte]"), 10, 64)
+		until, err := strconv.ParseInt(r.FormValue("created[lt]"), 10, 64)
+		if err != nil {
+			until = int64(n)
+		}
+
+		var data []string
+		for i := start - 1; i >= 0 && len(data) < limit; i-- {
+			if created := int64(i / 2); created >= since && created < until {
+				data = append(data, fmt.Sprintf(`{"id": "ch_%d", "created": %d}`, i, created))
+			}
+		}
+		more := len(data) == limit && start-limit > 0
+		fmt.Fprintf(w, `{"has_more": %t, "data": [`, more)
+		for i, d := range data {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprint(w, d)
+		}
+		fmt.Fprint(w, "]}")
+	}))
+	url := _default.URL
+	SetUrl(srv.URL)
+	return func() {
+		SetUrl(url)
+		srv.Close()
+	}
+}
+
+// TestListAllSince will test that every page after the given time is fetched,
+// and that the results are returned oldest first.
+func TestListAllSince(t *testing.T) {
+	defer serveCharges(250)()
+
+	charges, err := Charges.ListAllSince(context.Background(), time.Unix(10, 0))
+	if err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+		return
+	}
+	if len(charges) != 230 {
+		t.Errorf("Expected 230 Charges, got %d", len(charges))
+		return
+	}
+	for i, c := range charges {
+		if want := fmt.Sprintf("ch_%d", i+20); c.ID != want {
+			t.Errorf("Expected Charge %d to be %s, got %s", i, want, c.ID)
+			return
+		}
+	}
+}
+
+// TestStream will test that every page is streamed in order, and that
+// streaming stops when the context is canceled.
+func TestStream(t *testing.T) {
+	defer serveCharges(250)()
+
+	charges, errs := Charges.Stream(context.Background(), nil)
+	n := 250
+	for c := range charges {
+		n--
+		if want := fmt.Sprintf("ch_%d", n); c.ID != want {
+			t.Errorf("Expected Charge %s, got %s", want, c.ID)
+		}
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected no Error, got %s", err.Error())
+	}
+	if n != 0 {
+		t.Errorf("Expected 250 Charges, got %d", 250-n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	charges, errs = Charges.Stream(ctx, nil)
+	<-charges
+	cancel()
+	for range charges {
+	}
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("Expected Error %v, got %v", context.Canceled, err)
+	}
+
+	// filters are applied, and at most a page of Charges is buffered
+	params := &ChargeListParams{ListParams: ListParams{Limit: 10}, Created: Since(time.Unix(120, 0))}
+	charges, errs = Charges.Stream(context.Background(), params)
+	if cap(charges) != 10 {
+		t.Errorf("Expected a buffer of 10 Charges, got %d", cap(charges))
+	}
+	n = 0
+	for range charges {
+		n++
+	}
+	if err := <-errs; err != nil || n != 10 {
+		t.Errorf("Expected 10 Charges created since 120, got %d and %v", n, err)
+	}
+}
+
+// TestListParallel will test that the shards of a time range are merged back
+// into a single list, oldest first.
+func TestListParallel(t *testing.T) {
+	defer serveCharges(250)()
+
+	opts := &ParallelOptions{Start: time.Unix(10, 0), End: time.Unix(125, 0), Shards: 7, Workers: 3}
+	charges, err := Charges.ListParallel(context.Background(), opts)
+	if err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+		return
+	}
+	if len(charges) != 230 {
+		t.Errorf("Expected 230 Charges, got %d", len(charges))
+		return
+	}
+	for i, c := range charges {
+		if want := fmt.Sprintf("ch_%d", i+20); c.ID != want {
+			t.Errorf("Expected Charge %d to be %s, got %s", i, want, c.ID)
+			return
+		}
+	}
+}
+
+// TestListParallelError will test that the error of a failed shard is
+// returned, rather than the errors of the shards waiting for a worker that
+// are canceled because of it.
+func TestListParallelError(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Form.Get("created[gte]") != "10" {
+			fmt.Fprint(w, `{"has_more": false, "data": []}`)
+			return
+		}
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "bad shard"}}`)
+	})
+
+	opts := &ParallelOptions{Start: time.Unix(10, 0), End: time.Unix(90, 0), Shards: 16, Workers: 1}
+	charges, err := c.Charges.ListParallel(context.Background(), opts)
+	if serr, ok := err.(*Error); !ok || serr.Detail.Message != "bad shard" || charges != nil {
+		t.Errorf("Expected the error of the failed shard, got %v %v", charges, err)
+	}
+}
+
+// TestListCursor will test that a list is paged forward by passing the ID of
+// the last object of a page as the after cursor.
+func TestListCursor(t *testing.T) {
+	defer serveCharges(5)()
+
+	var ids []string
+	after := ""
+	for more := true; more; {
+		charges, hasMore, err := Charges.List(context.Background(), 2, "", after)
+		if err != nil {
+			t.Fatalf("Expected Charges, got Error %s", err.Error())
+		}
+		for _, c := range charges {
+			ids = append(ids, c.ID)
+		}
+		more = hasMore && len(charges) > 0
+		if more {
+			after = charges[len(charges)-1].ID
+		}
+	}
+	if fmt.Sprint(ids) != "[ch_4 ch_3 ch_2 ch_1 ch_0]" {
+		t.Errorf("Expected every Charge once, got %v", ids)
+	}
+
+	// the subscriptions of a customer are paged the same way
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("starting_after") != "sub_1" {
+			fmt.Fprint(w, `{"has_more": true, "data": [{"id": "sub_1"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"has_more": false, "data": [{"id": "sub_2"}]}`)
+	})
+	subs, more, err := c.Subscriptions.List(context.Background(), "cus_1", 1, "", "sub_1")
+	if err != nil || more || len(subs) != 1 || subs[0].ID != "sub_2" {
+		t.Errorf("Expected the page after sub_1, got %v %v %v", subs, more, err)
+	}
+}
+
+// TestListAllIter will test that an Iter returns every object across pages,
+// newest first, and stops with the error of a failed page.
+func TestListAllIter(t *testing.T) {
+	stop := serveCharges(250)
+
+	it := Charges.ListAll(context.Background(), nil)
+	n := 250
+	for it.Next() {
+		n--
+		if want := fmt.Sprintf("ch_%d", n); it.Value().ID != want {
+			t.Errorf("Expected Charge %s, got %s", want, it.Value().ID)
+		}
+	}
+	if err := it.Err(); err != nil || n != 0 || it.Value() != nil {
+		t.Errorf("Expected 250 Charges, got %d and %v", 250-n, err)
+	}
+	if it.Next() {
+		t.Errorf("Expected exhausted Iter to stay exhausted")
+	}
+
+	it = Charges.ListAll(context.Background(), &ChargeListParams{ListParams: ListParams{Limit: 10}})
+	if !it.Next() {
+		t.Fatalf("Expected a Charge, got %v", it.Err())
+	}
+	stop()
+	for it.Next() {
+	}
+	if it.Err() == nil {
+		t.Errorf("Expected Error fetching the next page")
+	}
+}
+
+// TestListFiltered will test that list filters, including a creation time
+// range, are sent with the request, and that the page is returned with its
+// metadata.
+func TestListFiltered(t *testing.T) {
+	defer serveCharges(10)()
+
+	params := &ChargeListParams{
+		ListParams: ListParams{Limit: 100},
+		Created:    Between(time.Unix(2, 0), time.Unix(4, 0)),
+	}
+	list, err := Charges.ListFiltered(context.Background(), params)
+	if err != nil || list.More {
+		t.Fatalf("Expected a single page of Charges, got %v", err)
+	}
+	var ids []string
+	for _, c := range list.Data {
+		ids = append(ids, c.ID)
+	}
+	if fmt.Sprint(ids) != "[ch_7 ch_6 ch_5 ch_4]" {
+		t.Errorf("Expected Charges created within the range, got %v", ids)
+	}
+
+	n := 0
+	for it := Charges.ListAll(context.Background(), &ChargeListParams{Created: Since(time.Unix(3, 0))}); it.Next(); {
+		n++
+	}
+	if n != 4 {
+		t.Errorf("Expected 4 Charges created since 3, got %d", n)
+	}
+}
+
+// TestListMetadata will test that the metadata of a list page is decoded,
+// and that its objects are bound to the Client that listed them.
+func TestListMetadata(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_1")
+		fmt.Fprint(w, `{"object": "list", "url": "/v1/charges", "has_more": true, "total_count": 3, "data": [{"id": "ch_1"}]}`)
+	})
+	list, err := c.Charges.ListFiltered(context.Background(), &ChargeListParams{Customer: "cus_1"})
+	if err != nil {
+		t.Fatalf("Expected Charges, got Error %s", err.Error())
+	}
+	if !list.More || list.TotalCount != 3 || list.URL != "/v1/charges" || list.LastResponse.RequestID != "req_1" {
+		t.Errorf("Expected list metadata, got %+v", list.ListObject)
+	}
+	if len(list.Data) != 1 || list.Data[0].client != c {
+		t.Errorf("Expected Charge bound to the Client, got %v", list.Data)
+	}
+}
+
+// TestListFilteredMetadata will test that the metadata of a list page is
+// returned by the ListFiltered of clients without filters, and of clients
+// listing the objects of a parent.
+func TestListFilteredMetadata(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"object": "list", "url": "%s", "has_more": true, "total_count": 7, "data": [{"id": "obj_1"}]}`, r.URL.Path)
+	})
+	ctx := context.Background()
+	plans, err := c.Plans.ListFiltered(ctx, &ListParams{Limit: 1})
+	if err != nil || !plans.More || plans.TotalCount != 7 || plans.URL != "/v1/plans" || len(plans.Data) != 1 {
+		t.Errorf("Expected list metadata of Plans, got %+v %v", plans, err)
+	}
+	cards, err := c.Cards.ListFiltered(ctx, "cus_1", nil)
+	if err != nil || !cards.More || cards.TotalCount != 7 || cards.URL != "/v1/customers/cus_1/cards" || len(cards.Data) != 1 {
+		t.Errorf("Expected list metadata of Cards, got %+v %v", cards, err)
+	}
+
+	if want := []string{"GET /v1/plans", "GET /v1/customers/cus_1/cards"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	if want := []url.Values{{"limit": {"1"}}, {}}; !reflect.DeepEqual(srv.forms, want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms)
+	}
+}
@@ -0,0 +1,133 @@
+package stripe
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestLog describes a request submitted to the Stripe API, and its
+// response.
+type RequestLog struct {
+	Method string
+	Path   string
+
+	// The parameters of the request, with card numbers, security codes, bank
+	// account numbers and API keys redacted.
+	Params url.Values
+
+	// The HTTP status code of the response, or zero if none was received.
+	Status int
+
+	// The ID Stripe assigned to the request, from the Request-Id header.
+	RequestID string
+
+	// The time between submitting the request and reading its response.
+	Latency time.Duration
+
+	// The error that prevented a response from being received, if any.
+	Err error
+}
+
+// Logger receives a RequestLog for every request submitted to the Stripe API,
+// including each retry of a failed request.
+type Logger interface {
+	LogRequest(*RequestLog)
+}
+
+// The LoggerFunc type is an adapter to allow the use of ordinary functions as
+// a Logger.
+type LoggerFunc func(*RequestLog)
+
+// LogRequest calls f(l).
+func (f LoggerFunc) LogRequest(l *RequestLog) {
+	f(l)
+}
+
+// RequestObserver is notified after every request submitted to the Stripe
+// API, including each retry of a failed request, ie to record latency and
+// error rate metrics. The path includes the IDs of the objects requested.
+// The status is zero if no response was received.
+type RequestObserver interface {
+	ObserveRequest(method, path string, status int, dur time.Duration)
+}
+
+// the value logged in place of sensitive parameters
+const redacted = "[REDACTED]"
+
+// sensitiveParams are the names of parameters whose values are never logged.
+// Nested parameters, ie card[number], are matched by their innermost name.
+var sensitiveParams = map[string]bool{
+	"number":         true,
+	"cvc":            true,
+	"account_number": true,
+
+	// government IDs of the people of connected accounts, and of recipients
+	"id_number":          true,
+	"ssn_last_4":         true,
+	"personal_id_number": true,
+	"tax_id":             true,
+}
+
+// newRequestLog returns the RequestLog of an http.Request.
+func newRequestLog(req *http.Request, resp *Response, latency time.Duration, err error) *RequestLog {
+	l := &RequestLog{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Params:  redactParams(requestParams(req)),
+		Latency: latency,
+		Err:     err,
+	}
+	if resp != nil {
+		l.Status, l.RequestID = resp.StatusCode, resp.RequestID
+	}
+	return l
+}
+
+// requestParams returns the parameters of an http.Request, from its URL or
+// its form-encoded body. The fields of file uploads are not included.
+func requestParams(req *http.Request) url.Values {
+	if req.GetBody == nil || strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		return req.URL.Query()
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	values, _ := url.ParseQuery(string(b))
+	return values
+}
+
+// redactParams replaces the values of sensitive parameters, and any value that
+// looks like an API key, in place.
+func redactParams(values url.Values) url.Values {
+	for k, vals := range values {
+		name := k
+		if i := strings.LastIndex(k, "["); i >= 0 {
+			name = strings.TrimSuffix(k[i+1:], "]")
+		}
+		for i, v := range vals {
+			if sensitiveParams[name] || isAPIKey(v) {
+				vals[i] = redacted
+			}
+		}
+	}
+	return values
+}
+
+// isAPIKey reports whether s looks like a secret or restricted API key.
+func isAPIKey(s string) bool {
+	for _, prefix := range []string{"sk_live_", "sk_test_", "rk_live_", "rk_test_"} {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
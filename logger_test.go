@@ -0,0 +1,99 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestLogger will test that every request is logged with its response, and
+// that card details, government IDs and API keys are redacted from the logged
+// parameters.
+func TestLogger(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_1")
+		fmt.Fprint(w, `{"id": "tok_1"}`)
+	})
+
+	var logs []*RequestLog
+	c.Logger = LoggerFunc(func(l *RequestLog) { logs = append(logs, l) })
+
+	card := &CardParams{Number: "4242424242424242", ExpMonth: 1, ExpYear: time.Now().Year() + 1, CVC: "123"}
+	if _, err := c.Tokens.Create(context.Background(), card); err != nil {
+		t.Fatalf("Expected token, got %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 logged request, got %d", len(logs))
+	}
+
+	l := logs[0]
+	if l.Method != "POST" || l.Path != "/v1/tokens" || l.Status != 200 || l.RequestID != "req_1" {
+		t.Errorf("Expected POST /v1/tokens 200 req_1, got %s %s %d %s", l.Method, l.Path, l.Status, l.RequestID)
+	}
+	if n := l.Params.Get("card[number]"); n != redacted {
+		t.Errorf("Expected card number to be redacted, got %s", n)
+	}
+	if cvc := l.Params.Get("card[cvc]"); cvc != redacted {
+		t.Errorf("Expected card cvc to be redacted, got %s", cvc)
+	}
+	if m := l.Params.Get("card[exp_month]"); m != "1" {
+		t.Errorf("Expected card exp_month to be logged, got %s", m)
+	}
+
+	if v := redactParams(map[string][]string{"key": {"sk_live_123"}}); v.Get("key") != redacted {
+		t.Errorf("Expected API key to be redacted, got %s", v.Get("key"))
+	}
+
+	if _, err := c.Persons.Create(context.Background(), "acct_1", &PersonParams{IDNumber: "123-45-6789", SSNLast4: "6789"}); err != nil {
+		t.Fatalf("Expected person, got %v", err)
+	}
+	l = logs[1]
+	for _, name := range []string{"id_number", "ssn_last_4"} {
+		if v := l.Params.Get(name); v != redacted {
+			t.Errorf("Expected %s to be redacted, got %s", name, v)
+		}
+	}
+	if v := redactParams(map[string][]string{"legal_entity[personal_id_number]": {"123456789"}}); v.Get("legal_entity[personal_id_number]") != redacted {
+		t.Errorf("Expected personal ID number to be redacted, got %s", v.Get("legal_entity[personal_id_number]"))
+	}
+	if v := redactParams(map[string][]string{"tax_id": {"000000000"}}); v.Get("tax_id") != redacted {
+		t.Errorf("Expected recipient tax ID to be redacted, got %s", v.Get("tax_id"))
+	}
+}
+
+type observation struct {
+	method, path string
+	status       int
+}
+
+type recordingObserver []observation
+
+func (o *recordingObserver) ObserveRequest(method, path string, status int, dur time.Duration) {
+	*o = append(*o, observation{method, path, status})
+}
+
+// TestObserver will test that the observer is notified of every request,
+// with a zero status when no response was received.
+func TestObserver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error"}}`)
+	}))
+
+	var o recordingObserver
+	c := New("sk_test_dummy")
+	c.URL = srv.URL
+	c.Observer = &o
+
+	c.Customers.Get(context.Background(), "cus_1")
+	srv.Close()
+	c.Customers.Get(context.Background(), "cus_1")
+
+	want := []observation{{"GET", "/v1/customers/cus_1", 404}, {"GET", "/v1/customers/cus_1", 0}}
+	if len(o) != len(want) || o[0] != want[0] || o[1] != want[1] {
+		t.Errorf("Expected observations %v, got %v", want, o)
+	}
+}
@@ -0,0 +1,121 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Payout Statuses
+const (
+	PayoutPaid      = "paid"
+	PayoutPending   = "pending"
+	PayoutInTransit = "in_transit"
+	PayoutCanceled  = "canceled"
+	PayoutFailed    = "failed"
+)
+
+// Payout represents funds sent from a Stripe balance to a bank account or
+// debit card.
+//
+// see https://stripe.com/docs/api#payout_object
+type Payout struct {
+	APIResource
+	ID                  string            `json:"id"`
+	Amount              int64             `json:"amount"`
+	ArrivalDate         UnixTime          `json:"arrival_date"`
+	Automatic           bool              `json:"automatic"`
+	BalanceTransaction  string            `json:"balance_transaction"`
+	Created             UnixTime          `json:"created"`
+	Currency            string            `json:"currency"`
+	Description         string            `json:"description,omitempty"`
+	Destination         string            `json:"destination"`
+	FailureCode         string            `json:"failure_code,omitempty"`
+	FailureMessage      string            `json:"failure_message,omitempty"`
+	Method              string            `json:"method"`
+	OriginalPayout      string            `json:"original_payout,omitempty"`
+	ReversedBy          string            `json:"reversed_by,omitempty"`
+	StatementDescriptor string            `json:"statement_descriptor,omitempty"`
+	Status              string            `json:"status"`
+	Type                string            `json:"type"`
+	Livemode            bool              `json:"livemode"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+}
+
+// PayoutListParams encapsulates options for filtering a list of Payouts.
+type PayoutListParams struct {
+	ListParams
+
+	// (Optional) Only return payouts with the given status.
+	Status string `form:"status"`
+
+	// (Optional) Only return payouts expected to arrive within this range.
+	ArrivalDate *DateRange `form:"arrival_date"`
+
+	// (Optional) Only return payouts created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// PayoutReverseParams encapsulates options for reversing a Payout.
+type PayoutReverseParams struct {
+	Metadata map[string]string `form:"metadata"`
+}
+
+// PayoutClient encapsulates operations for querying, canceling and reversing
+// payouts using the Stripe REST API.
+type PayoutClient struct{ api }
+
+// Retrieves the Payout with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_payout
+func (c PayoutClient) Get(ctx context.Context, id string) (*Payout, error) {
+	res := &Payout{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Payout into v, which may be any
+// type with matching JSON fields.
+func (c PayoutClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/payouts/"+url.QueryEscape(id), nil, v)
+}
+
+// Returns a list of Payouts matching the given filters, or all of your
+// Payouts when params is nil.
+//
+// see https://stripe.com/docs/api#list_payouts
+func (c PayoutClient) List(ctx context.Context, params *PayoutListParams) ([]*Payout, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Payouts matching the given filters, or of all your
+// Payouts when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_payouts
+func (c PayoutClient) ListFiltered(ctx context.Context, params *PayoutListParams) (*List[Payout], error) {
+	res := &List[Payout]{}
+	return res, c.query(ctx, "GET", "/payouts", formValues(params), res)
+}
+
+// Cancels a pending Payout with the given ID, returning the funds to the
+// available balance. Payouts that are already in transit can not be
+// canceled.
+//
+// see https://stripe.com/docs/api#cancel_payout
+func (c PayoutClient) Cancel(ctx context.Context, id string) (*Payout, error) {
+	res := &Payout{}
+	path := fmt.Sprintf("/payouts/%s/cancel", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, nil, res)
+}
+
+// Reverses a paid Payout with the given ID by creating a new Payout in the
+// opposite direction, debiting the destination bank account. Only payouts
+// to bank accounts in supported countries can be reversed.
+//
+// see https://stripe.com/docs/api#reverse_payout
+func (c PayoutClient) Reverse(ctx context.Context, id string, params *PayoutReverseParams) (*Payout, error) {
+	values := formValues(params)
+	res := &Payout{}
+	path := fmt.Sprintf("/payouts/%s/reverse", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, values, res)
+}
@@ -0,0 +1,283 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// Verification Statuses
+const (
+	VerificationUnverified = "unverified"
+	VerificationPending    = "pending"
+	VerificationVerified   = "verified"
+)
+
+// Person represents an individual associated with a connected Account, such
+// as its representative, an owner or a director.
+//
+// see https://stripe.com/docs/api#person_object
+type Person struct {
+	APIResource
+	ID               string              `json:"id"`
+	Account          string              `json:"account"`
+	FirstName        string              `json:"first_name,omitempty"`
+	LastName         string              `json:"last_name,omitempty"`
+	Email            string              `json:"email,omitempty"`
+	Phone            string              `json:"phone,omitempty"`
+	DOB              *DOB                `json:"dob,omitempty"`
+	Address          *Address            `json:"address,omitempty"`
+	IDNumberProvided bool                `json:"id_number_provided"`
+	SSNLast4Provided bool                `json:"ssn_last_4_provided"`
+	Relationship     *PersonRelationship `json:"relationship,omitempty"`
+	Verification     *PersonVerification `json:"verification,omitempty"`
+	Created          UnixTime            `json:"created"`
+	Metadata         map[string]string   `json:"metadata,omitempty"`
+}
+
+// DOB is a date of birth.
+type DOB struct {
+	Day   int `json:"day" form:"day"`
+	Month int `json:"month" form:"month"`
+	Year  int `json:"year" form:"year"`
+}
+
+// Address is a postal address.
+type Address struct {
+	Line1      string `json:"line1,omitempty" form:"line1"`
+	Line2      string `json:"line2,omitempty" form:"line2"`
+	City       string `json:"city,omitempty" form:"city"`
+	State      string `json:"state,omitempty" form:"state"`
+	PostalCode string `json:"postal_code,omitempty" form:"postal_code"`
+	Country    string `json:"country,omitempty" form:"country"`
+}
+
+// PersonRelationship describes how a Person is related to the Account.
+type PersonRelationship struct {
+	Representative   bool    `json:"representative"`
+	Owner            bool    `json:"owner"`
+	Director         bool    `json:"director"`
+	Executive        bool    `json:"executive"`
+	PercentOwnership float64 `json:"percent_ownership,omitempty"`
+	Title            string  `json:"title,omitempty"`
+}
+
+// PersonVerification holds the identity verification status of a Person.
+type PersonVerification struct {
+	Status             string                `json:"status"`
+	Details            string                `json:"details,omitempty"`
+	DetailsCode        string                `json:"details_code,omitempty"`
+	Document           *VerificationDocument `json:"document,omitempty"`
+	AdditionalDocument *VerificationDocument `json:"additional_document,omitempty"`
+}
+
+// VerificationDocument holds the IDs of the uploaded Files for the front and
+// back of an identity document.
+type VerificationDocument struct {
+	Front       string `json:"front,omitempty"`
+	Back        string `json:"back,omitempty"`
+	Details     string `json:"details,omitempty"`
+	DetailsCode string `json:"details_code,omitempty"`
+}
+
+// IdentityDocument holds the images of an identity document, such as a
+// passport or driver's license, to upload for verification.
+type IdentityDocument struct {
+	// The name of the front image file, including its extension.
+	FrontFilename string
+
+	// The contents of the front image.
+	Front io.Reader
+
+	// (Optional) The name of the back image file, including its extension.
+	BackFilename string
+
+	// (Optional) The contents of the back image, which is required for some
+	// document types.
+	Back io.Reader
+
+	// (Optional) When true, the images are attached as the additional
+	// document (e.g. a proof of address) instead of the primary identity
+	// document.
+	Additional bool
+}
+
+// PersonParams encapsulates options for creating or updating Persons.
+type PersonParams struct {
+	// (Optional) The person's first name.
+	FirstName string `form:"first_name"`
+
+	// (Optional) The person's last name.
+	LastName string `form:"last_name"`
+
+	// (Optional) The person's email address.
+	Email string `form:"email"`
+
+	// (Optional) The person's phone number.
+	Phone string `form:"phone"`
+
+	// (Optional) The person's date of birth.
+	DOB *DOB `form:"dob"`
+
+	// (Optional) The person's residential address.
+	Address *Address `form:"address"`
+
+	// (Optional) The person's government-issued ID number, ie their full
+	// social security number in the US. It can not be retrieved once set.
+	IDNumber string `form:"id_number"`
+
+	// (Optional) The last four digits of the person's social security
+	// number, in the US only.
+	SSNLast4 string `form:"ssn_last_4"`
+
+	// (Optional) How the person is related to the Account.
+	Relationship *PersonRelationshipParams `form:"relationship"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// PersonRelationshipParams encapsulates options for describing how a Person
+// is related to the Account. A pointer is used for each flag so that it can
+// be cleared by passing false.
+type PersonRelationshipParams struct {
+	// (Optional) Whether the person is authorized as the primary
+	// representative of the Account.
+	Representative *bool `form:"representative"`
+
+	// (Optional) Whether the person owns 25% or more of the Account.
+	Owner *bool `form:"owner"`
+
+	// (Optional) Whether the person is a member of the governing board of
+	// the Account.
+	Director *bool `form:"director"`
+
+	// (Optional) Whether the person has significant responsibility to
+	// control or manage the Account.
+	Executive *bool `form:"executive"`
+
+	// (Optional) The percent of the Account owned by the person.
+	PercentOwnership *float64 `form:"percent_ownership"`
+
+	// (Optional) The person's title, ie CEO.
+	Title string `form:"title"`
+}
+
+// PersonClient encapsulates operations for creating, querying, updating and
+// verifying the Persons of a connected Account using the Stripe REST API.
+type PersonClient struct{ api }
+
+func (c PersonClient) path(accountID, personID string) string {
+	p := fmt.Sprintf("/accounts/%s/persons", url.QueryEscape(accountID))
+	if personID != "" {
+		p += "/" + url.QueryEscape(personID)
+	}
+	return p
+}
+
+// Creates a new Person on the connected Account with the given ID.
+//
+// see https://stripe.com/docs/api#create_person
+func (c PersonClient) Create(ctx context.Context, accountID string, params *PersonParams) (*Person, error) {
+	res := &Person{}
+	return res, c.query(ctx, "POST", c.path(accountID, ""), formValues(params), res)
+}
+
+// Retrieves the Person with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_person
+func (c PersonClient) Get(ctx context.Context, accountID, personID string) (*Person, error) {
+	res := &Person{}
+	return res, c.GetInto(ctx, accountID, personID, res)
+}
+
+// GetInto is like Get, but decodes the Person into v, which may be any
+// type with matching JSON fields.
+func (c PersonClient) GetInto(ctx context.Context, accountID, personID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(accountID, personID), nil, v)
+}
+
+// Updates the Person with the given ID.
+//
+// see https://stripe.com/docs/api#update_person
+func (c PersonClient) Update(ctx context.Context, accountID, personID string, params *PersonParams) (*Person, error) {
+	res := &Person{}
+	return res, c.query(ctx, "POST", c.path(accountID, personID), formValues(params), res)
+}
+
+// Deletes the Person with the given ID. The representative of an Account
+// can not be deleted.
+//
+// see https://stripe.com/docs/api#delete_person
+func (c PersonClient) Delete(ctx context.Context, accountID, personID string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", c.path(accountID, personID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the Persons of a connected Account.
+//
+// see https://stripe.com/docs/api#list_persons
+func (c PersonClient) List(ctx context.Context, accountID string, limit int, before, after string) ([]*Person, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Person
+	}{}
+	err := c.query(ctx, "GET", c.path(accountID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the persons of the Account with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_persons
+func (c PersonClient) ListFiltered(ctx context.Context, accountID string, params *ListParams) (*List[Person], error) {
+	res := &List[Person]{}
+	return res, c.query(ctx, "GET", c.path(accountID, ""), formValues(params), res)
+}
+
+// UploadDocument uploads the images of the given identity document with the
+// identity_document purpose, and attaches the resulting File IDs to the
+// verification of the Person with the given ID.
+func (c PersonClient) UploadDocument(ctx context.Context, accountID, personID string, doc *IdentityDocument) (*Person, error) {
+	values, err := c.uploadIdentityDocument(ctx, "verification", doc)
+	if err != nil {
+		return nil, err
+	}
+	res := &Person{}
+	return res, c.query(ctx, "POST", c.path(accountID, personID), values, res)
+}
+
+// uploadIdentityDocument uploads the front and back images of doc, returning
+// the form values that attach them to the verification block at prefix.
+func (a api) uploadIdentityDocument(ctx context.Context, prefix string, doc *IdentityDocument) (url.Values, error) {
+	field := "document"
+	if doc.Additional {
+		field = "additional_document"
+	}
+	images := []struct {
+		side     string
+		filename string
+		r        io.Reader
+	}{
+		{"front", doc.FrontFilename, doc.Front},
+		{"back", doc.BackFilename, doc.Back},
+	}
+
+	values := make(url.Values)
+	for _, img := range images {
+		if img.r == nil {
+			continue
+		}
+		file, err := a.backend().Files.Create(ctx, &FileParams{
+			Purpose:  PurposeIdentityDocument,
+			Filename: img.filename,
+			Reader:   img.r,
+		})
+		if err != nil {
+			return nil, err
+		}
+		values.Add(fmt.Sprintf("%s[%s][%s]", prefix, field, img.side), file.ID)
+	}
+	return values, nil
+}
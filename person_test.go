@@ -0,0 +1,95 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCreatePerson will test that a Person is created under its Account with
+// its identity details and relationship nested in the form.
+func TestCreatePerson(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": "person_1",
+			"account": "acct_1",
+			"first_name": "Jenny",
+			"dob": {"day": 1, "month": 2, "year": 1980},
+			"id_number_provided": true,
+			"relationship": {"owner": true, "percent_ownership": 50},
+			"verification": {"status": "pending"}
+		}`)
+	})
+	owner, pct := true, 50.0
+	p, err := c.Persons.Create(context.Background(), "acct_1", &PersonParams{
+		FirstName: "Jenny",
+		DOB:       &DOB{Day: 1, Month: 2, Year: 1980},
+		Address:   &Address{Line1: "1 Main St", Country: "US"},
+		IDNumber:  "000000000",
+		Relationship: &PersonRelationshipParams{
+			Owner:            &owner,
+			PercentOwnership: &pct,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if !p.IDNumberProvided || p.DOB.Year != 1980 || !p.Relationship.Owner || p.Verification.Status != VerificationPending {
+		t.Errorf("Expected Person with identity details, got %+v", p)
+	}
+	if want := []string{"POST /v1/accounts/acct_1/persons"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	want := url.Values{
+		"first_name":                      {"Jenny"},
+		"dob[day]":                        {"1"},
+		"dob[month]":                      {"2"},
+		"dob[year]":                       {"1980"},
+		"address[line1]":                  {"1 Main St"},
+		"address[country]":                {"US"},
+		"id_number":                       {"000000000"},
+		"relationship[owner]":             {"true"},
+		"relationship[percent_ownership]": {"50"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestUploadPersonDocument will test that the images of an identity document
+// are uploaded to the files API, and attached to the Person's verification.
+func TestUploadPersonDocument(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "person_1", "account": "acct_1"}`)
+	})
+	uploads := newUploadServer(t, c)
+
+	doc := &IdentityDocument{
+		FrontFilename: "front.jpg",
+		Front:         strings.NewReader("front"),
+		BackFilename:  "back.jpg",
+		Back:          strings.NewReader("back"),
+	}
+	person, err := c.Persons.UploadDocument(context.Background(), "acct_1", "person_1", doc)
+	if err != nil || person.ID != "person_1" {
+		t.Fatalf("Expected Person person_1, got %v %v", person, err)
+	}
+
+	if want := []string{"identity_document front.jpg front", "identity_document back.jpg back"}; !reflect.DeepEqual(*uploads, want) {
+		t.Errorf("Expected uploads %v, got %v", want, *uploads)
+	}
+	if want := []string{"POST /v1/accounts/acct_1/persons/person_1"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Fatalf("Expected requests %v, got %v", want, srv.paths)
+	}
+	want := url.Values{
+		"verification[document][front]": {"file_1"},
+		"verification[document][back]":  {"file_2"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
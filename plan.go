@@ -1,25 +1,48 @@
 package stripe
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
-	"strconv"
 )
 
 // Plan Intervals
 const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
 	IntervalMonth = "month"
 	IntervalYear  = "year"
 )
 
+// Plan Billing Schemes
+const (
+	BillingPerUnit = "per_unit"
+	BillingTiered  = "tiered"
+)
+
+// Plan Tiers Modes
+const (
+	TiersGraduated = "graduated"
+	TiersVolume    = "volume"
+)
+
+// Usage Rounding Modes
+const (
+	RoundUp   = "up"
+	RoundDown = "down"
+)
+
 // Plan holds details about pricing information for different products and
 // feature levels on your site. For example, you might have a $10/month plan
 // for basic features and a different $20/month plan for premium features.
 //
 // see https://stripe.com/docs/api#plan_object
 type Plan struct {
+	APIResource
 	ID                   string            `json:"id"`
 	Name                 string            `json:"name"`
-	Amount               int               `json:"amount"`
+	Amount               int64             `json:"amount"`
 	Interval             string            `json:"interval"`
 	IntervalCount        int               `json:"interval_count"`
 	Currency             string            `json:"currency"`
@@ -28,114 +51,171 @@ type Plan struct {
 	Livemode             bool              `json:"livemode"`
 	Created              UnixTime          `json:"created"`
 	Metadata             map[string]string `json:"metadata"`
+	BillingScheme        string            `json:"billing_scheme,omitempty"`
+	Tiers                []*PlanTier       `json:"tiers,omitempty"`
+	TiersMode            string            `json:"tiers_mode,omitempty"`
+	TransformUsage       *TransformUsage   `json:"transform_usage,omitempty"`
+	Product              string            `json:"product,omitempty"`
+
+	// Nickname and StatementDescriptor are the name and statement
+	// description of the plan, as newer API versions call them.
+	Nickname            string `json:"-"`
+	StatementDescriptor string `json:"-"`
+}
+
+// UnmarshalJSON decodes a Plan, which is normalized to the fields of this
+// package's API version, and sets its Nickname and StatementDescriptor.
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	type plan Plan
+	if err := json.Unmarshal(data, (*plan)(p)); err != nil {
+		return err
+	}
+	p.Nickname = p.Name
+	p.StatementDescriptor = p.StatementDescription
+	return nil
+}
+
+// PlanTier is a pricing tier of a Plan with the tiered billing scheme. An
+// UpTo of zero means the tier has no upper bound, which is only allowed for
+// the last tier. A tier without either amount is free.
+type PlanTier struct {
+	UpTo       int64 `json:"up_to" form:"up_to"`
+	UnitAmount int64 `json:"unit_amount" form:"unit_amount"`
+	FlatAmount int64 `json:"flat_amount" form:"flat_amount"`
+}
+
+// TransformUsage describes how the reported quantity of a Plan is divided,
+// ie to bill per 1000 units, before it is billed.
+type TransformUsage struct {
+	DivideBy int64  `json:"divide_by" form:"divide_by"`
+	Round    string `json:"round" form:"round"`
 }
 
 // PlanClient encapsulates operations for creating, updating, deleting and
 // querying plans using the Stripe REST API.
-type PlanClient struct{}
+type PlanClient struct{ api }
 
 // PlanParams encapsulates options for creating a new Plan.
 type PlanParams struct {
 	// Unique string of your choice that will be used to identify this plan
 	// when subscribing a customer.
-	ID string
+	ID string `form:"id"`
 
 	// A positive integer in cents (or 0 for a free plan) representing how much
 	// to charge (on a recurring basis)
-	Amount int
+	Amount int64 `form:"amount,always"`
 
 	// 3-letter ISO code for currency. Currently, only 'usd' is supported.
-	Currency string
+	Currency string `form:"currency"`
 
-	// Specifies billing frequency. Either month or year.
-	Interval string
+	// Specifies billing frequency. Either day, week, month or year.
+	Interval string `form:"interval"`
 
-	// The number of intervals between each subscription billing.
-	IntervalCount int
+	// (Optional) The number of intervals between each subscription billing,
+	// ie 3 with a month interval to bill quarterly. Default is 1. The total
+	// may not exceed one year.
+	IntervalCount int `form:"interval_count"`
+
+	// (Optional) The ID of the Product the plan is a price of. Required in
+	// API versions that have products.
+	Product string `form:"product"`
 
 	// Name of the plan, to be displayed on invoices and in the web interface.
-	Name string
+	// Newer API versions call this the nickname of the plan; responses in
+	// those versions are normalized to Name.
+	Name string `form:"-"`
+
+	// (Optional) The nickname of the plan, as newer API versions call its
+	// name. Overrides Name.
+	Nickname string `form:"-"`
 
 	// (Optional) Specifies a trial period in (an integer number of) days. If
 	// you include a trial period, the customer won't be billed for the first
 	// time until the trial period ends. If the customer cancels before the
 	// trial period is over, she'll never be billed at all.
-	TrialPeriodDays int
+	TrialPeriodDays int `form:"trial_period_days"`
 
 	// An arbitrary string to be displayed on your customers' credit card
 	// statements (alongside your company name) for charges created by this
-	// plan.
-	StatementDescription *string
+	// plan. Newer API versions call this the statement descriptor; responses
+	// in those versions are normalized to StatementDescription.
+	StatementDescription *string `form:"-"`
+
+	// (Optional) The statement descriptor of the plan, as newer API versions
+	// call its statement description. Overrides StatementDescription.
+	StatementDescriptor *string `form:"-"`
+
+	// (Optional) How the price is computed from the quantity, either
+	// per_unit or tiered. Default is per_unit. Amount is not sent for tiered
+	// plans.
+	BillingScheme string `form:"billing_scheme"`
+
+	// (Optional) The pricing tiers of a tiered plan, in increasing order of
+	// UpTo. The last tier must have an UpTo of zero, which is sent as inf.
+	Tiers []*PlanTier `form:"tiers"`
 
-	Metadata map[string]string
+	// (Optional) Whether the tiers of a tiered plan are graduated, each tier
+	// pricing the quantity within it, or volume, the tier of the total
+	// quantity pricing all of it.
+	TiersMode string `form:"tiers_mode"`
+
+	// (Optional) How the quantity is transformed before it is billed. Not
+	// compatible with Tiers.
+	TransformUsage *TransformUsage `form:"transform_usage"`
+
+	Metadata map[string]string `form:"metadata"`
 }
 
 // Creates a new Plan.
 //
 // see https://stripe.com/docs/api#create_plan
-func (PlanClient) Create(params *PlanParams) (*Plan, error) {
+func (c PlanClient) Create(ctx context.Context, params *PlanParams) (*Plan, error) {
 	plan := Plan{}
-	values := url.Values{
-		"id":       {params.ID},
-		"name":     {params.Name},
-		"amount":   {strconv.Itoa(params.Amount)},
-		"interval": {params.Interval},
-		"currency": {params.Currency},
-	}
-
-	// trial_period_days is optional, add if specified
-	if params.TrialPeriodDays != 0 {
-		values.Add("trial_period_days", strconv.Itoa(params.TrialPeriodDays))
-	}
-	if params.IntervalCount > 1 {
-		values.Add("interval_count", strconv.Itoa(params.IntervalCount))
-	}
-	if params.StatementDescription != nil {
-		values.Add("statement_description", *params.StatementDescription)
-	}
-	appendMetadata(values, params.Metadata)
-
-	err := query("POST", "/plans", values, &plan)
+	values := planValues(params)
+	setPlanNames(values, params, c.backend().version(ctx))
+	err := c.query(ctx, "POST", "/plans", values, &plan)
 	return &plan, err
 }
 
 // Retrieves the plan with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_plan
-func (PlanClient) Get(id string) (*Plan, error) {
-	plan := Plan{}
+func (c PlanClient) Get(ctx context.Context, id string) (*Plan, error) {
+	plan := &Plan{}
+	return plan, c.GetInto(ctx, id, plan)
+}
+
+// GetInto is like Get, but decodes the Plan into v, which may be any
+// type with matching JSON fields.
+func (c PlanClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/plans/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &plan)
-	return &plan, err
+	return c.query(ctx, "GET", path, nil, v)
 }
 
-// Updates the name of a plan. Other plan details (price, interval, etc.) are,
-// by design, not editable.
+// Updates the name, statement description and metadata of a plan. Other plan
+// details (price, interval, etc.) are, by design, not editable.
 //
 // see https://stripe.com/docs/api#update_plan
-func (PlanClient) Update(id string, params *PlanParams) (*Plan, error) {
-	values := make(url.Values)
-	if params.Name != "" {
-		values.Add("name", params.Name)
-	}
-	if params.StatementDescription != nil {
-		values.Add("statement_description", *params.StatementDescription)
-	}
-	appendMetadata(values, params.Metadata)
+func (c PlanClient) Update(ctx context.Context, id string, params *PlanParams) (*Plan, error) {
+	// only the name, statement description and metadata can be changed
+	values := formValues(struct {
+		Metadata map[string]string `form:"metadata"`
+	}{params.Metadata})
+	setPlanNames(values, params, c.backend().version(ctx))
 
 	plan := Plan{}
 	path := "/plans/" + url.QueryEscape(id)
-	err := query("POST", path, values, &plan)
+	err := c.query(ctx, "POST", path, values, &plan)
 	return &plan, err
 }
 
 // Deletes a plan with the given ID.
 //
 // see https://stripe.com/docs/api#delete_plan
-func (PlanClient) Delete(id string) (bool, error) {
+func (c PlanClient) Delete(ctx context.Context, id string) (bool, error) {
 	resp := DeleteResp{}
 	path := "/plans/" + url.QueryEscape(id)
-	if err := query("DELETE", path, nil, &resp); err != nil {
+	if err := c.query(ctx, "DELETE", path, nil, &resp); err != nil {
 		return false, err
 	}
 	return resp.Deleted, nil
@@ -144,11 +224,75 @@ func (PlanClient) Delete(id string) (bool, error) {
 // Returns a list of your Plans.
 //
 // see https://stripe.com/docs/api#list_Plans
-func (PlanClient) List(limit int, before, after string) ([]*Plan, bool, error) {
+func (c PlanClient) List(ctx context.Context, limit int, before, after string) ([]*Plan, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Plan
 	}{}
-	err := query("GET", "/plans", listParams(limit, before, after), &res)
+	err := c.query(ctx, "GET", "/plans", listParams(limit, before, after), &res)
 	return res.Data, res.More, err
 }
+
+// Returns a page of Plans, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_Plans
+func (c PlanClient) ListFiltered(ctx context.Context, params *ListParams) (*List[Plan], error) {
+	res := &List[Plan]{}
+	return res, c.query(ctx, "GET", "/plans", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Plan, newest first, fetching pages as
+// they are needed. The Limit of params, if any, sets the size of each page.
+func (c PlanClient) ListAll(ctx context.Context, params *ListParams) *Iter[Plan] {
+	return newIter(ctx, c.backend(), "/plans", params, func(p *Plan) string { return p.ID })
+}
+
+func planValues(params *PlanParams) url.Values {
+	values := formValues(params)
+	if params.BillingScheme == BillingTiered {
+		values.Del("amount")
+	}
+	setTierValues(values, params.Tiers)
+	return values
+}
+
+// setPlanNames sends the name and statement description of a plan under the
+// names of the given API version, which calls them the nickname from
+// 2018-02-05 and the statement descriptor from 2014-12-17.
+func setPlanNames(values url.Values, params *PlanParams, version string) {
+	name, nameKey := params.Name, "name"
+	if params.Nickname != "" {
+		name = params.Nickname
+	}
+	if version >= "2018-02-05" {
+		nameKey = "nickname"
+	}
+	if name != "" {
+		values.Set(nameKey, name)
+	}
+
+	desc, descKey := params.StatementDescription, "statement_description"
+	if params.StatementDescriptor != nil {
+		desc = params.StatementDescriptor
+	}
+	if version >= "2014-12-17" {
+		descKey = "statement_descriptor"
+	}
+	if desc != nil {
+		values.Set(descKey, *desc)
+	}
+}
+
+// setTierValues sends the up_to of each tier without an upper bound as inf,
+// and a zero unit_amount for free tiers, since every tier needs an amount.
+func setTierValues(values url.Values, tiers []*PlanTier) {
+	for i, tier := range tiers {
+		if tier.UpTo == 0 {
+			values.Set(fmt.Sprintf("tiers[%d][up_to]", i), "inf")
+		}
+		if tier.UnitAmount == 0 && tier.FlatAmount == 0 {
+			values.Set(fmt.Sprintf("tiers[%d][unit_amount]", i), "0")
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package stripe
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -44,8 +50,8 @@ var (
 func TestCreatePlan(t *testing.T) {
 
 	// Create the plan, and defer its deletion
-	plan, err := Plans.Create(&p1)
-	defer Plans.Delete(p1.ID)
+	plan, err := Plans.Create(context.Background(), &p1)
+	defer Plans.Delete(context.Background(), p1.ID)
 
 	if err != nil {
 		t.Errorf("Expected Plan %s, got Error %s", p1.ID, err.Error())
@@ -64,7 +70,7 @@ func TestCreatePlan(t *testing.T) {
 	}
 
 	// Now try to re-create the existing plan, which should throw an exception
-	_, err = Plans.Create(&p1)
+	_, err = Plans.Create(context.Background(), &p1)
 	if err == nil {
 		t.Error("Expected non-null Error when creating a duplicate Plan.")
 	} else if err.Error() != "Plan already exists." {
@@ -75,7 +81,7 @@ func TestCreatePlan(t *testing.T) {
 	var p3 PlanParams
 	p3 = p1
 	p3.Currency = "XXX"
-	_, err = Plans.Create(&p3)
+	_, err = Plans.Create(context.Background(), &p3)
 	if err == nil {
 		t.Error("Expected non-null Error when using an Invalid Currency.")
 	} else if strings.HasPrefix(err.Error(), "Invalid currency: xxx.") == false {
@@ -87,11 +93,11 @@ func TestCreatePlan(t *testing.T) {
 // parse the JSON response, and that all values are populated as expected.
 func TestRetrievePlan(t *testing.T) {
 	// Create the plan, and defer its deletion
-	Plans.Create(&p2)
-	defer Plans.Delete(p2.ID)
+	Plans.Create(context.Background(), &p2)
+	defer Plans.Delete(context.Background(), p2.ID)
 
 	// Retrieve the Plan by ID
-	plan, err := Plans.Get(p2.ID)
+	plan, err := Plans.Get(context.Background(), p2.ID)
 	if err != nil {
 		t.Errorf("Expected Plan %s, got Error %s", p2.ID, err.Error())
 	}
@@ -117,10 +123,10 @@ func TestRetrievePlan(t *testing.T) {
 // the JSON reponse, and verify the updated name was returned.
 func TestUpdatePlan(t *testing.T) {
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Plans.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Plans.Delete(context.Background(), p1.ID)
 
-	plan, err := Plans.Update(p1.ID, &PlanParams{Name: "New Name"})
+	plan, err := Plans.Update(context.Background(), p1.ID, &PlanParams{Name: "New Name"})
 	if err != nil {
 		t.Errorf("Expected Plan update, got Error %s", err.Error())
 	}
@@ -133,10 +139,10 @@ func TestUpdatePlan(t *testing.T) {
 // the JSON reponse, and that the deletion flag is captured as a boolean value.
 func TestDeletePlan(t *testing.T) {
 	// create a Plan that we can delete
-	Plans.Create(&p1)
+	Plans.Create(context.Background(), &p1)
 
 	// let's try to delete the plan
-	ok, err := Plans.Delete(p1.ID)
+	ok, err := Plans.Delete(context.Background(), p1.ID)
 	if err != nil {
 		t.Errorf("Expected Plan deletion, got Error %s", err.Error())
 	}
@@ -151,13 +157,13 @@ func TestDeletePlan(t *testing.T) {
 func TestListPlan(t *testing.T) {
 
 	// create 2 dummy plans that we can retrieve
-	Plans.Create(&p1)
-	Plans.Create(&p2)
-	defer Plans.Delete(p1.ID)
-	defer Plans.Delete(p2.ID)
+	Plans.Create(context.Background(), &p1)
+	Plans.Create(context.Background(), &p2)
+	defer Plans.Delete(context.Background(), p1.ID)
+	defer Plans.Delete(context.Background(), p2.ID)
 
 	// get the list from Stripe
-	plans, _, err := Plans.List(10, "", "")
+	plans, _, err := Plans.List(context.Background(), 10, "", "")
 	if err != nil {
 		t.Errorf("Expected Plan List, got Error %s", err.Error())
 	}
@@ -167,3 +173,110 @@ func TestListPlan(t *testing.T) {
 		t.Errorf("Expected 2 Plans, got %d", len(plans))
 	}
 }
+
+// TestTieredPlan will test that the tiers of a tiered Plan are sent without
+// an amount and with an unbounded last tier, and decoded on retrieval.
+func TestTieredPlan(t *testing.T) {
+	got := planValues(&PlanParams{
+		ID:            "api",
+		Currency:      "usd",
+		Interval:      IntervalMonth,
+		BillingScheme: BillingTiered,
+		TiersMode:     TiersGraduated,
+		Tiers: []*PlanTier{
+			{UpTo: 1000, UnitAmount: 5},
+			{FlatAmount: 2000},
+		},
+	})
+	want := url.Values{
+		"id":                    {"api"},
+		"currency":              {"usd"},
+		"interval":              {"month"},
+		"billing_scheme":        {"tiered"},
+		"tiers_mode":            {"graduated"},
+		"tiers[0][up_to]":       {"1000"},
+		"tiers[0][unit_amount]": {"5"},
+		"tiers[1][up_to]":       {"inf"},
+		"tiers[1][flat_amount]": {"2000"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	plan := &Plan{}
+	data := `{"id": "api", "billing_scheme": "tiered", "tiers_mode": "graduated", "tiers": [{"up_to": 1000, "unit_amount": 5}, {"up_to": null, "flat_amount": 2000}]}`
+	if err := json.Unmarshal([]byte(data), plan); err != nil || len(plan.Tiers) != 2 || plan.Tiers[1].UpTo != 0 || plan.Tiers[1].FlatAmount != 2000 {
+		t.Errorf("Expected 2 tiers with an unbounded last tier, got %+v %v", plan.Tiers, err)
+	}
+}
+
+// TestFreeTier will test that a free tier is sent with a zero unit amount,
+// for both Plans and Prices.
+func TestFreeTier(t *testing.T) {
+	tiers := []*PlanTier{{UpTo: 100}, {UnitAmount: 5}}
+	got := planValues(&PlanParams{ID: "api", BillingScheme: BillingTiered, Tiers: tiers})
+	if got.Get("tiers[0][unit_amount]") != "0" || got.Get("tiers[1][unit_amount]") != "5" {
+		t.Errorf("Expected a free first tier and a unit amount of 5, got %v", got)
+	}
+
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "price_1"}`)
+	})
+	if _, err := c.Prices.Create(context.Background(), &PriceParams{Currency: "usd", Tiers: tiers}); err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if form := srv.forms[0]; form.Get("tiers[0][unit_amount]") != "0" || form.Get("tiers[0][up_to]") != "100" {
+		t.Errorf("Expected a free first tier up to 100, got %v", form)
+	}
+}
+
+// TestUpdatePlanMetadata will test that only the name, statement description
+// and metadata of a Plan are sent when it is updated.
+func TestUpdatePlanMetadata(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "gold", "name": "Gold", "statement_description": "GOLD PLAN", "metadata": {"sku": "g-1"}}`)
+	})
+	desc := "GOLD PLAN"
+	plan, err := c.Plans.Update(context.Background(), "gold", &PlanParams{
+		Amount:               999,
+		Name:                 "Gold",
+		StatementDescription: &desc,
+		Metadata:             map[string]string{"sku": "g-1"},
+	})
+	if err != nil || plan.StatementDescription != desc || plan.Metadata["sku"] != "g-1" {
+		t.Fatalf("Expected Plan with statement description and metadata, got %+v %v", plan, err)
+	}
+	want := url.Values{"name": {"Gold"}, "statement_description": {"GOLD PLAN"}, "metadata[sku]": {"g-1"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestPlanNicknameByVersion will test that the name and statement
+// description of a Plan are sent as its nickname and statement descriptor in
+// the API versions that call them that, and decoded into both fields.
+func TestPlanNicknameByVersion(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "gold", "object": "plan", "nickname": "Gold", "statement_descriptor": "GOLD PLAN"}`)
+	})
+	desc := "GOLD PLAN"
+	params := &PlanParams{Nickname: "Gold", StatementDescriptor: &desc}
+	ctx := WithAPIVersion(context.Background(), "2018-02-05")
+	plan, err := c.Plans.Update(ctx, "gold", params)
+	if err != nil || plan.Name != "Gold" || plan.Nickname != "Gold" || plan.StatementDescriptor != desc {
+		t.Fatalf("Expected Plan with nickname and statement descriptor, got %+v %v", plan, err)
+	}
+	if _, err := c.Plans.Update(context.Background(), "gold", params); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+
+	forms := []url.Values{
+		{"nickname": {"Gold"}, "statement_descriptor": {"GOLD PLAN"}},
+		{"name": {"Gold"}, "statement_description": {"GOLD PLAN"}},
+	}
+	for i, want := range forms {
+		if !reflect.DeepEqual(srv.forms[i], want) {
+			t.Errorf("Expected %v, got %v", want, srv.forms[i])
+		}
+	}
+}
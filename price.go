@@ -0,0 +1,203 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Price Types
+const (
+	PriceTypeOneTime   = "one_time"
+	PriceTypeRecurring = "recurring"
+)
+
+// Usage Types
+const (
+	UsageLicensed = "licensed"
+	UsageMetered  = "metered"
+)
+
+// Price represents how much, and how often, to charge for a product. Prices
+// supersede Plans in newer API versions; a Plan is a recurring Price.
+//
+// see https://stripe.com/docs/api#price_object
+type Price struct {
+	APIResource
+	ID            string            `json:"id"`
+	Active        bool              `json:"active"`
+	Type          string            `json:"type"`
+	Currency      string            `json:"currency"`
+	UnitAmount    int64             `json:"unit_amount"`
+	Product       string            `json:"product"`
+	Nickname      string            `json:"nickname,omitempty"`
+	LookupKey     string            `json:"lookup_key,omitempty"`
+	Recurring     *PriceRecurring   `json:"recurring,omitempty"`
+	BillingScheme string            `json:"billing_scheme"`
+	Tiers         []*PlanTier       `json:"tiers,omitempty"`
+	TiersMode     string            `json:"tiers_mode,omitempty"`
+	Created       UnixTime          `json:"created"`
+	Livemode      bool              `json:"livemode"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// PriceRecurring describes how often a recurring Price is billed.
+type PriceRecurring struct {
+	Interval      string `json:"interval" form:"interval"`
+	IntervalCount int    `json:"interval_count,omitempty" form:"interval_count"`
+	UsageType     string `json:"usage_type,omitempty" form:"usage_type"`
+}
+
+// PriceParams encapsulates options for creating and updating Prices.
+type PriceParams struct {
+	// 3-letter ISO code for currency. Only used when creating a Price.
+	Currency string `form:"currency"`
+
+	// The ID of the product the Price is for. Only used when creating a
+	// Price.
+	Product string `form:"product"`
+
+	// (Optional) The amount in cents to charge, which may be zero for a free
+	// Price. Required unless BillingScheme is tiered. Only used when creating
+	// a Price.
+	UnitAmount *int64 `form:"unit_amount"`
+
+	// (Optional) How often to bill, for a recurring Price. Only used when
+	// creating a Price.
+	Recurring *PriceRecurring `form:"recurring"`
+
+	// (Optional) How the price is computed from the quantity, either
+	// per_unit or tiered. Only used when creating a Price.
+	BillingScheme string `form:"billing_scheme"`
+
+	// (Optional) The pricing tiers of a tiered Price, in increasing order of
+	// UpTo. The last tier must have an UpTo of zero, which is sent as inf.
+	// Only used when creating a Price.
+	Tiers []*PlanTier `form:"tiers"`
+
+	// (Optional) Either graduated or volume, for a tiered Price. Only used
+	// when creating a Price.
+	TiersMode string `form:"tiers_mode"`
+
+	// (Optional) Whether the Price can be used for new purchases.
+	Active *bool `form:"active"`
+
+	// (Optional) A brief description of the Price, hidden from customers.
+	Nickname string `form:"nickname"`
+
+	// (Optional) A key to retrieve the Price by, in place of its ID.
+	LookupKey string `form:"lookup_key"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// PriceListParams encapsulates options for filtering a list of Prices.
+type PriceListParams struct {
+	ListParams
+
+	// (Optional) Only return prices for the product with this ID.
+	Product string `form:"product"`
+
+	// (Optional) Only return active or inactive prices.
+	Active *bool `form:"active"`
+
+	// (Optional) Only return prices of this type, either one_time or
+	// recurring.
+	Type string `form:"type"`
+
+	// (Optional) Only return prices in this currency.
+	Currency string `form:"currency"`
+
+	// (Optional) Only return prices with these lookup keys.
+	LookupKeys []string `form:"lookup_keys"`
+}
+
+// PriceClient encapsulates operations for creating, updating and querying
+// prices using the Stripe REST API.
+type PriceClient struct{ api }
+
+// Creates a new Price.
+//
+// see https://stripe.com/docs/api#create_price
+func (c PriceClient) Create(ctx context.Context, params *PriceParams) (*Price, error) {
+	values := formValues(params)
+	setTierValues(values, params.Tiers)
+
+	res := &Price{}
+	return res, c.query(ctx, "POST", "/prices", values, res)
+}
+
+// Retrieves the Price with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_price
+func (c PriceClient) Get(ctx context.Context, id string) (*Price, error) {
+	res := &Price{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Price into v, which may be any type
+// with matching JSON fields.
+func (c PriceClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/prices/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates whether a Price is active, and its nickname, lookup key and
+// metadata. The amount and interval of a Price can not be changed; create a
+// new Price instead.
+//
+// see https://stripe.com/docs/api#update_price
+func (c PriceClient) Update(ctx context.Context, id string, params *PriceParams) (*Price, error) {
+	// only these fields can be changed
+	values := formValues(struct {
+		Active    *bool             `form:"active"`
+		Nickname  string            `form:"nickname"`
+		LookupKey string            `form:"lookup_key"`
+		Metadata  map[string]string `form:"metadata"`
+	}{params.Active, params.Nickname, params.LookupKey, params.Metadata})
+
+	res := &Price{}
+	return res, c.query(ctx, "POST", "/prices/"+url.QueryEscape(id), values, res)
+}
+
+// Returns a list of Prices matching the given filters, or all of your Prices
+// when params is nil.
+//
+// see https://stripe.com/docs/api#list_prices
+func (c PriceClient) List(ctx context.Context, params *PriceListParams) ([]*Price, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Prices matching the given filters, or of all your
+// Prices when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_prices
+func (c PriceClient) ListFiltered(ctx context.Context, params *PriceListParams) (*List[Price], error) {
+	res := &List[Price]{}
+	return res, c.query(ctx, "GET", "/prices", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Price matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c PriceClient) ListAll(ctx context.Context, params *PriceListParams) *Iter[Price] {
+	return newIter(ctx, c.backend(), "/prices", params, func(p *Price) string { return p.ID })
+}
+
+// Search returns a page of the Prices matching the given query, ie
+// "active:'true' AND metadata['sku']:'g-1'". The page is empty for the first
+// page, or the NextPage of the previous result. Search results may lag
+// behind recent changes by up to a minute.
+//
+// see https://stripe.com/docs/api#search_prices
+func (c PriceClient) Search(ctx context.Context, query string, limit int, page string) (*SearchResult[Price], error) {
+	values := url.Values{"query": {query}}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	if page != "" {
+		values.Set("page", page)
+	}
+	res := &SearchResult[Price]{}
+	return res, c.query(ctx, "GET", "/prices/search", values, res)
+}
@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestCreatePrice will test that a recurring Price is created with its
+// interval nested under recurring, including a free amount.
+func TestCreatePrice(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "price_1", "type": "recurring", "product": "prod_1", "unit_amount": 0, "recurring": {"interval": "month", "interval_count": 3}}`)
+	})
+	free := int64(0)
+	price, err := c.Prices.Create(context.Background(), &PriceParams{
+		Currency:   "usd",
+		Product:    "prod_1",
+		UnitAmount: &free,
+		Recurring:  &PriceRecurring{Interval: IntervalMonth, IntervalCount: 3},
+	})
+	if err != nil || price.Type != PriceTypeRecurring || price.Recurring == nil || price.Recurring.IntervalCount != 3 {
+		t.Fatalf("Expected quarterly Price price_1, got %+v %v", price, err)
+	}
+	want := url.Values{
+		"currency":                  {"usd"},
+		"product":                   {"prod_1"},
+		"unit_amount":               {"0"},
+		"recurring[interval]":       {"month"},
+		"recurring[interval_count]": {"3"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestSearchPrices will test that Prices are searched with a query and paged
+// with the cursor of the previous result.
+func TestSearchPrices(t *testing.T) {
+	var query url.Values
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if r.URL.Path != "/v1/prices/search" {
+			t.Errorf("Expected path /v1/prices/search, got %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"object": "search_result", "url": "/v1/prices/search", "has_more": true, "next_page": "page_2", "data": [{"id": "price_1"}]}`)
+	})
+	res, err := c.Prices.Search(context.Background(), "active:'true'", 10, "page_1")
+	if err != nil || len(res.Data) != 1 || !res.More || res.NextPage != "page_2" {
+		t.Fatalf("Expected a page of Prices with a next page, got %+v %v", res, err)
+	}
+	want := url.Values{"query": {"active:'true'"}, "limit": {"10"}, "page": {"page_1"}}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("Expected %v, got %v", want, query)
+	}
+}
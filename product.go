@@ -0,0 +1,146 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// Product represents a good or service sold to customers, which Prices and
+// Plans refer to.
+//
+// see https://stripe.com/docs/api#product_object
+type Product struct {
+	APIResource
+	ID                  string            `json:"id"`
+	Name                string            `json:"name"`
+	Description         string            `json:"description,omitempty"`
+	Active              bool              `json:"active"`
+	Images              []string          `json:"images,omitempty"`
+	URL                 string            `json:"url,omitempty"`
+	StatementDescriptor string            `json:"statement_descriptor,omitempty"`
+	UnitLabel           string            `json:"unit_label,omitempty"`
+	Created             UnixTime          `json:"created"`
+	Updated             UnixTime          `json:"updated"`
+	Livemode            bool              `json:"livemode"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+}
+
+// ProductParams encapsulates options for creating and updating Products.
+type ProductParams struct {
+	// (Optional) Unique string of your choice that will be used to identify
+	// this product. Only used when creating a Product; default is generated.
+	ID string `form:"id"`
+
+	// The name of the product, shown to customers. Required when creating a
+	// Product.
+	Name string `form:"name"`
+
+	// (Optional) A description of the product, shown to customers.
+	Description string `form:"description"`
+
+	// (Optional) Whether the product can be bought. Default is true.
+	Active *bool `form:"active"`
+
+	// (Optional) The URLs of up to 8 images of the product.
+	Images []string `form:"images"`
+
+	// (Optional) The URL of a publicly accessible page for the product.
+	URL string `form:"url"`
+
+	// (Optional) An arbitrary string to be displayed on your customers'
+	// credit card statements for charges of the product.
+	StatementDescriptor string `form:"statement_descriptor"`
+
+	// (Optional) A label for the units of the product, ie seat, shown on
+	// invoices and receipts.
+	UnitLabel string `form:"unit_label"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// ProductListParams encapsulates options for filtering a list of Products.
+type ProductListParams struct {
+	ListParams
+
+	// (Optional) Only return active or inactive products.
+	Active *bool `form:"active"`
+
+	// (Optional) Only return products with these IDs.
+	IDs []string `form:"ids"`
+
+	// (Optional) Only return products created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// ProductClient encapsulates operations for creating, updating, deleting and
+// querying products using the Stripe REST API.
+type ProductClient struct{ api }
+
+// Creates a new Product.
+//
+// see https://stripe.com/docs/api#create_product
+func (c ProductClient) Create(ctx context.Context, params *ProductParams) (*Product, error) {
+	res := &Product{}
+	return res, c.query(ctx, "POST", "/products", formValues(params), res)
+}
+
+// Retrieves the Product with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_product
+func (c ProductClient) Get(ctx context.Context, id string) (*Product, error) {
+	res := &Product{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Product into v, which may be any type
+// with matching JSON fields.
+func (c ProductClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/products/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the Product with the given ID. The ID of a Product can not be
+// changed.
+//
+// see https://stripe.com/docs/api#update_product
+func (c ProductClient) Update(ctx context.Context, id string, params *ProductParams) (*Product, error) {
+	values := formValues(params)
+	values.Del("id")
+
+	res := &Product{}
+	return res, c.query(ctx, "POST", "/products/"+url.QueryEscape(id), values, res)
+}
+
+// Deletes the Product with the given ID. Only products without prices or
+// plans can be deleted; deactivate the Product instead.
+//
+// see https://stripe.com/docs/api#delete_product
+func (c ProductClient) Delete(ctx context.Context, id string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", "/products/"+url.QueryEscape(id), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of Products matching the given filters, or all of your
+// Products when params is nil.
+//
+// see https://stripe.com/docs/api#list_products
+func (c ProductClient) List(ctx context.Context, params *ProductListParams) ([]*Product, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Products matching the given filters, or of all your
+// Products when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_products
+func (c ProductClient) ListFiltered(ctx context.Context, params *ProductListParams) (*List[Product], error) {
+	res := &List[Product]{}
+	return res, c.query(ctx, "GET", "/products", formValues(params), res)
+}
+
+// ListAll returns an Iter over every Product matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c ProductClient) ListAll(ctx context.Context, params *ProductListParams) *Iter[Product] {
+	return newIter(ctx, c.backend(), "/products", params, func(p *Product) string { return p.ID })
+}
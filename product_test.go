@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestProducts will test that a Product is created with its images, and that
+// its ID is not sent when it is updated.
+func TestProducts(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "prod_gold", "name": "Gold", "active": true, "images": ["https://example.com/gold.png"]}`)
+	})
+	ctx := context.Background()
+	params := &ProductParams{ID: "prod_gold", Name: "Gold", Images: []string{"https://example.com/gold.png"}}
+	prod, err := c.Products.Create(ctx, params)
+	if err != nil || prod.ID != "prod_gold" || !prod.Active || len(prod.Images) != 1 {
+		t.Fatalf("Expected active Product prod_gold with an image, got %+v %v", prod, err)
+	}
+	want := url.Values{"id": {"prod_gold"}, "name": {"Gold"}, "images[0]": {"https://example.com/gold.png"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	params.Description = "The gold tier"
+	params.Images = nil
+	if _, err := c.Products.Update(ctx, "prod_gold", params); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if want := (url.Values{"name": {"Gold"}, "description": {"The gold tier"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	if want := []string{"POST /v1/products", "POST /v1/products/prod_gold"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
@@ -0,0 +1,151 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// PromotionCode represents a customer-redeemable code for a Coupon.
+//
+// see https://stripe.com/docs/api#promotion_code_object
+type PromotionCode struct {
+	APIResource
+	ID             string                    `json:"id"`
+	Code           string                    `json:"code"`
+	Coupon         *Coupon                   `json:"coupon"`
+	Customer       string                    `json:"customer,omitempty"`
+	Active         bool                      `json:"active"`
+	ExpiresAt      *UnixTime                 `json:"expires_at,omitempty"`
+	MaxRedemptions int                       `json:"max_redemptions,omitempty"`
+	TimesRedeemed  int                       `json:"times_redeemed"`
+	Restrictions   PromotionCodeRestrictions `json:"restrictions"`
+	Created        UnixTime                  `json:"created"`
+	Livemode       bool                      `json:"livemode"`
+	Metadata       map[string]string         `json:"metadata,omitempty"`
+}
+
+// PromotionCodeRestrictions limits which purchases a PromotionCode can be
+// redeemed for.
+type PromotionCodeRestrictions struct {
+	// Whether the code can only be redeemed by customers without any
+	// successful payments or invoices.
+	FirstTimeTransaction bool `json:"first_time_transaction" form:"first_time_transaction"`
+
+	// The minimum amount, in MinimumAmountCurrency, required to redeem the
+	// code.
+	MinimumAmount         int64  `json:"minimum_amount,omitempty" form:"minimum_amount"`
+	MinimumAmountCurrency string `json:"minimum_amount_currency,omitempty" form:"minimum_amount_currency"`
+}
+
+// PromotionCodeParams encapsulates options for creating a new PromotionCode.
+type PromotionCodeParams struct {
+	// The ID of the coupon applied when the code is redeemed.
+	Coupon string `form:"coupon"`
+
+	// (Optional) The code customers redeem, ie SPRING20. Default is
+	// generated.
+	Code string `form:"code"`
+
+	// (Optional) The ID of the only customer that can redeem the code.
+	Customer string `form:"customer"`
+
+	// (Optional) Whether the code can be redeemed. Default is true.
+	Active *bool `form:"active"`
+
+	// (Optional) The time at which the code can no longer be redeemed.
+	ExpiresAt *UnixTime `form:"expires_at"`
+
+	// (Optional) The number of times the code can be redeemed.
+	MaxRedemptions int `form:"max_redemptions"`
+
+	// (Optional) Limits on the purchases the code can be redeemed for.
+	Restrictions *PromotionCodeRestrictions `form:"restrictions"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// PromotionCodeListParams encapsulates options for filtering a list of
+// PromotionCodes.
+type PromotionCodeListParams struct {
+	ListParams
+
+	// (Optional) Only return active or inactive codes.
+	Active *bool `form:"active"`
+
+	// (Optional) Only return codes with this customer-facing code.
+	Code string `form:"code"`
+
+	// (Optional) Only return codes for the coupon with this ID.
+	Coupon string `form:"coupon"`
+
+	// (Optional) Only return codes restricted to the customer with this ID.
+	Customer string `form:"customer"`
+
+	// (Optional) Only return codes created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// PromotionCodeClient encapsulates operations for creating, updating and
+// querying promotion codes using the Stripe REST API.
+type PromotionCodeClient struct{ api }
+
+// Creates a new PromotionCode for a Coupon.
+//
+// see https://stripe.com/docs/api#create_promotion_code
+func (c PromotionCodeClient) Create(ctx context.Context, params *PromotionCodeParams) (*PromotionCode, error) {
+	res := &PromotionCode{}
+	return res, c.query(ctx, "POST", "/promotion_codes", formValues(params), res)
+}
+
+// Retrieves the PromotionCode with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_promotion_code
+func (c PromotionCodeClient) Get(ctx context.Context, id string) (*PromotionCode, error) {
+	res := &PromotionCode{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the PromotionCode into v, which may be any
+// type with matching JSON fields.
+func (c PromotionCodeClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/promotion_codes/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates whether the PromotionCode with the given ID can be redeemed, and
+// its metadata. Only these can be changed once a code is created.
+//
+// see https://stripe.com/docs/api#update_promotion_code
+func (c PromotionCodeClient) Update(ctx context.Context, id string, active *bool, metadata map[string]string) (*PromotionCode, error) {
+	params := struct {
+		Active   *bool             `form:"active"`
+		Metadata map[string]string `form:"metadata"`
+	}{active, metadata}
+
+	res := &PromotionCode{}
+	return res, c.query(ctx, "POST", "/promotion_codes/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// Returns a list of PromotionCodes matching the given filters, or all of your
+// PromotionCodes when params is nil.
+//
+// see https://stripe.com/docs/api#list_promotion_codes
+func (c PromotionCodeClient) List(ctx context.Context, params *PromotionCodeListParams) ([]*PromotionCode, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of PromotionCodes matching the given filters, or of all your
+// PromotionCodes when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_promotion_codes
+func (c PromotionCodeClient) ListFiltered(ctx context.Context, params *PromotionCodeListParams) (*List[PromotionCode], error) {
+	res := &List[PromotionCode]{}
+	return res, c.query(ctx, "GET", "/promotion_codes", formValues(params), res)
+}
+
+// ListAll returns an Iter over every PromotionCode matching the filters of
+// params, newest first, fetching pages as they are needed. The Limit of
+// params, if any, sets the size of each page.
+func (c PromotionCodeClient) ListAll(ctx context.Context, params *PromotionCodeListParams) *Iter[PromotionCode] {
+	return newIter(ctx, c.backend(), "/promotion_codes", params, func(pc *PromotionCode) string { return pc.ID })
+}
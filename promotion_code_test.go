@@ -0,0 +1,62 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestPromotionCodes will test that a PromotionCode is created with its
+// restrictions, and that only active and metadata are sent when it is
+// updated.
+func TestPromotionCodes(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "promo_1", "code": "SPRING20", "coupon": {"id": "SPRING"}, "active": true,
+			"restrictions": {"first_time_transaction": true, "minimum_amount": 1000, "minimum_amount_currency": "usd"}}`)
+	})
+	ctx := context.Background()
+	code, err := c.PromotionCodes.Create(ctx, &PromotionCodeParams{
+		Coupon: "SPRING",
+		Code:   "SPRING20",
+		Restrictions: &PromotionCodeRestrictions{
+			FirstTimeTransaction:  true,
+			MinimumAmount:         1000,
+			MinimumAmountCurrency: "usd",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if code.Coupon == nil || code.Coupon.ID != "SPRING" || !code.Restrictions.FirstTimeTransaction {
+		t.Errorf("Expected first time PromotionCode for coupon SPRING, got %+v", code)
+	}
+	want := url.Values{
+		"coupon":                                {"SPRING"},
+		"code":                                  {"SPRING20"},
+		"restrictions[first_time_transaction]":  {"true"},
+		"restrictions[minimum_amount]":          {"1000"},
+		"restrictions[minimum_amount_currency]": {"usd"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	active := false
+	if _, err := c.PromotionCodes.Update(ctx, "promo_1", &active, nil); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if want := (url.Values{"active": {"false"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	if _, _, err := c.PromotionCodes.List(ctx, &PromotionCodeListParams{Coupon: "SPRING"}); err != nil {
+		t.Fatalf("List failed: %s", err)
+	}
+
+	if want := []string{"POST /v1/promotion_codes", "POST /v1/promotion_codes/promo_1", "GET /v1/promotion_codes"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
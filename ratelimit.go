@@ -0,0 +1,53 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned when Stripe responds with 429 Too Many Requests,
+// and the request was not retried, or rate limiting continued for longer than
+// the wait set with SetRateLimitWait.
+//
+// see https://stripe.com/docs/rate-limits
+type RateLimitError struct {
+	// The error returned by Stripe.
+	Err *Error
+
+	// How long Stripe asked to wait before retrying, from the Retry-After
+	// header. Zero if the header was not sent.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("stripe: rate limited, retry after %s", e.RetryAfter)
+	}
+	return "stripe: rate limited"
+}
+
+// Unwrap returns the Error returned by Stripe.
+func (e *RateLimitError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// rateLimitError returns the RateLimitError for a 429 Response with the given
+// body.
+func rateLimitError(r *Response, body []byte) *RateLimitError {
+	e := &RateLimitError{Err: newError(r, body)}
+
+	// Retry-After is either a number of seconds or an HTTP date
+	if v := r.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			e.RetryAfter = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil && t.After(time.Now()) {
+			e.RetryAfter = time.Until(t)
+		}
+	}
+	return e
+}
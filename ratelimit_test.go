@@ -0,0 +1,46 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestRateLimit will test that a 429 response is returned as a
+// RateLimitError, and is retried after its Retry-After delay when a rate
+// limit wait is set.
+func TestRateLimit(t *testing.T) {
+	var attempts int
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts%2 == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(429)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Too many requests"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "ch_1"}`)
+	})
+	ctx := context.Background()
+	params := &ChargeParams{Amount: 400, Currency: USD, Customer: "cus_1"}
+
+	_, err := c.Charges.Create(ctx, params)
+	if rerr, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("Expected RateLimitError, got %v", err)
+	} else if rerr.RetryAfter != time.Second || rerr.Err.Detail.Message != "Too many requests" {
+		t.Errorf("Expected Retry-After of 1s and the Stripe error, got %s %v", rerr.RetryAfter, rerr.Err)
+	}
+
+	attempts = 0
+	c.RateLimitWait = 500 * time.Millisecond
+	if _, err := c.Charges.Create(ctx, params); attempts != 1 {
+		t.Errorf("Expected no retry beyond the rate limit wait, got %d attempts and %v", attempts, err)
+	}
+
+	attempts = 0
+	c.RateLimitWait = 2 * time.Second
+	if _, err := c.Charges.Create(ctx, params); err != nil || attempts != 2 {
+		t.Errorf("Expected POST to be retried after Retry-After, got %d attempts and %v", attempts, err)
+	}
+}
@@ -0,0 +1,149 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// Recipient Types.
+const (
+	RecipientIndividual  = "individual"
+	RecipientCorporation = "corporation"
+)
+
+// Recipient represents a person or company that receives transfers under the
+// legacy recipient model, which Stripe has replaced with connected accounts.
+//
+// see https://stripe.com/docs/api#recipient_object
+type Recipient struct {
+	APIResource
+	ID            string            `json:"id"`
+	Type          string            `json:"type"`
+	Name          string            `json:"name"`
+	Email         string            `json:"email,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	ActiveAccount *BankAccount      `json:"active_account,omitempty"`
+	Cards         *CardList         `json:"cards,omitempty"`
+	DefaultCard   string            `json:"default_card,omitempty"`
+	Verified      bool              `json:"verified"`
+	Created       UnixTime          `json:"created"`
+	Livemode      bool              `json:"livemode"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// RecipientParams encapsulates options for creating and updating Recipients.
+type RecipientParams struct {
+	// The recipient's full, legal name.
+	Name string `form:"name"`
+
+	// The type of the recipient, either individual or corporation. Can only
+	// be set when the recipient is created.
+	Type string `form:"type"`
+
+	// (Optional) The recipient's tax ID, as a string. For individuals, this
+	// is the full SSN; for corporations, the full EIN.
+	TaxID string `form:"tax_id"`
+
+	// (Optional) The bank account to transfer to.
+	BankAccount *BankAccountParams `form:"bank_account"`
+
+	// (Optional) A bank account Token, in place of BankAccount. Ignored if
+	// BankAccount is set.
+	BankAccountToken string `form:"-"`
+
+	// (Optional) A debit card to transfer to.
+	Card *CardParams `form:"card"`
+
+	// (Optional) A debit card Token, in place of Card. Ignored if Card is
+	// set.
+	Token string `form:"-"`
+
+	// (Optional) The recipient's email address.
+	Email string `form:"email"`
+
+	// (Optional) An arbitrary string to attach to the recipient.
+	Description string `form:"description"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// RecipientClient encapsulates operations for creating, updating, deleting
+// and querying legacy recipients using the Stripe REST API.
+type RecipientClient struct{ api }
+
+// Creates a new Recipient.
+//
+// see https://stripe.com/docs/api#create_recipient
+func (c RecipientClient) Create(ctx context.Context, params *RecipientParams) (*Recipient, error) {
+	res := &Recipient{}
+	return res, c.query(ctx, "POST", "/recipients", recipientValues(params), res)
+}
+
+// Retrieves the Recipient with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_recipient
+func (c RecipientClient) Get(ctx context.Context, id string) (*Recipient, error) {
+	res := &Recipient{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Recipient into v, which may be any
+// type with matching JSON fields.
+func (c RecipientClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/recipients/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the Recipient with the given ID. Only the fields that are set in
+// params are changed.
+//
+// see https://stripe.com/docs/api#update_recipient
+func (c RecipientClient) Update(ctx context.Context, id string, params *RecipientParams) (*Recipient, error) {
+	res := &Recipient{}
+	return res, c.query(ctx, "POST", "/recipients/"+url.QueryEscape(id), recipientValues(params), res)
+}
+
+// recipientValues returns the form-encoded fields of params, with each Token
+// sent only when the bank account or card it stands in for is not given.
+func recipientValues(params *RecipientParams) url.Values {
+	values := formValues(params)
+	if params.BankAccount == nil && params.BankAccountToken != "" {
+		values.Set("bank_account", params.BankAccountToken)
+	}
+	if params.Card == nil && params.Token != "" {
+		values.Set("card", params.Token)
+	}
+	return values
+}
+
+// Deletes the Recipient with the given ID.
+//
+// see https://stripe.com/docs/api#delete_recipient
+func (c RecipientClient) Delete(ctx context.Context, id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := c.query(ctx, "DELETE", "/recipients/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Recipients.
+//
+// see https://stripe.com/docs/api#list_recipients
+func (c RecipientClient) List(ctx context.Context, limit int, before, after string) ([]*Recipient, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Recipient
+	}{}
+	err := c.query(ctx, "GET", "/recipients", listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Recipients, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_recipients
+func (c RecipientClient) ListFiltered(ctx context.Context, params *ListParams) (*List[Recipient], error) {
+	res := &List[Recipient]{}
+	return res, c.query(ctx, "GET", "/recipients", formValues(params), res)
+}
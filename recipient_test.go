@@ -0,0 +1,77 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestCreateRecipient will test that a Recipient is created with its bank
+// account details nested under bank_account.
+func TestCreateRecipient(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "rp_1", "type": "individual", "name": "John Smith", "active_account": {"id": "ba_1", "last4": "6789"}}`)
+	})
+	rp, err := c.Recipients.Create(context.Background(), &RecipientParams{
+		Name:  "John Smith",
+		Type:  RecipientIndividual,
+		TaxID: "000000000",
+		BankAccount: &BankAccountParams{
+			Country:       "US",
+			RoutingNumber: "110000000",
+			AccountNumber: "000123456789",
+		},
+	})
+	if err != nil || rp.ID != "rp_1" || rp.ActiveAccount == nil || rp.ActiveAccount.Last4 != "6789" {
+		t.Fatalf("Expected Recipient rp_1 with its bank account, got %v %v", rp, err)
+	}
+	want := url.Values{
+		"name":                         {"John Smith"},
+		"type":                         {"individual"},
+		"tax_id":                       {"000000000"},
+		"bank_account[country]":        {"US"},
+		"bank_account[routing_number]": {"110000000"},
+		"bank_account[account_number]": {"000123456789"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestRecipientTokens will test that the bank account and card Tokens of a
+// Recipient are sent only in place of the details they stand in for.
+func TestRecipientTokens(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "rp_1"}`)
+	})
+	ctx := context.Background()
+	if _, err := c.Recipients.Update(ctx, "rp_1", &RecipientParams{
+		BankAccount:      &BankAccountParams{Country: "US", RoutingNumber: "110000000", AccountNumber: "000123456789"},
+		BankAccountToken: "btok_1",
+		Card:             &CardParams{Number: "4242424242424242", ExpMonth: 5, ExpYear: 2030},
+		Token:            "tok_1",
+	}); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	want := url.Values{
+		"bank_account[country]":        {"US"},
+		"bank_account[routing_number]": {"110000000"},
+		"bank_account[account_number]": {"000123456789"},
+		"card[number]":                 {"4242424242424242"},
+		"card[exp_month]":              {"5"},
+		"card[exp_year]":               {"2030"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	if _, err := c.Recipients.Update(ctx, "rp_1", &RecipientParams{BankAccountToken: "btok_1", Token: "tok_1"}); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if want := (url.Values{"bank_account": {"btok_1"}, "card": {"tok_1"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+}
@@ -0,0 +1,130 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Refund Reasons.
+const (
+	RefundDuplicate           = "duplicate"
+	RefundFraudulent          = "fraudulent"
+	RefundRequestedByCustomer = "requested_by_customer"
+)
+
+// Refund represents funds returned to the card of a previously made Charge.
+//
+// see https://stripe.com/docs/api#refund_object
+type Refund struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Amount             int64             `json:"amount"`
+	Currency           string            `json:"currency"`
+	Created            UnixTime          `json:"created"`
+	Charge             string            `json:"charge"`
+	BalanceTransaction string            `json:"balance_transaction"`
+	Reason             string            `json:"reason,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// RefundParams encapsulates options for refunding a Charge.
+type RefundParams struct {
+	// (Optional) The amount in cents to refund. Default is the entire amount
+	// of the Charge that has not been refunded yet.
+	Amount int64 `form:"amount"`
+
+	// (Optional) Why the Charge is refunded, one of duplicate, fraudulent or
+	// requested_by_customer.
+	Reason string `form:"reason"`
+
+	// (Optional) Whether to also refund the application fee that was
+	// collected with the Charge, in proportion to the amount refunded.
+	RefundApplicationFee bool `form:"refund_application_fee"`
+
+	// (Optional) Whether to also reverse the transfer made to the destination
+	// account of the Charge, in proportion to the amount refunded.
+	ReverseTransfer bool `form:"reverse_transfer"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows a Create request to be safely
+	// retried without refunding the Charge twice.
+	IdempotencyKey string
+}
+
+// RefundClient encapsulates operations for refunding charges and querying
+// their refunds using the Stripe REST API.
+type RefundClient struct{ api }
+
+func (c RefundClient) path(chargeID, refundID string) string {
+	p := fmt.Sprintf("/charges/%s/refunds", url.QueryEscape(chargeID))
+	if refundID != "" {
+		p += "/" + url.QueryEscape(refundID)
+	}
+	return p
+}
+
+// Refunds all or part of the Charge with the given ID.
+//
+// see https://stripe.com/docs/api#create_refund
+func (c RefundClient) Create(ctx context.Context, chargeID string, params *RefundParams) (*Refund, error) {
+	if params == nil {
+		params = &RefundParams{}
+	}
+	res := &Refund{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
+	return res, c.query(ctx, "POST", c.path(chargeID, ""), formValues(params), res)
+}
+
+// Retrieves the refund with the given ID of a Charge.
+//
+// see https://stripe.com/docs/api#retrieve_refund
+func (c RefundClient) Get(ctx context.Context, chargeID, refundID string) (*Refund, error) {
+	res := &Refund{}
+	return res, c.GetInto(ctx, chargeID, refundID, res)
+}
+
+// GetInto is like Get, but decodes the Refund into v, which may be any type
+// with matching JSON fields.
+func (c RefundClient) GetInto(ctx context.Context, chargeID, refundID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(chargeID, refundID), nil, v)
+}
+
+// Updates the metadata of a Refund. Other details of a Refund cannot be
+// changed once it is created.
+//
+// see https://stripe.com/docs/api#update_refund
+func (c RefundClient) Update(ctx context.Context, chargeID, refundID string, metadata map[string]string) (*Refund, error) {
+	values := formValues(struct {
+		Metadata map[string]string `form:"metadata"`
+	}{metadata})
+
+	res := &Refund{}
+	return res, c.query(ctx, "POST", c.path(chargeID, refundID), values, res)
+}
+
+// Returns a list of the refunds of a Charge.
+//
+// see https://stripe.com/docs/api#list_refunds
+func (c RefundClient) List(ctx context.Context, chargeID string, limit int, before, after string) ([]*Refund, bool, error) {
+	res := struct {
+		ListObject
+		Data []*Refund
+	}{}
+	err := c.query(ctx, "GET", c.path(chargeID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the refunds of the Charge with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_refunds
+func (c RefundClient) ListFiltered(ctx context.Context, chargeID string, params *ListParams) (*List[Refund], error) {
+	res := &List[Refund]{}
+	return res, c.query(ctx, "GET", c.path(chargeID, ""), formValues(params), res)
+}
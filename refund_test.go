@@ -0,0 +1,76 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestRefund will test that refunds are created, updated and listed under
+// the path of their Charge.
+func TestRefund(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"object": "list", "has_more": true, "data": [{"id": "re_1", "charge": "ch_1", "amount": 500}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "re_1", "charge": "ch_1", "amount": 500, "reason": "duplicate"}`)
+	})
+	ctx := context.Background()
+	re, err := c.Refunds.Create(ctx, "ch_1", &RefundParams{Amount: 500, Reason: RefundDuplicate})
+	if err != nil || re.ID != "re_1" || re.Charge != "ch_1" || re.Reason != RefundDuplicate {
+		t.Fatalf("Expected Refund re_1 of ch_1, got %v %v", re, err)
+	}
+	if want := (url.Values{"amount": {"500"}, "reason": {"duplicate"}}); !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	if _, err := c.Refunds.Update(ctx, "ch_1", "re_1", map[string]string{"order": "1"}); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if want := (url.Values{"metadata[order]": {"1"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	refunds, more, err := c.Refunds.List(ctx, "ch_1", 10, "", "")
+	if err != nil || len(refunds) != 1 || !more {
+		t.Errorf("Expected 1 Refund and more, got %d %v %v", len(refunds), more, err)
+	}
+
+	want := []string{"POST /v1/charges/ch_1/refunds", "POST /v1/charges/ch_1/refunds/re_1", "GET /v1/charges/ch_1/refunds"}
+	if !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestRefundWithParams will test that refunding a Charge sends the reason,
+// metadata and Connect options of the refund.
+func TestRefundWithParams(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "ch_1", "refunded": true, "amount_refunded": 1000}`)
+	})
+	ch, err := c.Charges.RefundWithParams(context.Background(), "ch_1", &RefundParams{
+		Reason:               RefundFraudulent,
+		Metadata:             map[string]string{"case": "42"},
+		RefundApplicationFee: true,
+		ReverseTransfer:      true,
+	})
+	if err != nil || !ch.Refunded {
+		t.Fatalf("Expected refunded Charge, got %v %v", ch, err)
+	}
+	if want := []string{"POST /v1/charges/ch_1/refund"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	want := url.Values{
+		"reason":                 {"fraudulent"},
+		"metadata[case]":         {"42"},
+		"refund_application_fee": {"true"},
+		"reverse_transfer":       {"true"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
@@ -0,0 +1,41 @@
+package stripe
+
+import "net/http"
+
+// Response describes the HTTP response a resource or Error was decoded from.
+// Stripe support asks for the RequestID when investigating a request.
+type Response struct {
+	StatusCode int
+	RequestID  string
+	Header     http.Header
+}
+
+// status returns the status code of r, or zero if there is no response.
+func (r *Response) status() int {
+	if r == nil {
+		return 0
+	}
+	return r.StatusCode
+}
+
+// APIResource is embedded in each of the resources returned by the API,
+// recording the response the resource was decoded from. Resources nested in
+// another, and those returned in lists, have no LastResponse.
+type APIResource struct {
+	LastResponse *Response `json:"-"`
+}
+
+func (r *APIResource) setLastResponse(resp *Response) {
+	r.LastResponse = resp
+}
+
+type responseSetter interface {
+	setLastResponse(*Response)
+}
+
+// setLastResponse records resp on v, if v is a resource.
+func setLastResponse(v interface{}, resp *Response) {
+	if s, ok := v.(responseSetter); ok {
+		s.setLastResponse(resp)
+	}
+}
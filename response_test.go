@@ -0,0 +1,37 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestLastResponse will test that the status code, request ID and headers of
+// the response are recorded on returned resources and errors.
+func TestLastResponse(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_"+r.URL.Path[len("/v1/charges/"):])
+		if r.URL.Path == "/v1/charges/missing" {
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "No such charge"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "ch_1"}`)
+	})
+
+	charge, err := c.Charges.Get(context.Background(), "ch_1")
+	if err != nil {
+		t.Fatalf("Expected charge, got %v", err)
+	}
+	if r := charge.LastResponse; r == nil || r.StatusCode != 200 || r.RequestID != "req_ch_1" || r.Header.Get("Request-Id") != "req_ch_1" {
+		t.Errorf("Expected LastResponse with request ID req_ch_1, got %+v", r)
+	}
+
+	_, err = c.Charges.Get(context.Background(), "missing")
+	if e, ok := err.(*Error); !ok {
+		t.Errorf("Expected Error, got %v", err)
+	} else if r := e.LastResponse; r == nil || r.StatusCode != 404 || r.RequestID != "req_missing" {
+		t.Errorf("Expected LastResponse with request ID req_missing, got %+v", r)
+	}
+}
@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether, and after how long, a failed request is
+// retried.
+type RetryPolicy interface {
+	// Retry is called after each failed attempt with the number of attempts
+	// made so far, the time elapsed since the first attempt, and either the
+	// HTTP status code of the response or the error that prevented one from
+	// being received. It returns how long to wait before the next attempt, or
+	// false to give up and return the failure to the caller.
+	Retry(attempt int, elapsed time.Duration, status int, err error) (time.Duration, bool)
+}
+
+// Jitter selects how a Backoff randomizes its delays, which keeps many
+// clients that failed at the same time from retrying in lockstep.
+type Jitter int
+
+// Jitter Strategies
+const (
+	// NoJitter waits exactly the exponential delay.
+	NoJitter Jitter = iota
+
+	// FullJitter waits a random duration between zero and the exponential
+	// delay.
+	FullJitter
+
+	// EqualJitter waits half of the exponential delay, plus a random duration
+	// up to the other half.
+	EqualJitter
+)
+
+// Backoff is a RetryPolicy that waits exponentially longer after each failed
+// attempt: Base, 2*Base, 4*Base and so on, up to Cap.
+type Backoff struct {
+	// The maximum number of attempts, including the first. Zero means no
+	// limit, in which case MaxElapsed should be set.
+	MaxAttempts int
+
+	// (Optional) The total time after which no further attempts are made,
+	// including time spent waiting between attempts.
+	MaxElapsed time.Duration
+
+	// The delay after the first failed attempt.
+	Base time.Duration
+
+	// (Optional) The maximum delay between any two attempts.
+	Cap time.Duration
+
+	// (Optional) How delays are randomized. Default is NoJitter.
+	Jitter Jitter
+
+	// (Optional) Reports whether a failure is worth retrying. Default is
+	// RetryableFailure.
+	Retryable func(status int, err error) bool
+}
+
+// Retry implements RetryPolicy.
+func (b *Backoff) Retry(attempt int, elapsed time.Duration, status int, err error) (time.Duration, bool) {
+	retryable := b.Retryable
+	if retryable == nil {
+		retryable = RetryableFailure
+	}
+	if !retryable(status, err) {
+		return 0, false
+	}
+	if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+		return 0, false
+	}
+	wait := b.Delay(attempt)
+	if b.MaxElapsed > 0 && elapsed+wait > b.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// Delay returns how long to wait after the given failed attempt, with jitter
+// applied.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	d := b.Base
+	for i := 1; i < attempt; i++ {
+		if b.Cap > 0 && d >= b.Cap {
+			break
+		}
+		d *= 2
+	}
+	if b.Cap > 0 && d > b.Cap {
+		d = b.Cap
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	switch b.Jitter {
+	case FullJitter:
+		return time.Duration(rand.Int63n(int64(d) + 1))
+	case EqualJitter:
+		return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+	return d
+}
+
+// RetryableFailure reports whether a failure is likely to be transient:
+// network errors, responses with a 5xx status code, and 409 responses caused
+// by contention on the object being modified.
+func RetryableFailure(status int, err error) bool {
+	return err != nil || status == http.StatusConflict || status >= http.StatusInternalServerError
+}
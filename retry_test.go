@@ -0,0 +1,116 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestBackoffDelay will test that delays grow exponentially up to the cap,
+// and that jittered delays stay within their expected bounds.
+func TestBackoffDelay(t *testing.T) {
+	b := &Backoff{Base: 100 * time.Millisecond, Cap: time.Second}
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if d := b.Delay(i + 1); d != w*time.Millisecond {
+			t.Errorf("Expected attempt %d delay %v, got %v", i+1, w*time.Millisecond, d)
+		}
+	}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		b.Jitter = FullJitter
+		if d := b.Delay(attempt); d < 0 || d > time.Second {
+			t.Errorf("Expected full jitter delay between 0 and 1s, got %v", d)
+		}
+		b.Jitter = EqualJitter
+		if d := b.Delay(attempt); d < 50*time.Millisecond || d > time.Second {
+			t.Errorf("Expected equal jitter delay between 50ms and 1s, got %v", d)
+		}
+	}
+}
+
+// TestBackoffRetry will test that a Backoff gives up once the attempt or
+// elapsed time budget is spent, or the failure is not retryable.
+func TestBackoffRetry(t *testing.T) {
+	b := &Backoff{MaxAttempts: 3, MaxElapsed: time.Second, Base: 100 * time.Millisecond}
+	netErr := errors.New("connection reset")
+
+	if _, ok := b.Retry(1, 0, 0, netErr); !ok {
+		t.Errorf("Expected network error to be retried")
+	}
+	if _, ok := b.Retry(1, 0, 503, nil); !ok {
+		t.Errorf("Expected 503 to be retried")
+	}
+	if _, ok := b.Retry(1, 0, 409, nil); !ok {
+		t.Errorf("Expected 409 to be retried")
+	}
+	if _, ok := b.Retry(1, 0, 402, nil); ok {
+		t.Errorf("Expected 402 not to be retried")
+	}
+	if _, ok := b.Retry(3, 0, 503, nil); ok {
+		t.Errorf("Expected no retry after MaxAttempts")
+	}
+	if _, ok := b.Retry(2, 950*time.Millisecond, 503, nil); ok {
+		t.Errorf("Expected no retry past MaxElapsed")
+	}
+
+	b.Retryable = func(status int, err error) bool { return status == 402 }
+	if _, ok := b.Retry(1, 0, 402, nil); !ok {
+		t.Errorf("Expected custom predicate to retry 402")
+	}
+}
+
+// TestBackoffIdempotent will test that only idempotent requests, and requests
+// with an idempotency key, are retried, and that a POST made without a key is
+// given one that stays the same across attempts. A key set on the context is
+// not replaced by the empty key of the params.
+func TestBackoffIdempotent(t *testing.T) {
+	var keys []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if keys = append(keys, r.Header.Get("Idempotency-Key")); len(keys)%2 == 1 {
+			w.WriteHeader(503)
+			fmt.Fprint(w, `{"error": {"type": "api_error"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "ch_1"}`)
+	})
+	c.RetryPolicy = &Backoff{MaxAttempts: 2}
+	ctx := context.Background()
+
+	keys = nil
+	if _, err := c.Charges.Get(ctx, "ch_1"); err != nil || len(keys) != 2 {
+		t.Errorf("Expected GET to be retried, got %d attempts and %v", len(keys), err)
+	}
+
+	keys = nil
+	params := &ChargeParams{Amount: 400, Currency: USD, Customer: "cus_1"}
+	if _, err := c.Charges.Create(ctx, params); err != nil || len(keys) != 2 {
+		t.Errorf("Expected POST to be retried, got %d attempts and %v", len(keys), err)
+	} else if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the same generated key on each attempt, got %q", keys)
+	}
+
+	keys = nil
+	params.IdempotencyKey = "key"
+	if _, err := c.Charges.Create(ctx, params); err != nil || len(keys) != 2 || keys[1] != "key" {
+		t.Errorf("Expected POST to be retried with the given key, got %q and %v", keys, err)
+	}
+
+	keys = nil
+	c.RetryPolicy = nil
+	if c.Charges.Create(ctx, &ChargeParams{Amount: 400, Currency: USD, Customer: "cus_1"}); len(keys) != 1 || keys[0] != "" {
+		t.Errorf("Expected no generated key without retries, got %q", keys)
+	}
+
+	// a key set on the context is kept when the params have none
+	keys = nil
+	keyed := WithIdempotencyKey(ctx, "order-42")
+	c.Charges.Create(keyed, &ChargeParams{Amount: 400, Currency: USD, Customer: "cus_1"})
+	c.Customers.Create(keyed, &CustomerParams{Email: "a@example.com"})
+	if len(keys) != 2 || keys[0] != "order-42" || keys[1] != "order-42" {
+		t.Errorf("Expected the key of the context on each request, got %q", keys)
+	}
+}
@@ -0,0 +1,218 @@
+// Package seed provisions a small, realistic set of test mode data in a
+// Stripe account, for demos, staging environments and integration tests.
+//
+// Seeding is reproducible: objects are identified by a prefix, products and
+// plans by ID, customers by email address and disputed charges by their
+// metadata, so running it again against the same account reuses what already
+// exists rather than creating duplicates.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cupcake/stripe"
+)
+
+// Test mode card numbers.
+//
+// see https://stripe.com/docs/testing
+const (
+	cardSucceeds = "4242424242424242"
+	cardDeclines = "4000000000000341" // attaches, but charges fail
+	cardDisputed = "4000000000000259" // charges succeed, then are disputed
+)
+
+// Options encapsulates options for seeding an account.
+type Options struct {
+	// The secret test mode API key of the account to seed. Live mode keys are
+	// refused.
+	Key string
+
+	// (Optional) The Client to seed with, ie one with a retry policy. Overrides
+	// Key, though its key must also be a secret test mode key.
+	Client *stripe.Client
+
+	// (Optional) The prefix of product and plan IDs and customer emails.
+	// Default is "seed".
+	Prefix string
+
+	// (Optional) The number of customers to create. Default is 8.
+	Customers int
+
+	// (Optional) The number of disputed charges to create. Default is 2.
+	Disputes int
+}
+
+// Dataset describes the seeded objects.
+type Dataset struct {
+	Products      []*stripe.Product
+	Plans         []*stripe.Plan
+	Customers     []*stripe.Customer
+	Subscriptions []*stripe.Subscription
+	Disputed      []*stripe.Charge
+}
+
+// Run seeds the account with products and their plans, customers with cards, subscriptions that
+// are active, trialing, canceled or heading to past due, and disputed
+// charges. Subscriptions heading to past due are trialing on a card that
+// declines, and become past due once their one day trial ends. Disputed
+// charges are made to a customer of their own.
+func Run(ctx context.Context, opts *Options) (*Dataset, error) {
+	c := opts.Client
+	if c == nil {
+		c = stripe.New(opts.Key)
+	}
+	if !strings.HasPrefix(c.Key, "sk_test_") {
+		return nil, errors.New("seed: refusing to seed without a secret test mode key")
+	}
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = "seed"
+	}
+	customers := opts.Customers
+	if customers <= 0 {
+		customers = 8
+	}
+	disputes := opts.Disputes
+	if disputes <= 0 {
+		disputes = 2
+	}
+
+	data := &Dataset{}
+	for _, p := range []stripe.ProductParams{
+		{ID: prefix + "_basic", Name: "Basic"},
+		{ID: prefix + "_pro", Name: "Pro"},
+	} {
+		product, err := getOrCreateProduct(ctx, c, &p)
+		if err != nil {
+			return data, err
+		}
+		data.Products = append(data.Products, product)
+	}
+
+	basic, pro := data.Products[0].ID, data.Products[1].ID
+	for _, p := range []stripe.PlanParams{
+		{ID: prefix + "_basic", Product: basic, Name: "Basic", Amount: 900, Currency: stripe.USD, Interval: stripe.IntervalMonth},
+		{ID: prefix + "_pro", Product: pro, Name: "Pro", Amount: 2900, Currency: stripe.USD, Interval: stripe.IntervalMonth},
+		{ID: prefix + "_annual", Product: pro, Name: "Pro Annual", Amount: 29000, Currency: stripe.USD, Interval: stripe.IntervalYear},
+	} {
+		plan, err := getOrCreatePlan(ctx, c, &p)
+		if err != nil {
+			return data, err
+		}
+		data.Plans = append(data.Plans, plan)
+	}
+
+	exp := time.Now().Year() + 2
+	for i := 0; i < customers; i++ {
+		if err := ctx.Err(); err != nil {
+			return data, err
+		}
+
+		// every fourth customer has a card that declines, and so on
+		state := i % 4
+		number := cardSucceeds
+		if state == 3 {
+			number = cardDeclines
+		}
+		email := fmt.Sprintf("%s+%d@example.com", prefix, i)
+		cust, created, err := c.Customers.GetOrCreateByEmail(ctx, email, &stripe.CustomerParams{
+			Description: fmt.Sprintf("Seed customer %d", i),
+			Card:        &stripe.CardParams{Name: fmt.Sprintf("Customer %d", i), Number: number, ExpMonth: 12, ExpYear: exp, CVC: "123"},
+		})
+		if err != nil {
+			return data, err
+		}
+		data.Customers = append(data.Customers, cust)
+		if !created {
+			if cust.Subscriptions != nil {
+				data.Subscriptions = append(data.Subscriptions, cust.Subscriptions.Data...)
+			}
+			continue
+		}
+
+		params := &stripe.SubscriptionParams{Plan: data.Plans[i%len(data.Plans)].ID}
+		switch state {
+		case 1:
+			trial := stripe.UnixTime{Time: time.Now().AddDate(0, 0, 14)}
+			params.TrialEnd = &trial
+		case 3:
+			trial := stripe.UnixTime{Time: time.Now().AddDate(0, 0, 1)}
+			params.TrialEnd = &trial
+		}
+		sub, err := c.Subscriptions.Create(ctx, cust.ID, params)
+		if err != nil {
+			return data, err
+		}
+		if state == 2 {
+			if sub, err = c.Subscriptions.Cancel(ctx, cust.ID, sub.ID, false); err != nil {
+				return data, err
+			}
+		}
+		data.Subscriptions = append(data.Subscriptions, sub)
+	}
+
+	// the disputed charges of an earlier run are found by their seed key, as
+	// idempotency keys expire after 24 hours
+	disputer, _, err := c.Customers.GetOrCreateByEmail(ctx, prefix+"+disputes@example.com", &stripe.CustomerParams{
+		Description: "Seed disputing customer",
+		Card:        &stripe.CardParams{Name: "Disputing Customer", Number: cardDisputed, ExpMonth: 12, ExpYear: exp, CVC: "123"},
+	})
+	if err != nil {
+		return data, err
+	}
+	disputed := make(map[string]*stripe.Charge)
+	it := c.Charges.ListAll(ctx, &stripe.ChargeListParams{Customer: disputer.ID})
+	for it.Next() {
+		if key := it.Value().Metadata["seed"]; key != "" {
+			disputed[key] = it.Value()
+		}
+	}
+	if err := it.Err(); err != nil {
+		return data, err
+	}
+
+	for i := 0; i < disputes; i++ {
+		key := fmt.Sprintf("%s-dispute-%d", prefix, i)
+		charge, ok := disputed[key]
+		if !ok {
+			charge, err = c.Charges.Create(ctx, &stripe.ChargeParams{
+				Amount:         int64(1000 * (i + 1)),
+				Currency:       stripe.USD,
+				Customer:       disputer.ID,
+				Description:    fmt.Sprintf("Seed disputed charge %d", i),
+				IdempotencyKey: key,
+				Metadata:       map[string]string{"seed": key},
+			})
+			if err != nil {
+				return data, err
+			}
+		}
+		data.Disputed = append(data.Disputed, charge)
+	}
+	return data, nil
+}
+
+// getOrCreateProduct returns the product with the ID of params, creating it
+// if it does not exist.
+func getOrCreateProduct(ctx context.Context, c *stripe.Client, params *stripe.ProductParams) (*stripe.Product, error) {
+	product, err := c.Products.Get(ctx, params.ID)
+	if e, ok := err.(*stripe.Error); ok && e.Code == 404 {
+		return c.Products.Create(ctx, params)
+	}
+	return product, err
+}
+
+// getOrCreatePlan returns the plan with the ID of params, creating it if it
+// does not exist.
+func getOrCreatePlan(ctx context.Context, c *stripe.Client, params *stripe.PlanParams) (*stripe.Plan, error) {
+	plan, err := c.Plans.Get(ctx, params.ID)
+	if e, ok := err.(*stripe.Error); ok && e.Code == 404 {
+		return c.Plans.Create(ctx, params)
+	}
+	return plan, err
+}
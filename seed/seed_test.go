@@ -0,0 +1,169 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cupcake/stripe"
+)
+
+// fakeAccount is an in-memory Stripe account serving the requests made while
+// seeding. It does not honor idempotency keys, as if they had expired.
+type fakeAccount struct {
+	mu      sync.Mutex
+	objects map[string]map[string]interface{} // by path, ie /v1/plans/seed_basic
+	order   []string                          // the paths of objects, in creation order
+}
+
+// create stores obj under path, with a generated ID if it has none.
+func (a *fakeAccount) create(path string, obj map[string]interface{}) map[string]interface{} {
+	if obj["id"] == nil {
+		obj["id"] = fmt.Sprintf("obj_%d", len(a.order))
+	}
+	key := fmt.Sprintf("%s/%s", path, obj["id"])
+	a.objects[key] = obj
+	a.order = append(a.order, key)
+	return obj
+}
+
+// list returns the objects created under path whose field matches value.
+func (a *fakeAccount) list(path, field, value string) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, key := range a.order {
+		if obj := a.objects[key]; strings.HasPrefix(key, path+"/") && obj[field] == value {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// count returns the number of objects created under path.
+func (a *fakeAccount) count(path string) int {
+	n := 0
+	for _, key := range a.order {
+		if strings.HasPrefix(key, path+"/") {
+			n++
+		}
+	}
+	return n
+}
+
+func (a *fakeAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	form, _ := url.ParseQuery(string(body))
+	if r.Method == "GET" {
+		form = r.URL.Query()
+	}
+
+	var res interface{}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	switch {
+	case r.Method == "POST" && len(parts) == 1:
+		obj := map[string]interface{}{}
+		for k := range form {
+			if n, err := strconv.ParseInt(form.Get(k), 10, 64); err == nil {
+				obj[k] = n
+			} else {
+				obj[k] = form.Get(k)
+			}
+		}
+		if id := form.Get("id"); id != "" {
+			obj["id"] = id
+		}
+		if seed := form.Get("metadata[seed]"); seed != "" {
+			obj["metadata"] = map[string]string{"seed": seed}
+		}
+		res = a.create(r.URL.Path, obj)
+	case r.Method == "GET" && len(parts) == 1 && parts[0] == "customers":
+		res = map[string]interface{}{"data": a.list(r.URL.Path, "email", form.Get("email"))}
+	case r.Method == "GET" && len(parts) == 1 && parts[0] == "charges":
+		res = map[string]interface{}{"data": a.list(r.URL.Path, "customer", form.Get("customer"))}
+	case r.Method == "GET" && len(parts) == 2:
+		obj, ok := a.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(404)
+			fmt.Fprintf(w, `{"error": {"type": "invalid_request_error", "message": "No such object: %s"}}`, parts[1])
+			return
+		}
+		res = obj
+	case r.Method == "POST" && len(parts) == 3 && parts[2] == "subscriptions":
+		sub := a.create("/v1/subscriptions", map[string]interface{}{
+			"customer": parts[1],
+			"plan":     map[string]interface{}{"id": form.Get("plan")},
+			"status":   "active",
+		})
+		a.objects["/v1/customers/"+parts[1]]["subscriptions"] = map[string]interface{}{
+			"data": a.list("/v1/subscriptions", "customer", parts[1]),
+		}
+		res = sub
+	case r.Method == "DELETE" && len(parts) == 4 && parts[2] == "subscriptions":
+		obj := a.objects["/v1/subscriptions/"+parts[3]]
+		obj["status"] = "canceled"
+		res = obj
+	default:
+		w.WriteHeader(404)
+		fmt.Fprintf(w, `{"error": {"type": "invalid_request_error", "message": "Unrecognized request URL (%s %s)"}}`, r.Method, r.URL.Path)
+		return
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+// TestRun will test that an account is seeded with products, plans,
+// customers, subscriptions and disputed charges, and that seeding it again
+// reuses every object rather than creating duplicates.
+func TestRun(t *testing.T) {
+	account := &fakeAccount{objects: make(map[string]map[string]interface{})}
+	srv := httptest.NewServer(account)
+	defer srv.Close()
+
+	c := stripe.New("sk_test_dummy")
+	c.URL = srv.URL
+	opts := &Options{Client: c, Prefix: "demo", Customers: 4, Disputes: 2}
+
+	first, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Seeding failed: %s", err)
+	}
+	if len(first.Products) != 2 || len(first.Plans) != 3 || len(first.Customers) != 4 || len(first.Subscriptions) != 4 || len(first.Disputed) != 2 {
+		t.Fatalf("Expected 2 products, 3 plans, 4 customers, 4 subscriptions and 2 disputed charges, got %+v", first)
+	}
+	if plan := account.objects["/v1/plans/demo_annual"]; plan["product"] != "demo_pro" {
+		t.Errorf("Expected plan demo_annual of product demo_pro, got %v", plan)
+	}
+	if charge := first.Disputed[1]; charge.Amount != 2000 || charge.Metadata["seed"] != "demo-dispute-1" {
+		t.Errorf("Expected disputed charge demo-dispute-1 of 2000, got %+v", charge)
+	}
+
+	second, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Seeding again failed: %s", err)
+	}
+	counts := map[string]int{"products": 2, "plans": 3, "customers": 5, "subscriptions": 4, "charges": 2}
+	for path, want := range counts {
+		if got := account.count("/v1/" + path); got != want {
+			t.Errorf("Expected %d %s after seeding again, got %d", want, path, got)
+		}
+	}
+	if len(second.Subscriptions) != 4 || len(second.Disputed) != 2 || second.Disputed[0].ID != first.Disputed[0].ID {
+		t.Errorf("Expected the objects of the first run, got %+v", second)
+	}
+}
+
+// TestRunLiveKey will test that an account is not seeded with a live mode
+// key.
+func TestRunLiveKey(t *testing.T) {
+	if _, err := Run(context.Background(), &Options{Key: "sk_live_dummy"}); err == nil {
+		t.Errorf("Expected seeding with a live mode key to be refused")
+	}
+}
@@ -0,0 +1,69 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SourceTransaction represents funds pushed by a customer to a push-funded
+// Source, such as an ACH or SEPA credit transfer.
+//
+// see https://stripe.com/docs/api#source_transaction_object
+type SourceTransaction struct {
+	APIResource
+	ID                 string              `json:"id"`
+	Amount             int64               `json:"amount"`
+	Created            UnixTime            `json:"created"`
+	Currency           string              `json:"currency"`
+	Source             string              `json:"source"`
+	Status             string              `json:"status"`
+	Type               string              `json:"type"`
+	Livemode           bool                `json:"livemode"`
+	ACHCreditTransfer  *ACHCreditTransfer  `json:"ach_credit_transfer,omitempty"`
+	SEPACreditTransfer *SEPACreditTransfer `json:"sepa_credit_transfer,omitempty"`
+}
+
+// ACHCreditTransfer holds the details of the bank account an ACH credit
+// transfer was sent from.
+type ACHCreditTransfer struct {
+	CustomerData  string `json:"customer_data,omitempty"`
+	Fingerprint   string `json:"fingerprint"`
+	Last4         string `json:"last4"`
+	RoutingNumber string `json:"routing_number"`
+}
+
+// SEPACreditTransfer holds the details of the bank account a SEPA credit
+// transfer was sent from.
+type SEPACreditTransfer struct {
+	Reference  string `json:"reference,omitempty"`
+	SenderIBAN string `json:"sender_iban"`
+	SenderName string `json:"sender_name"`
+}
+
+// SourceTransactionClient encapsulates operations for querying the
+// transactions of a Source using the Stripe REST API.
+type SourceTransactionClient struct{ api }
+
+// Returns a list of the transactions received by the Source with the given
+// ID.
+//
+// see https://stripe.com/docs/api#source_transactions
+func (c SourceTransactionClient) List(ctx context.Context, sourceID string, limit int, before, after string) ([]*SourceTransaction, bool, error) {
+	res := struct {
+		ListObject
+		Data []*SourceTransaction
+	}{}
+	path := fmt.Sprintf("/sources/%s/source_transactions", url.QueryEscape(sourceID))
+	err := c.query(ctx, "GET", path, listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the transactions of the Source with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#source_transactions
+func (c SourceTransactionClient) ListFiltered(ctx context.Context, sourceID string, params *ListParams) (*List[SourceTransaction], error) {
+	res := &List[SourceTransaction]{}
+	return res, c.query(ctx, "GET", fmt.Sprintf("/sources/%s/source_transactions", url.QueryEscape(sourceID)), formValues(params), res)
+}
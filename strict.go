@@ -0,0 +1,211 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StrictMode controls how responses that don't match the structs they are
+// decoded into are handled.
+type StrictMode int
+
+// Strict Modes
+const (
+	// StrictOff decodes responses leniently, as encoding/json does.
+	StrictOff StrictMode = iota
+
+	// StrictLog reports a SchemaError for mismatched responses to the
+	// SchemaErrorHandler of the Client, or else as the Err of a RequestLog
+	// to its Logger, but otherwise decodes them leniently.
+	StrictLog
+
+	// StrictError returns a SchemaError for mismatched responses, in
+	// addition to decoding them.
+	StrictError
+)
+
+// SchemaError describes how a response differed from the struct it was
+// decoded into, which usually means the API version the account is pinned to
+// has changed.
+type SchemaError struct {
+	// The Go type the response was decoded into.
+	Type string
+
+	// Fields in the response that the struct has no field for, as dotted
+	// paths such as "card.brand" or "lines.data[].tax_rates".
+	Unknown []string
+
+	// Fields the struct expects, that is fields without the omitempty
+	// option, that were not in the response.
+	Missing []string
+}
+
+func (e *SchemaError) Error() string {
+	var parts []string
+	if len(e.Unknown) != 0 {
+		parts = append(parts, "unknown fields "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) != 0 {
+		parts = append(parts, "missing fields "+strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("stripe: response does not match %s: %s", e.Type, strings.Join(parts, "; "))
+}
+
+// SetStrictMode sets how responses that contain unknown fields, or lack
+// expected ones, are handled. Note that when decoding into your own types
+// with GetInto, any field left out of the type is reported as unknown.
+func SetStrictMode(mode StrictMode) {
+	_default.StrictMode = mode
+}
+
+// SetSchemaErrorHandler sets the function called with the SchemaError of
+// every mismatched response in StrictLog mode. When it is nil, the default,
+// the SchemaError is sent to the Logger instead, if there is one.
+func SetSchemaErrorHandler(f func(*SchemaError)) {
+	_default.SchemaErrorHandler = f
+}
+
+// the response fields that are never reported as unknown
+var ignoredFields = map[string]bool{"object": true}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// checkSchema compares the JSON-encoded body with the type of v, returning a
+// SchemaError if the body has fields the type doesn't know about, or lacks
+// fields the type requires.
+func checkSchema(body []byte, v interface{}) *SchemaError {
+	var raw interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return nil
+	}
+
+	t := reflect.TypeOf(v)
+	e := &SchemaError{Type: t.String()}
+	unknown, missing := make(map[string]bool), make(map[string]bool)
+	walkSchema(t, raw, "", unknown, missing)
+	if len(unknown) == 0 && len(missing) == 0 {
+		return nil
+	}
+	for k := range unknown {
+		e.Unknown = append(e.Unknown, k)
+	}
+	for k := range missing {
+		e.Missing = append(e.Missing, k)
+	}
+	sort.Strings(e.Unknown)
+	sort.Strings(e.Missing)
+	return e
+}
+
+// schemaField is a JSON-encoded struct field, following the same naming
+// rules as encoding/json.
+type schemaField struct {
+	name      string
+	omitempty bool
+	typ       reflect.Type
+}
+
+func walkSchema(t reflect.Type, raw interface{}, path string, unknown, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// types that decode themselves are opaque
+	if raw == nil || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := schemaFields(t)
+		seen := make(map[string]bool)
+		for key, val := range obj {
+			f := matchField(fields, key)
+			if f == nil {
+				if !ignoredFields[key] {
+					unknown[path+key] = true
+				}
+				continue
+			}
+			seen[f.name] = true
+			walkSchema(f.typ, val, path+key+".", unknown, missing)
+		}
+		for _, f := range fields {
+			if !f.omitempty && !seen[f.name] {
+				missing[path+f.name] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := raw.([]interface{})
+		if !ok {
+			return
+		}
+		for _, val := range list {
+			walkSchema(t.Elem(), val, strings.TrimSuffix(path, ".")+"[].", unknown, missing)
+		}
+	}
+}
+
+// schemaFields returns the JSON-encoded fields of struct type t, including
+// those promoted from embedded structs.
+func schemaFields(t reflect.Type) []*schemaField {
+	var fields []*schemaField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		ft := sf.Type
+		if sf.Anonymous && name == "" {
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, schemaFields(ft)...)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, &schemaField{
+			name:      name,
+			omitempty: strings.Contains(opts, "omitempty"),
+			typ:       ft,
+		})
+	}
+	return fields
+}
+
+// matchField finds the field for a JSON key, preferring an exact match over
+// a case-insensitive one as encoding/json does.
+func matchField(fields []*schemaField, key string) *schemaField {
+	for _, f := range fields {
+		if f.name == key {
+			return f
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestCheckSchema will test that fields the response structs don't know
+// about, and expected fields the response lacks, are both reported.
+func TestCheckSchema(t *testing.T) {
+	body := []byte(`{
+		"object": "list",
+		"url": "/v1/charges",
+		"has_more": false,
+		"data": [{
+			"id": "ch_1",
+			"object": "charge",
+			"amount": 400,
+			"currency": "usd",
+			"created": 1400000000,
+			"paid": true,
+			"livemode": false,
+			"balance_transaction": "txn_1",
+			"card": null,
+			"outcome": {"type": "authorized"}
+		}]
+	}`)
+	res := struct {
+		ListObject
+		Data []*Charge
+	}{}
+
+	err := checkSchema(body, &res)
+	if err == nil {
+		t.Errorf("Expected SchemaError, got nil")
+		return
+	}
+	if want := []string{"data[].outcome"}; !reflect.DeepEqual(err.Unknown, want) {
+		t.Errorf("Expected Unknown %v, got %v", want, err.Unknown)
+	}
+	if want := []string{"total_count"}; !reflect.DeepEqual(err.Missing, want) {
+		t.Errorf("Expected Missing %v, got %v", want, err.Missing)
+	}
+
+	// a response that matches its struct exactly should not be reported
+	if err := checkSchema([]byte(`{"id": "ba_1", "deleted": true}`), &DeleteResp{}); err != nil {
+		t.Errorf("Expected no SchemaError, got %s", err)
+	}
+}
+
+// TestStrictLog will test that in StrictLog mode the SchemaError of a
+// mismatched response is passed to the SchemaErrorHandler, or else logged to
+// the Logger, and that the response is still decoded.
+func TestStrictLog(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_1")
+		fmt.Fprint(w, `{"id": "SPRING", "deleted": true, "livemode": false}`)
+	})
+
+	var logs []*RequestLog
+	c.StrictMode = StrictLog
+	c.Logger = LoggerFunc(func(l *RequestLog) { logs = append(logs, l) })
+	ctx := context.Background()
+	if ok, err := c.Coupons.Delete(ctx, "SPRING"); err != nil || !ok {
+		t.Fatalf("Expected Coupon deleted, got %v %v", ok, err)
+	}
+	// one log for the request, and one for its SchemaError
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 logs, got %d", len(logs))
+	}
+	if err, ok := logs[1].Err.(*SchemaError); !ok || !reflect.DeepEqual(err.Unknown, []string{"livemode"}) || logs[1].RequestID != "req_1" {
+		t.Errorf("Expected SchemaError with unknown livemode for req_1, got %+v", logs[1])
+	}
+
+	var errs []*SchemaError
+	logs = nil
+	c.SchemaErrorHandler = func(err *SchemaError) { errs = append(errs, err) }
+	if ok, err := c.Coupons.Delete(ctx, "SPRING"); err != nil || !ok {
+		t.Fatalf("Expected Coupon deleted, got %v %v", ok, err)
+	}
+	if len(errs) != 1 || !reflect.DeepEqual(errs[0].Unknown, []string{"livemode"}) {
+		t.Errorf("Expected SchemaError with unknown livemode, got %v", errs)
+	}
+	if len(logs) != 1 {
+		t.Errorf("Expected only the request logged, got %d logs", len(logs))
+	}
+}
@@ -1,76 +1,227 @@
 package stripe
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
 	"strings"
+	"time"
 )
 
-// enable logging to print the request and reponses to stdout
-var _log bool
-
-// the API Key used to authenticate all Stripe API requests
-var _key string
-
-// the default URL for all Stripe API requests
-var _url string = "https://api.stripe.com"
+// the client used by the package-level APIs and setters
+var _default = New("")
 
+// the API version requests are made with, unless overridden
 const apiVersion = "2014-03-28"
 
 // SetUrl will override the default Stripe API URL. This is primarily used
 // for unit testing.
 func SetUrl(url string) {
-	_url = url
+	_default.URL = url
+}
+
+// SetUploadUrl will override the default Stripe file upload URL. This is
+// primarily used for unit testing.
+func SetUploadUrl(url string) {
+	_default.UploadURL = url
+}
+
+// SetHTTPClient sets the http.Client used to submit requests, ie to set
+// timeouts or use a custom http.RoundTripper. A nil client, the default, uses
+// http.DefaultClient.
+func SetHTTPClient(client *http.Client) {
+	_default.HTTPClient = client
+}
+
+// SetAppInfo identifies the application or plugin making requests, which
+// Stripe asks plugin authors to do. The name, version and URL of the
+// application are sent in the User-Agent and X-Stripe-Client-User-Agent
+// headers along with those of this library. The version and url may be empty.
+//
+// see https://stripe.com/docs/building-plugins
+func SetAppInfo(name, version, url string) {
+	_default.AppInfo = &AppInfo{Name: name, Version: version, URL: url}
+}
+
+// SetAPIVersion sets the API version requests are made with, sent as the
+// Stripe-Version header, in place of the version the account is pinned to on
+// Stripe. Responses in other versions are converted to the types of this
+// package where possible. Default is 2014-03-28.
+//
+// see https://stripe.com/docs/upgrades
+func SetAPIVersion(version string) {
+	_default.APIVersion = version
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests. A nil policy,
+// the default, disables retries. While retries are enabled, POST requests made
+// without an idempotency key are sent with a randomly generated one.
+func SetRetryPolicy(p RetryPolicy) {
+	_default.RetryPolicy = p
+}
+
+// SetCoalescing enables or disables coalescing. When enabled, identical GET
+// requests that are made while one is already in flight do not hit the API;
+// they wait for and share the response of the request in flight instead.
+// This is useful for hot paths that retrieve the same Plan or Customer many
+// times per second.
+func SetCoalescing(enabled bool) {
+	_default.Coalescing = enabled
+}
+
+// SetMaxResponseSize sets the maximum size, in bytes, of a response body.
+// Larger responses are abandoned with a ResponseTooLargeError rather than
+// read into memory. Zero, the default, means no limit.
+func SetMaxResponseSize(n int64) {
+	_default.MaxResponseSize = n
+}
+
+// AddRequestInterceptor adds a function that is called with every request
+// before it is submitted, ie to add tracing headers. A request for which an
+// interceptor returns an error is not submitted, and fails with that error.
+func AddRequestInterceptor(f RequestInterceptor) {
+	_default.RequestInterceptors = append(_default.RequestInterceptors, f)
+}
+
+// AddResponseInterceptor adds a function that is called with every response
+// before its body is read, ie to record metrics. A response for which an
+// interceptor returns an error is discarded, and the request fails with that
+// error.
+func AddResponseInterceptor(f ResponseInterceptor) {
+	_default.ResponseInterceptors = append(_default.ResponseInterceptors, f)
+}
+
+// SetLogger sets the Logger that receives a RequestLog for every request. A
+// nil Logger, the default, disables logging.
+func SetLogger(l Logger) {
+	_default.Logger = l
+}
+
+// SetObserver sets the RequestObserver that is notified of every request. A
+// nil RequestObserver, the default, disables observation.
+func SetObserver(o RequestObserver) {
+	_default.Observer = o
+}
+
+// SetRateLimitWait sets the longest a request waits, in total, for rate
+// limiting to clear. A request that receives a 429 response is retried after
+// the delay in its Retry-After header, or after one second if there is none,
+// while the total wait remains within d. Zero, the default, means 429
+// responses are returned immediately as a RateLimitError.
+func SetRateLimitWait(d time.Duration) {
+	_default.RateLimitWait = d
+}
+
+// SetTimeout sets how long a request may take in total, including any
+// retries, after which it is canceled. A shorter deadline on the context of
+// the request takes precedence. Zero, the default, means no timeout.
+func SetTimeout(d time.Duration) {
+	_default.Timeout = d
+}
+
+// SetResponseHeaderTimeout sets how long to wait for the headers of a
+// response after submitting a request, after which the request is abandoned
+// with a SlowResponseError. Zero, the default, means no timeout.
+func SetResponseHeaderTimeout(d time.Duration) {
+	_default.ResponseHeaderTimeout = d
 }
 
 // SetKey will set the default Stripe API key used to authenticate all Stripe
 // API requests.
 func SetKey(key string) {
-	_key = key
+	_default.Key = key
 }
 
-// Available APIs
+// Available APIs, using the default client configured by the package-level
+// setters.
 var (
-	Charges       = new(ChargeClient)
-	Coupons       = new(CouponClient)
-	Customers     = new(CustomerClient)
-	Invoices      = new(InvoiceClient)
-	InvoiceItems  = new(InvoiceItemClient)
-	Plans         = new(PlanClient)
-	Subscriptions = new(SubscriptionClient)
-	Tokens        = new(TokenClient)
-	Cards         = new(CardClient)
+	Accounts                    = _default.Accounts
+	AccountLinks                = _default.AccountLinks
+	ApplicationFees             = _default.ApplicationFees
+	Balances                    = _default.Balances
+	BankAccounts                = _default.BankAccounts
+	BalanceTransactions         = _default.BalanceTransactions
+	Capabilities                = _default.Capabilities
+	Charges                     = _default.Charges
+	Coupons                     = _default.Coupons
+	Customers                   = _default.Customers
+	CustomerBalanceTransactions = _default.CustomerBalanceTransactions
+	Disputes                    = _default.Disputes
+	Events                      = _default.Events
+	FeeRefunds                  = _default.FeeRefunds
+	Files                       = _default.Files
+	FileLinks                   = _default.FileLinks
+	Invoices                    = _default.Invoices
+	InvoiceItems                = _default.InvoiceItems
+	Payouts                     = _default.Payouts
+	Persons                     = _default.Persons
+	Plans                       = _default.Plans
+	Prices                      = _default.Prices
+	Products                    = _default.Products
+	PromotionCodes              = _default.PromotionCodes
+	Recipients                  = _default.Recipients
+	Refunds                     = _default.Refunds
+	SourceTransactions          = _default.SourceTransactions
+	Subscriptions               = _default.Subscriptions
+	SubscriptionItems           = _default.SubscriptionItems
+	SubscriptionSchedules       = _default.SubscriptionSchedules
+	TaxCalculations             = _default.TaxCalculations
+	TaxIDs                      = _default.TaxIDs
+	TaxRates                    = _default.TaxRates
+	TaxTransactions             = _default.TaxTransactions
+	Tokens                      = _default.Tokens
+	Transfers                   = _default.Transfers
+	TransferReversals           = _default.TransferReversals
+	UsageRecords                = _default.UsageRecords
+	Cards                       = _default.Cards
+	ExternalAccounts            = _default.ExternalAccounts
+	WebhookEndpoints            = _default.WebhookEndpoints
 )
 
 // SetKeyEnv retrieves the Stripe API key using the STRIPE_API_KEY environment
 // variable.
 func SetKeyEnv() (err error) {
-	_key = os.Getenv("STRIPE_API_KEY")
-	if _key == "" {
+	_default.Key = os.Getenv("STRIPE_API_KEY")
+	if _default.Key == "" {
 		err = errors.New("STRIPE_API_KEY not found in environment")
 	}
 	return
 }
 
 // query submits an http.Request and parses the JSON-encoded http.Response,
-// storing the result in the value pointed to by v.
-func query(method, path string, values url.Values, v interface{}) error {
+// storing the result in the value pointed to by v. The request is canceled
+// when ctx is done.
+func (c *Client) query(ctx context.Context, method, path string, values url.Values, v interface{}) error {
 	// parse the stripe URL
-	endpoint, err := url.Parse(_url)
+	endpoint, err := url.Parse(c.URL)
 	if err != nil {
 		return err
 	}
 
 	// set the endpoint for the specific API
 	endpoint.Path = "/v1" + path
-	endpoint.User = url.User(_key)
+
+	// add the fields to expand, if any, without changing the values of the
+	// caller
+	if fields, ok := ctx.Value(expandKey{}).([]string); ok && len(fields) > 0 {
+		expanded := make(url.Values, len(values)+1)
+		for k, v := range values {
+			expanded[k] = v
+		}
+		expanded["expand[]"] = append(append([]string(nil), values["expand[]"]...), fields...)
+		values = expanded
+	}
 
 	// if this is an http GET, add the url.Values to the endpoint
 	if method == "GET" {
@@ -83,58 +234,390 @@ func query(method, path string, values url.Values, v interface{}) error {
 		reqBody = strings.NewReader(values.Encode())
 	}
 
-	// Log request if logging enabled
-	if _log {
-		fmt.Println("REQUEST: ", method, endpoint.String())
-		fmt.Println(values.Encode())
-	}
-
 	// create the request
-	req, err := http.NewRequest(method, endpoint.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reqBody)
 	if err != nil {
 		return err
 	}
+	// generate an idempotency key if none was given, so that a POST can be
+	// retried without risk of being applied twice
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	} else if method == "POST" && c.RetryPolicy != nil {
+		if key, err = generateIdempotencyKey(); err != nil {
+			return err
+		}
+		req.Header.Set("Idempotency-Key", key)
+	}
 
-	req.Header.Set("Stripe-Version", apiVersion)
+	return c.send(req, v)
+}
 
-	// submit the http request
-	r, err := http.DefaultClient.Do(req)
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that sends key as the
+// Idempotency-Key of requests made with it, so that a retried request is not
+// applied twice.
+//
+// see https://stripe.com/docs/api#idempotent_requests
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+type apiVersionKey struct{}
+
+// WithAPIVersion returns a copy of ctx that makes requests made with it in
+// the given API version, overriding the version of the Client.
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// version returns the API version of requests made with ctx, which is the
+// version given to WithAPIVersion, if any, or else that of the Client.
+func (c *Client) version(ctx context.Context) string {
+	if v, ok := ctx.Value(apiVersionKey{}).(string); ok && v != "" {
+		return v
+	}
+	if c.APIVersion != "" {
+		return c.APIVersion
+	}
+	return apiVersion
+}
+
+type expandKey struct{}
+
+// WithExpand returns a copy of ctx that asks for the given fields of objects
+// returned by requests made with it to be expanded, ie customer to return
+// the full Customer of a Charge in place of its ID. The fields of the objects
+// in a list are prefixed with data, ie data.customer.
+//
+// see https://stripe.com/docs/api#expanding_objects
+func WithExpand(ctx context.Context, fields ...string) context.Context {
+	return context.WithValue(ctx, expandKey{}, fields)
+}
+
+type stripeAccountKey struct{}
+
+// WithStripeAccount returns a copy of ctx that makes requests made with it on
+// behalf of the connected account with the given ID, overriding the
+// StripeAccount of the Client.
+//
+// see https://stripe.com/docs/connect/authentication
+func WithStripeAccount(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, stripeAccountKey{}, accountID)
+}
+
+// generateIdempotencyKey returns a random key for a request made without one.
+func generateIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// deriveIdempotencyKey returns a key for the request identified by parts,
+// prefixed with prefix, ie customer-<hash>, so that repeating the request
+// sends the same key.
+func deriveIdempotencyKey(prefix string, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return prefix + "-" + hex.EncodeToString(sum[:])
+}
+
+// upload submits a multipart/form-data request containing the given fields
+// and file to the Stripe file upload API, storing the result in the value
+// pointed to by v.
+func (c *Client) upload(ctx context.Context, path string, fields url.Values, filename string, file io.Reader, v interface{}) error {
+	if file == nil {
+		return fmt.Errorf("stripe: file %q has no contents", filename)
+	}
+
+	// parse the stripe upload URL
+	endpoint, err := url.Parse(c.UploadURL)
 	if err != nil {
 		return err
 	}
+	endpoint.Path = "/v1" + path
 
-	// read the body of the http message into a byte array
-	body, err := ioutil.ReadAll(r.Body)
-	defer r.Body.Close()
+	// write the fields, followed by the file contents
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for k, vals := range fields {
+		for _, val := range vals {
+			if err := w.WriteField(k, val); err != nil {
+				return err
+			}
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
 	if err != nil {
 		return err
 	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return c.send(req, v)
+}
+
+// send submits an http.Request and parses the JSON-encoded http.Response,
+// storing the result in the value pointed to by v. Failed requests are
+// retried according to the retry policy, if one is set, and identical GET
+// requests are coalesced if enabled.
+func (c *Client) send(req *http.Request, v interface{}) error {
+	// bound the request, including any retries, by the timeout if one is set
+	if c.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	// authenticate with a header, rather than in the URL, so that the key is
+	// not written to proxy logs or included in the errors of a failed request
+	req.Header.Set("Authorization", "Bearer "+c.Key)
+	req.Header.Set("Stripe-Version", c.version(req.Context()))
+	req.Header.Set("User-Agent", c.AppInfo.userAgent())
+	req.Header.Set("X-Stripe-Client-User-Agent", c.AppInfo.clientUserAgent())
+
+	// make the request on behalf of a connected account, if one is given
+	account := c.StripeAccount
+	if a, ok := req.Context().Value(stripeAccountKey{}).(string); ok && a != "" {
+		account = a
+	}
+	if account != "" {
+		req.Header.Set("Stripe-Account", account)
+	}
 
-	// Log response if logging enabled
-	if _log {
-		fmt.Println("RESPONSE: ", r.StatusCode)
-		fmt.Println(string(body))
+	var resp *Response
+	var body []byte
+	var err error
+	if c.Coalescing && req.Method == "GET" {
+		// the shared request is made on behalf of every caller, so it is not
+		// canceled with the first one, only bounded by the timeout
+		resp, body, err = c.inflight.do(req.Context(), coalesceKey(req), func() (*Response, []byte, error) {
+			ctx := context.WithoutCancel(req.Context())
+			if c.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+				defer cancel()
+			}
+			return c.retry(req.WithContext(ctx))
+		})
+	} else {
+		resp, body, err = c.retry(req)
+	}
+	if err != nil {
+		return err
 	}
 
 	// is this an error?
-	if r.StatusCode != 200 {
-		error := Error{}
-		json.Unmarshal(body, &error)
-		return &error
+	if resp.StatusCode != 200 {
+		return newError(resp, body)
+	}
+
+	// parse the JSON response, in the shape of the package's API version, into
+	// the response object
+	body = normalize(body, req.Header.Get("Stripe-Version"))
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+
+	// check the response against the response object, if enabled
+	if c.StrictMode != StrictOff {
+		if err := checkSchema(body, v); err != nil {
+			if c.StrictMode == StrictError {
+				return err
+			}
+			if c.SchemaErrorHandler != nil {
+				c.SchemaErrorHandler(err)
+			} else if c.Logger != nil {
+				c.Logger.LogRequest(newRequestLog(req, resp, 0, err))
+			}
+		}
+	}
+	setClient(v, c)
+	setLastResponse(v, resp)
+	return nil
+}
+
+// retry submits an http.Request until it succeeds, or the retry policy gives
+// up, returning the Response and body of the last http.Response. Only
+// idempotent requests, and requests with an idempotency key, are retried,
+// except after a 429 response, which waits within the rate limit wait.
+func (c *Client) retry(req *http.Request) (*Response, []byte, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, body, err := c.roundTrip(req)
+		status := resp.status()
+
+		// a rate limited request was not processed, so it is safe to retry
+		// regardless of its method
+		var wait time.Duration
+		var ok bool
+		if rerr, limited := err.(*RateLimitError); limited {
+			wait = rerr.RetryAfter
+			if wait == 0 {
+				wait = time.Second
+			}
+			if time.Since(start)+wait > c.RateLimitWait {
+				return resp, body, err
+			}
+		} else {
+			if c.RetryPolicy == nil || !idempotent(req) || (err == nil && status == 200) {
+				return resp, body, err
+			}
+			if wait, ok = c.RetryPolicy.Retry(attempt, time.Since(start), status, err); !ok {
+				return resp, body, err
+			}
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, body, err
+		}
+
+		// rewind the request body for the next attempt
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, nil, err
+			}
+		}
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, nil, req.Context().Err()
+		}
 	}
+}
 
-	//parse the JSON response into the response object
-	return json.Unmarshal(body, v)
+// idempotent reports whether an http.Request can safely be submitted more
+// than once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "DELETE":
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// roundTrip submits an http.Request, returning the Response and body of the
+// http.Response, or a nil Response if none was received. The response is
+// subject to the configured header timeout and maximum size, if any.
+func (c *Client) roundTrip(req *http.Request) (resp *Response, body []byte, err error) {
+	// log and observe the request once it completes, if enabled
+	start := time.Now()
+	if c.Logger != nil {
+		defer func() {
+			c.Logger.LogRequest(newRequestLog(req, resp, time.Since(start), err))
+		}()
+	}
+	if c.Observer != nil {
+		defer func() {
+			c.Observer.ObserveRequest(req.Method, req.URL.Path, resp.status(), time.Since(start))
+		}()
+	}
+
+	// abort the request if the response headers take too long to arrive
+	var timer *time.Timer
+	if c.ResponseHeaderTimeout > 0 {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		timer = time.AfterFunc(c.ResponseHeaderTimeout, cancel)
+		defer timer.Stop()
+		req = req.WithContext(ctx)
+	}
+
+	for _, f := range c.RequestInterceptors {
+		if err := f(req); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// submit the http request
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r, err := client.Do(req)
+
+	// the headers have arrived, so stop the timer before the body is read,
+	// which may take longer. If it already fired the request was canceled.
+	if timer != nil && !timer.Stop() {
+		if err == nil {
+			r.Body.Close()
+		}
+		return nil, nil, &SlowResponseError{Timeout: c.ResponseHeaderTimeout}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Body.Close()
+	resp = &Response{
+		StatusCode: r.StatusCode,
+		RequestID:  r.Header.Get("Request-Id"),
+		Header:     r.Header,
+	}
+	for _, f := range c.ResponseInterceptors {
+		if err := f(r); err != nil {
+			return resp, nil, err
+		}
+	}
+
+	// read the body of the http message into a byte array
+	body, err = readBody(r, c.MaxResponseSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.StatusCode == http.StatusTooManyRequests {
+		return resp, body, rateLimitError(resp, body)
+	}
+	return resp, body, nil
+}
+
+// readBody reads the body of an http.Response, failing if it is larger than
+// max bytes. A max of zero means no limit.
+func readBody(r *http.Response, max int64) ([]byte, error) {
+	if max <= 0 {
+		return ioutil.ReadAll(r.Body)
+	}
+	if r.ContentLength > max {
+		return nil, &ResponseTooLargeError{Limit: max}
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > max {
+		return nil, &ResponseTooLargeError{Limit: max}
+	}
+	return body, nil
 }
 
 // Error encapsulates an error returned by the Stripe REST API.
 type Error struct {
-	Code   int
+	Code int
+
+	// The response the error was decoded from.
+	LastResponse *Response `json:"-"`
+
+	// The raw body of the response, truncated to maxErrorBody bytes, when it
+	// was not a Stripe error object (ie an HTML page from a proxy).
+	Body string `json:"-"`
+
 	Detail struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-		Param   string `json:"param"`
-		Type    string `json:"type"`
+		Code        ErrorCode   `json:"code"`
+		DeclineCode DeclineCode `json:"decline_code"`
+		Message     string      `json:"message"`
+		Param       string      `json:"param"`
+		Type        ErrorType   `json:"type"`
 	} `json:"error"`
 }
 
@@ -142,6 +625,34 @@ func (e *Error) Error() string {
 	return e.Detail.Message
 }
 
+// maxErrorBody is the most of an unparseable error body kept in an Error.
+const maxErrorBody = 512
+
+// newError returns the Error for a failed Response with the given body. If
+// the body is not a Stripe error object, as when a proxy or load balancer
+// fails with an HTML page or an empty body, the message of the Error names
+// the HTTP status and includes the start of the raw body instead.
+func newError(r *Response, body []byte) *Error {
+	e := &Error{Code: r.StatusCode, LastResponse: r}
+	if err := json.Unmarshal(body, e); err == nil && (e.Detail.Type != "" || e.Detail.Message != "") {
+		return e
+	}
+
+	raw := strings.TrimSpace(string(body))
+	if len(raw) > maxErrorBody {
+		raw = raw[:maxErrorBody] + "..."
+	}
+	e.Body = raw
+	if raw == "" {
+		e.Detail.Message = fmt.Sprintf("stripe: unexpected %d %s response with an empty body",
+			r.StatusCode, http.StatusText(r.StatusCode))
+	} else {
+		e.Detail.Message = fmt.Sprintf("stripe: unexpected %d %s response: %s",
+			r.StatusCode, http.StatusText(r.StatusCode), raw)
+	}
+	return e
+}
+
 // Response to a Deletion request.
 type DeleteResp struct {
 	// ID of the Object that was deleted
@@ -150,22 +661,52 @@ type DeleteResp struct {
 	Deleted bool `json:"deleted"`
 }
 
-func appendMetadata(values url.Values, meta map[string]string) {
-	for k, v := range meta {
-		values.Add(fmt.Sprintf("metadata[%s]", k), v)
-	}
+func listParams(limit int, before, after string) url.Values {
+	return formValues(&ListParams{Limit: limit, EndingBefore: before, StartingAfter: after})
 }
 
-func listParams(limit int, before, after string) url.Values {
-	params := make(url.Values)
-	if limit > 0 {
-		params.Add("limit", strconv.Itoa(limit))
-	}
-	if before != "" {
-		params.Add("ending_before", before)
-	}
-	if after != "" {
-		params.Add("starting_after", after)
-	}
-	return params
+// ListParams encapsulates the pagination options shared by all list requests.
+type ListParams struct {
+	// (Optional) A limit on the number of objects to be returned. Limit can
+	// range between 1 and 100 items.
+	Limit int `form:"limit"`
+
+	// (Optional) A cursor for use in pagination. EndingBefore is an object ID
+	// that defines your place in the list, returning the objects before it.
+	EndingBefore string `form:"ending_before"`
+
+	// (Optional) A cursor for use in pagination. StartingAfter is an object
+	// ID that defines your place in the list, returning the objects after it.
+	StartingAfter string `form:"starting_after"`
+
+	// (Optional) The fields of each object in the list to expand into full
+	// objects, ie data.customer, see WithExpand.
+	Expand []string `form:"expand"`
+}
+
+// DateRange filters list results on a timestamp, such as the time an object
+// was created. Bounds that are left nil are not applied.
+type DateRange struct {
+	// Return results where the timestamp is after this time.
+	GT *UnixTime `form:"gt"`
+
+	// Return results where the timestamp is after or equal to this time.
+	GTE *UnixTime `form:"gte"`
+
+	// Return results where the timestamp is before this time.
+	LT *UnixTime `form:"lt"`
+
+	// Return results where the timestamp is before or equal to this time.
+	LTE *UnixTime `form:"lte"`
+}
+
+// Since returns a DateRange matching everything at or after t.
+func Since(t time.Time) *DateRange {
+	return &DateRange{GTE: &UnixTime{t}}
+}
+
+// Between returns a DateRange matching everything at or after start and
+// before end.
+func Between(start, end time.Time) *DateRange {
+	return &DateRange{GTE: &UnixTime{start}, LT: &UnixTime{end}}
 }
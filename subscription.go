@@ -1,9 +1,10 @@
 package stripe
 
 import (
+	"context"
 	"fmt"
 	"net/url"
-	"strconv"
+	"time"
 )
 
 // Subscription Statuses
@@ -19,121 +20,327 @@ const (
 //
 // see https://stripe.com/docs/api#subscription_object
 type Subscription struct {
-	ID                 string    `json:"id"`
-	Customer           string    `json:"customer"`
-	Status             string    `json:"status"`
-	Plan               *Plan     `json:"plan"`
-	Start              UnixTime  `json:"start"`
-	EndedAt            *UnixTime `json:"ended_at,omitempty"`
-	CurrentPeriodStart UnixTime  `json:"current_period_start"`
-	CurrentPeriodEnd   UnixTime  `json:"current_period_end"`
-	TrialStart         *UnixTime `json:"trial_start,omitempty"`
-	TrialEnd           *UnixTime `json:"trial_end,omitempty"`
-	CanceledAt         *UnixTime `json:"canceled_at,omitempty"`
-	CancelAtPeriodEnd  bool      `json:"cancel_at_period_end"`
-	Quantity           int       `json:"quantity"`
-	Discount           *Discount `json:"discount,omitempty"`
-}
-
-// SubscriptionClient encapsulates operations for updating and canceling
-// customer subscriptions using the Stripe REST API.
-type SubscriptionClient struct{}
+	APIResource
+	ID                 string            `json:"id"`
+	Customer           string            `json:"customer"`
+	Status             string            `json:"status"`
+	Plan               *Plan             `json:"plan"`
+	Start              UnixTime          `json:"start"`
+	EndedAt            *UnixTime         `json:"ended_at,omitempty"`
+	CurrentPeriodStart UnixTime          `json:"current_period_start"`
+	CurrentPeriodEnd   UnixTime          `json:"current_period_end"`
+	TrialStart         *UnixTime         `json:"trial_start,omitempty"`
+	TrialEnd           *UnixTime         `json:"trial_end,omitempty"`
+	CanceledAt         *UnixTime         `json:"canceled_at,omitempty"`
+	CancelAtPeriodEnd  bool              `json:"cancel_at_period_end"`
+	Quantity           int               `json:"quantity"`
+	Discount           *Discount         `json:"discount,omitempty"`
+	DefaultTaxRates    []*TaxRate        `json:"default_tax_rates,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+
+	// Items is only set in API versions that support multiple plans per
+	// subscription. In older versions, Plan and Quantity describe the only
+	// item.
+	Items *List[SubscriptionItem] `json:"items,omitempty"`
+}
+
+// SubscriptionClient encapsulates operations for creating, updating, querying
+// and canceling customer subscriptions using the Stripe REST API.
+//
+// Subscriptions are addressed under the Customer they belong to, ie
+// /customers/{id}/subscriptions. When an empty customer ID is given, the
+// top-level /subscriptions endpoints are used instead, in which case the
+// customer to subscribe is set with SubscriptionParams.Customer.
+type SubscriptionClient struct{ api }
 
 // SubscriptionParams encapsulates options for updating a Customer's
 // subscription.
 type SubscriptionParams struct {
-	// The identifier of the plan to subscribe the customer to.
-	Plan string
+	// (Optional) The identifier of the customer to subscribe. Only used when
+	// creating a Subscription with the top-level /subscriptions endpoint.
+	Customer string `form:"customer"`
+
+	// The identifier of the plan to subscribe the customer to, unless Items
+	// is set.
+	Plan string `form:"plan"`
+
+	// (Optional) The plans, and their quantities, to subscribe the customer
+	// to, in place of Plan and Quantity.
+	Items []*SubscriptionItemParams `form:"items"`
 
 	// (Optional) The code of the coupon to apply to the customer if you would
 	// like to apply it at the same time as creating the subscription.
-	Coupon string
+	Coupon string `form:"coupon"`
 
 	// (Optional) Flag telling us whether to prorate switching plans during a
 	// billing cycle. Default is true.
-	Prorate *bool
+	Prorate *bool `form:"prorate"`
+
+	// (Optional) The time at which switching plans is prorated. Default is
+	// the time of the request.
+	ProrationDate *UnixTime `form:"proration_date"`
 
 	// (Optional) UTC integer timestamp representing the end of the trial period
 	// the customer will get before being charged for the first time. If set,
 	// trial_end will override the default trial period of the plan the customer
 	// is being subscribed to.
-	TrialEnd *UnixTime
+	TrialEnd *UnixTime `form:"trial_end"`
+
+	// (Optional) End the trial period immediately, billing the customer
+	// now. Overrides TrialEnd.
+	EndTrialNow bool
 
 	// (Optional) A new card to attach to the customer.
-	Card *CardParams
+	Card *CardParams `form:"card"`
 
-	// (Optional) A new card Token to attach to the customer.
-	Token string
+	// (Optional) A new card Token to attach to the customer. Ignored if Card
+	// is set.
+	Token string `form:"-"`
 
 	// (Optional) The quantity you'd like to apply to the subscription you're creating.
-	Quantity int
+	Quantity int `form:"quantity"`
+
+	// (Optional) The IDs of the TaxRates applied to the invoices of the
+	// subscription, for items without their own TaxRates.
+	DefaultTaxRates []string `form:"default_tax_rates"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// SubscriptionListParams encapsulates options for filtering a list of
+// Subscriptions.
+type SubscriptionListParams struct {
+	ListParams
+
+	// (Optional) Only return subscriptions of the customer with this ID.
+	Customer string `form:"customer"`
+
+	// (Optional) Only return subscriptions to the plan with this ID.
+	Plan string `form:"plan"`
+
+	// (Optional) Only return subscriptions with this status, ie active or
+	// past_due, or all to include canceled subscriptions. Default is every
+	// status except canceled.
+	Status string `form:"status"`
 }
 
 func (c SubscriptionClient) path(customerID, subscriptionID string) string {
-	p := fmt.Sprintf("/customers/%s/subscriptions", url.QueryEscape(customerID))
+	p := "/subscriptions"
+	if customerID != "" {
+		p = fmt.Sprintf("/customers/%s/subscriptions", url.QueryEscape(customerID))
+	}
 	if subscriptionID != "" {
 		p += "/" + url.QueryEscape(subscriptionID)
 	}
 	return p
 }
 
-func (c SubscriptionClient) Create(customerID string, params *SubscriptionParams) (*Subscription, error) {
+// Subscribes a customer to a plan, as a new Subscription in addition to any
+// they already have.
+//
+// see https://stripe.com/docs/api#create_subscription
+func (c SubscriptionClient) Create(ctx context.Context, customerID string, params *SubscriptionParams) (*Subscription, error) {
 	res := &Subscription{}
-	return res, query("POST", c.path(customerID, ""), c.values(params), res)
+	return res, c.query(ctx, "POST", c.path(customerID, ""), subscriptionValues(params), res)
 }
 
-func (c SubscriptionClient) values(params *SubscriptionParams) url.Values {
-	values := make(url.Values)
-	if params.Plan != "" {
-		values.Add("plan", params.Plan)
+// Subscribes a customer to a new plan.
+//
+// see https://stripe.com/docs/api#update_subscription
+func (c SubscriptionClient) Update(ctx context.Context, customerID, subscriptionID string, params *SubscriptionParams) (*Subscription, error) {
+	res := &Subscription{}
+	return res, c.query(ctx, "POST", c.path(customerID, subscriptionID), subscriptionValues(params), res)
+}
+
+// SetQuantity changes the quantity of the plan of a Subscription, ie the
+// number of seats billed for, prorating the change. Unlike Update, a quantity
+// of zero is sent.
+//
+// see https://stripe.com/docs/api#update_subscription
+func (c SubscriptionClient) SetQuantity(ctx context.Context, customerID, subscriptionID string, quantity int) (*Subscription, error) {
+	values := formValues(struct {
+		Quantity int `form:"quantity,always"`
+	}{quantity})
+
+	res := &Subscription{}
+	return res, c.query(ctx, "POST", c.path(customerID, subscriptionID), values, res)
+}
+
+// ChangePlanOptions encapsulates options for switching a subscription to a
+// new plan with ChangePlan.
+type ChangePlanOptions struct {
+	// (Optional) Whether to prorate the change. Default is true.
+	Prorate *bool
+
+	// (Optional) The quantity to apply to the new plan. Default is the
+	// current quantity.
+	Quantity int
+
+	// (Optional) Whether to invoice and pay for the proration immediately,
+	// rather than adding it to the next invoice.
+	InvoiceNow bool
+}
+
+// PlanChange describes the result of ChangePlan.
+type PlanChange struct {
+	// The upcoming invoice as previewed before the change was made.
+	Preview *Invoice
+
+	// The subscription after the change.
+	Subscription *Subscription
+
+	// The invoice for the proration, if it was invoiced immediately.
+	Invoice *Invoice
+}
+
+// ChangePlan upgrades or downgrades a subscription to the given plan. The
+// upcoming invoice is previewed first and then the change is made with the
+// same proration date, so the preview reflects what the customer is billed.
+//
+// The customerID may be empty, in which case the subscription is retrieved
+// first to find the customer it belongs to.
+//
+// If the proration is invoiced immediately and payment fails, the returned
+// PlanChange includes the unpaid Invoice along with the error, and the
+// subscription remains on the new plan. The caller may retry payment with
+// Invoices.Pay. PaymentIntents are not wrapped by this package yet, so a
+// payment that requires authentication fails like a declined card; the
+// customer must then pay the open Invoice themselves, ie from its hosted
+// invoice page.
+func (c SubscriptionClient) ChangePlan(ctx context.Context, customerID, subscriptionID, plan string, opts *ChangePlanOptions) (*PlanChange, error) {
+	if opts == nil {
+		opts = &ChangePlanOptions{}
 	}
-	if params.Coupon != "" {
-		values.Add("coupon", params.Coupon)
+	if customerID == "" {
+		sub, err := c.Get(ctx, "", subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		customerID = sub.Customer
 	}
-	if params.Prorate != nil && !*params.Prorate {
-		values.Add("prorate", "false")
+	date := UnixTime{time.Now()}
+	res := &PlanChange{Preview: &Invoice{}}
+
+	// preview the change
+	preview := &UpcomingInvoiceParams{
+		Subscription:  subscriptionID,
+		Plan:          plan,
+		Quantity:      opts.Quantity,
+		Prorate:       opts.Prorate,
+		ProrationDate: &date,
 	}
-	if params.TrialEnd != nil {
-		values.Add("trial_end", strconv.FormatInt(params.TrialEnd.Unix(), 10))
+	if err := c.query(ctx, "GET", "/invoices/upcoming", upcomingValues(customerID, preview), res.Preview); err != nil {
+		return nil, err
 	}
-	if params.Quantity != 0 {
-		values.Add("quantity", strconv.Itoa(params.Quantity))
+
+	// make the change
+	params := &SubscriptionParams{
+		Plan:          plan,
+		Quantity:      opts.Quantity,
+		Prorate:       opts.Prorate,
+		ProrationDate: &date,
 	}
-	if params.Token != "" {
-		values.Add("card", params.Token)
-	} else if params.Card != nil {
-		appendCardParams(values, true, params.Card)
+	res.Subscription = &Subscription{}
+	if err := c.query(ctx, "POST", c.path(customerID, subscriptionID), formValues(params), res.Subscription); err != nil {
+		res.Subscription = nil
+		return res, err
+	}
+	if !opts.InvoiceNow || (opts.Prorate != nil && !*opts.Prorate) {
+		return res, nil
 	}
-	return values
-}
 
-// Subscribes a customer to a new plan.
-//
-// see https://stripe.com/docs/api#update_subscription
-func (c SubscriptionClient) Update(customerID, subscriptionID string, params *SubscriptionParams) (*Subscription, error) {
-	res := &Subscription{}
-	return res, query("POST", c.path(customerID, subscriptionID), c.values(params), res)
+	// invoice and pay for the proration
+	inv := &Invoice{}
+	values := formValues(&InvoiceParams{Customer: customerID, Subscription: subscriptionID})
+	if err := c.query(ctx, "POST", "/invoices", values, inv); err != nil {
+		return res, err
+	}
+	res.Invoice = inv
+	if inv.Paid || inv.AmountDue <= 0 {
+		return res, nil
+	}
+	paid := &Invoice{}
+	if err := c.query(ctx, "POST", fmt.Sprintf("/invoices/%s/pay", url.QueryEscape(inv.ID)), nil, paid); err != nil {
+		return res, err
+	}
+	res.Invoice = paid
+	return res, nil
 }
 
-func (c SubscriptionClient) Cancel(customerID, subscriptionID string, atPeriodEnd bool) (*Subscription, error) {
+// Cancels a Subscription, either immediately or at the end of the current
+// period.
+//
+// see https://stripe.com/docs/api#cancel_subscription
+func (c SubscriptionClient) Cancel(ctx context.Context, customerID, subscriptionID string, atPeriodEnd bool) (*Subscription, error) {
 	values := make(url.Values)
 	if atPeriodEnd {
 		values.Add("at_period_end", "true")
 	}
 	res := &Subscription{}
-	return res, query("DELETE", c.path(customerID, subscriptionID), values, res)
+	return res, c.query(ctx, "DELETE", c.path(customerID, subscriptionID), values, res)
 }
 
-func (c SubscriptionClient) Get(customerID, subscriptionID string) (*Subscription, error) {
+// DeleteDiscount removes the Discount applied to the Subscription with the
+// given ID.
+//
+// see https://stripe.com/docs/api#delete_subscription_discount
+func (c SubscriptionClient) DeleteDiscount(ctx context.Context, customerID, subscriptionID string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", c.path(customerID, subscriptionID)+"/discount", nil, res)
+	return res.Deleted, err
+}
+
+// Retrieves the Subscription with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_subscription
+func (c SubscriptionClient) Get(ctx context.Context, customerID, subscriptionID string) (*Subscription, error) {
 	res := &Subscription{}
-	return res, query("GET", c.path(customerID, subscriptionID), nil, res)
+	return res, c.GetInto(ctx, customerID, subscriptionID, res)
 }
 
-func (c SubscriptionClient) List(customerID string, limit int, before, after string) ([]*Subscription, bool, error) {
+// GetInto is like Get, but decodes the Subscription into v, which may be any
+// type with matching JSON fields.
+func (c SubscriptionClient) GetInto(ctx context.Context, customerID, subscriptionID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(customerID, subscriptionID), nil, v)
+}
+
+// Returns a list of the Subscriptions of the given Customer ID.
+//
+// see https://stripe.com/docs/api#list_subscriptions
+func (c SubscriptionClient) List(ctx context.Context, customerID string, limit int, before, after string) ([]*Subscription, bool, error) {
 	res := struct {
 		ListObject
 		Data []*Subscription
 	}{}
-	err := query("GET", c.path(customerID, ""), listParams(limit, before, after), res)
+	err := c.query(ctx, "GET", c.path(customerID, ""), listParams(limit, before, after), &res)
 	return res.Data, res.More, err
 }
+
+// ListAll returns an Iter over every Subscription of the given Customer ID,
+// fetching pages as they are needed. The Limit of params, if any, sets the
+// size of each page.
+func (c SubscriptionClient) ListAll(ctx context.Context, customerID string, params *ListParams) *Iter[Subscription] {
+	return newIter(ctx, c.backend(), c.path(customerID, ""), params, func(s *Subscription) string { return s.ID })
+}
+
+// Returns a page of Subscriptions of any customer matching the given filters,
+// or all of your active Subscriptions when params is nil, along with the
+// metadata of the list.
+//
+// see https://stripe.com/docs/api#list_subscriptions
+func (c SubscriptionClient) ListFiltered(ctx context.Context, params *SubscriptionListParams) (*List[Subscription], error) {
+	res := &List[Subscription]{}
+	return res, c.query(ctx, "GET", "/subscriptions", formValues(params), res)
+}
+
+func subscriptionValues(params *SubscriptionParams) url.Values {
+	values := formValues(params)
+	if params != nil && params.EndTrialNow {
+		values.Set("trial_end", "now")
+	}
+	if params != nil && params.Card == nil && params.Token != "" {
+		values.Set("card", params.Token)
+	}
+	return values
+}
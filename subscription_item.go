@@ -0,0 +1,128 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// SubscriptionItem represents a plan, and its quantity, that a Subscription
+// bills for.
+//
+// see https://stripe.com/docs/api#subscription_items
+type SubscriptionItem struct {
+	APIResource
+	ID           string            `json:"id"`
+	Subscription string            `json:"subscription"`
+	Plan         *Plan             `json:"plan"`
+	Quantity     int               `json:"quantity"`
+	TaxRates     []*TaxRate        `json:"tax_rates,omitempty"`
+	Created      UnixTime          `json:"created"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// SubscriptionItemParams encapsulates options for an item of a
+// Subscription, either as one of SubscriptionParams.Items or with
+// SubscriptionItemClient.
+type SubscriptionItemParams struct {
+	// (Optional) The ID of an existing item to change, when updating a
+	// Subscription.
+	ID string `form:"id"`
+
+	// The identifier of the Subscription to add the item to. Only used when
+	// creating an item with SubscriptionItemClient.
+	Subscription string `form:"subscription"`
+
+	// The identifier of the plan of the item.
+	Plan string `form:"plan"`
+
+	// (Optional) The quantity of the plan. Default is 1.
+	Quantity int `form:"quantity"`
+
+	// (Optional) The IDs of the TaxRates applied to the item, in place of
+	// the default TaxRates of the Subscription.
+	TaxRates []string `form:"tax_rates"`
+
+	// (Optional) When updating a Subscription, remove the item with the
+	// given ID.
+	Deleted bool `form:"deleted"`
+
+	// (Optional) Whether to prorate the change. Default is true. Only used
+	// with SubscriptionItemClient.
+	Prorate *bool `form:"prorate"`
+
+	// (Optional) The time at which the change is prorated. Default is the
+	// time of the request. Only used with SubscriptionItemClient.
+	ProrationDate *UnixTime `form:"proration_date"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// SubscriptionItemClient encapsulates operations for adding, changing,
+// removing and querying the items of subscriptions using the Stripe REST API.
+type SubscriptionItemClient struct{ api }
+
+// Adds an item to an existing Subscription.
+//
+// see https://stripe.com/docs/api#create_subscription_item
+func (c SubscriptionItemClient) Create(ctx context.Context, params *SubscriptionItemParams) (*SubscriptionItem, error) {
+	res := &SubscriptionItem{}
+	return res, c.query(ctx, "POST", "/subscription_items", formValues(params), res)
+}
+
+// Retrieves the Subscription Item with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_subscription_item
+func (c SubscriptionItemClient) Get(ctx context.Context, id string) (*SubscriptionItem, error) {
+	res := &SubscriptionItem{}
+	return res, c.query(ctx, "GET", "/subscription_items/"+url.QueryEscape(id), nil, res)
+}
+
+// Changes the plan or quantity of the Subscription Item with the given ID.
+//
+// see https://stripe.com/docs/api#update_subscription_item
+func (c SubscriptionItemClient) Update(ctx context.Context, id string, params *SubscriptionItemParams) (*SubscriptionItem, error) {
+	res := &SubscriptionItem{}
+	return res, c.query(ctx, "POST", "/subscription_items/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// Removes the Subscription Item with the given ID from its Subscription. The
+// last item of a Subscription can not be removed; cancel the Subscription
+// instead.
+//
+// see https://stripe.com/docs/api#delete_subscription_item
+func (c SubscriptionItemClient) Delete(ctx context.Context, id string, prorate *bool) (bool, error) {
+	values := formValues(struct {
+		Prorate *bool `form:"prorate"`
+	}{prorate})
+
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", "/subscription_items/"+url.QueryEscape(id), values, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the items of the Subscription with the given ID.
+//
+// see https://stripe.com/docs/api#list_subscription_items
+func (c SubscriptionItemClient) List(ctx context.Context, subscriptionID string, limit int, before, after string) ([]*SubscriptionItem, bool, error) {
+	res := struct {
+		ListObject
+		Data []*SubscriptionItem
+	}{}
+	params := listParams(limit, before, after)
+	params.Set("subscription", subscriptionID)
+	err := c.query(ctx, "GET", "/subscription_items", params, &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the items of the Subscription with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_subscription_items
+func (c SubscriptionItemClient) ListFiltered(ctx context.Context, subscriptionID string, params *ListParams) (*List[SubscriptionItem], error) {
+	values := formValues(params)
+	values.Set("subscription", subscriptionID)
+
+	res := &List[SubscriptionItem]{}
+	return res, c.query(ctx, "GET", "/subscription_items", values, res)
+}
@@ -0,0 +1,197 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Subscription Schedule Statuses
+const (
+	ScheduleNotStarted = "not_started"
+	ScheduleActive     = "active"
+	ScheduleCompleted  = "completed"
+	ScheduleReleased   = "released"
+	ScheduleCanceled   = "canceled"
+)
+
+// Subscription Schedule End Behaviors
+const (
+	ScheduleEndRelease = "release"
+	ScheduleEndCancel  = "cancel"
+)
+
+// SubscriptionSchedule represents a sequence of phases, each with its own
+// plans, that a customer's Subscription moves through automatically.
+//
+// see https://stripe.com/docs/api#subscription_schedule_object
+type SubscriptionSchedule struct {
+	APIResource
+	ID                   string              `json:"id"`
+	Customer             string              `json:"customer"`
+	Subscription         string              `json:"subscription,omitempty"`
+	Status               string              `json:"status"`
+	EndBehavior          string              `json:"end_behavior"`
+	CurrentPhase         *SchedulePhaseRange `json:"current_phase,omitempty"`
+	Phases               []*SchedulePhase    `json:"phases"`
+	Created              UnixTime            `json:"created"`
+	CanceledAt           *UnixTime           `json:"canceled_at,omitempty"`
+	ReleasedAt           *UnixTime           `json:"released_at,omitempty"`
+	ReleasedSubscription string              `json:"released_subscription,omitempty"`
+	Livemode             bool                `json:"livemode"`
+	Metadata             map[string]string   `json:"metadata,omitempty"`
+}
+
+// SchedulePhaseRange is the start and end of a phase of a Subscription
+// Schedule.
+type SchedulePhaseRange struct {
+	StartDate UnixTime `json:"start_date"`
+	EndDate   UnixTime `json:"end_date"`
+}
+
+// SchedulePhase describes what the Subscription of a Subscription Schedule
+// bills for between its start and end dates.
+type SchedulePhase struct {
+	StartDate UnixTime             `json:"start_date"`
+	EndDate   UnixTime             `json:"end_date"`
+	Plans     []*SchedulePhaseItem `json:"plans"`
+	Coupon    string               `json:"coupon,omitempty"`
+	TrialEnd  *UnixTime            `json:"trial_end,omitempty"`
+}
+
+// SchedulePhaseItem is a plan, and its quantity, billed during a phase of a
+// Subscription Schedule.
+type SchedulePhaseItem struct {
+	Plan     string `json:"plan" form:"plan"`
+	Quantity int    `json:"quantity,omitempty" form:"quantity"`
+}
+
+// SubscriptionScheduleParams encapsulates options for creating and updating
+// Subscription Schedules.
+type SubscriptionScheduleParams struct {
+	// (Optional) The identifier of the customer to create the schedule for.
+	// Only used when creating a schedule, unless FromSubscription is set.
+	Customer string `form:"customer"`
+
+	// (Optional) The identifier of an existing Subscription to create the
+	// schedule from, in which case its current plans become the first phase.
+	// Only used when creating a schedule, and not with any other field.
+	FromSubscription string `form:"from_subscription"`
+
+	// (Optional) When the first phase starts. Only used when creating a
+	// schedule; default is now.
+	StartDate *UnixTime `form:"start_date"`
+
+	// (Optional) What happens to the Subscription when the last phase ends,
+	// either release or cancel. Default is release.
+	EndBehavior string `form:"end_behavior"`
+
+	// (Optional) The phases of the schedule, in order. When updating, every
+	// phase from the current one onwards must be given.
+	Phases []*SchedulePhaseParams `form:"phases"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// SchedulePhaseParams encapsulates options for a phase of a Subscription
+// Schedule.
+type SchedulePhaseParams struct {
+	// The plans, and their quantities, billed during the phase.
+	Plans []*SchedulePhaseItem `form:"plans"`
+
+	// (Optional) When the phase ends. Either EndDate or Iterations should be
+	// set for every phase but the last.
+	EndDate *UnixTime `form:"end_date"`
+
+	// (Optional) The number of billing periods the phase lasts.
+	Iterations int `form:"iterations"`
+
+	// (Optional) The code of a coupon to apply during the phase.
+	Coupon string `form:"coupon"`
+
+	// (Optional) The end of a trial period at the start of the phase.
+	TrialEnd *UnixTime `form:"trial_end"`
+}
+
+// SubscriptionScheduleListParams encapsulates options for filtering a list of
+// Subscription Schedules.
+type SubscriptionScheduleListParams struct {
+	ListParams
+
+	// (Optional) Only return schedules for the customer with this ID.
+	Customer string `form:"customer"`
+}
+
+// SubscriptionScheduleClient encapsulates operations for creating, updating,
+// releasing, canceling and querying subscription schedules using the Stripe
+// REST API.
+type SubscriptionScheduleClient struct{ api }
+
+// Creates a new Subscription Schedule.
+//
+// see https://stripe.com/docs/api#create_subscription_schedule
+func (c SubscriptionScheduleClient) Create(ctx context.Context, params *SubscriptionScheduleParams) (*SubscriptionSchedule, error) {
+	res := &SubscriptionSchedule{}
+	return res, c.query(ctx, "POST", "/subscription_schedules", formValues(params), res)
+}
+
+// Retrieves the Subscription Schedule with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_subscription_schedule
+func (c SubscriptionScheduleClient) Get(ctx context.Context, id string) (*SubscriptionSchedule, error) {
+	res := &SubscriptionSchedule{}
+	return res, c.query(ctx, "GET", "/subscription_schedules/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the phases, end behavior or metadata of the Subscription Schedule
+// with the given ID.
+//
+// see https://stripe.com/docs/api#update_subscription_schedule
+func (c SubscriptionScheduleClient) Update(ctx context.Context, id string, params *SubscriptionScheduleParams) (*SubscriptionSchedule, error) {
+	res := &SubscriptionSchedule{}
+	return res, c.query(ctx, "POST", "/subscription_schedules/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// Release stops the Subscription Schedule with the given ID from managing its
+// Subscription, which is left as it is in the current phase.
+//
+// see https://stripe.com/docs/api#release_subscription_schedule
+func (c SubscriptionScheduleClient) Release(ctx context.Context, id string) (*SubscriptionSchedule, error) {
+	res := &SubscriptionSchedule{}
+	path := fmt.Sprintf("/subscription_schedules/%s/release", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, nil, res)
+}
+
+// Cancels the Subscription Schedule with the given ID and its Subscription
+// immediately. If invoiceNow is true, any pending usage and prorations are
+// invoiced.
+//
+// see https://stripe.com/docs/api#cancel_subscription_schedule
+func (c SubscriptionScheduleClient) Cancel(ctx context.Context, id string, invoiceNow bool) (*SubscriptionSchedule, error) {
+	values := formValues(struct {
+		InvoiceNow bool `form:"invoice_now"`
+	}{invoiceNow})
+
+	res := &SubscriptionSchedule{}
+	path := fmt.Sprintf("/subscription_schedules/%s/cancel", url.QueryEscape(id))
+	return res, c.query(ctx, "POST", path, values, res)
+}
+
+// Returns a list of Subscription Schedules matching the given filters, or all
+// of your Subscription Schedules when params is nil.
+//
+// see https://stripe.com/docs/api#list_subscription_schedules
+func (c SubscriptionScheduleClient) List(ctx context.Context, params *SubscriptionScheduleListParams) ([]*SubscriptionSchedule, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Subscription Schedules matching the given filters, or of all your
+// Subscription Schedules when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_subscription_schedules
+func (c SubscriptionScheduleClient) ListFiltered(ctx context.Context, params *SubscriptionScheduleListParams) (*List[SubscriptionSchedule], error) {
+	res := &List[SubscriptionSchedule]{}
+	return res, c.query(ctx, "GET", "/subscription_schedules", formValues(params), res)
+}
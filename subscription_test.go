@@ -1,6 +1,15 @@
 package stripe
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -38,15 +47,15 @@ var (
 
 func TestCreateSubscription(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, _ := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Customers.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Customers.Delete(context.Background(), p1.ID)
 
 	// Subscribe the Customer to the Plan
-	resp, err := Subscriptions.Create(cust.ID, &sub1)
+	resp, err := Subscriptions.Create(context.Background(), cust.ID, &sub1)
 	if err != nil {
 		t.Errorf("Expected Subscription, got error %s", err.Error())
 	}
@@ -60,23 +69,23 @@ func TestCreateSubscription(t *testing.T) {
 
 func TestCreateSubscriptionCard(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, _ := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 	if cust.DefaultCard != "" {
 		t.Errorf("Expected Customer to be created with a nil card")
 		return
 	}
 
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Customers.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Customers.Delete(context.Background(), p1.ID)
 
 	// Create the coupon, and defer its deletion
-	Coupons.Create(&c1)
-	defer Coupons.Delete(c1.ID)
+	Coupons.Create(context.Background(), &c1)
+	defer Coupons.Delete(context.Background(), c1.ID)
 
 	// Subscribe a Customer to a new plan, using a new Credit Card
-	resp, err := Subscriptions.Create(cust.ID, &sub2)
+	resp, err := Subscriptions.Create(context.Background(), cust.ID, &sub2)
 	if err != nil {
 		t.Errorf("Expected Subscription, got error %s", err.Error())
 	}
@@ -85,7 +94,7 @@ func TestCreateSubscriptionCard(t *testing.T) {
 	}
 
 	// Check to see if the customer's card was added
-	cust, _ = Customers.Get(cust.ID)
+	cust, _ = Customers.Get(context.Background(), cust.ID)
 	if cust.DefaultCard == "" {
 		t.Errorf("Expected Subscription to assign a new active customer card")
 	}
@@ -93,29 +102,29 @@ func TestCreateSubscriptionCard(t *testing.T) {
 
 func TestCreateSubscriptionToken(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, _ := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 	if cust.DefaultCard != "" {
 		t.Errorf("Expected Customer to be created with a nil card")
 		return
 	}
 
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Customers.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Customers.Delete(context.Background(), p1.ID)
 
 	// Create a Token for the credit card
-	token, _ := Tokens.Create(&token1)
+	token, _ := Tokens.Create(context.Background(), &token1)
 
 	// Subscribe the Customer to the Plan, using the Token
 	params := SubscriptionParams{Plan: "plan1", Token: token.ID}
-	_, err := Subscriptions.Create(cust.ID, &params)
+	_, err := Subscriptions.Create(context.Background(), cust.ID, &params)
 	if err != nil {
 		t.Errorf("Expected Subscription with Token, got error %s", err.Error())
 	}
 
 	// Check to see if the customer's card was added
-	cust, _ = Customers.Get(cust.ID)
+	cust, _ = Customers.Get(context.Background(), cust.ID)
 	if cust.DefaultCard == "" {
 		t.Errorf("Expected Subscription to assign a new active customer card")
 	}
@@ -123,21 +132,21 @@ func TestCreateSubscriptionToken(t *testing.T) {
 
 func TestCancelSubscription(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, _ := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Customers.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Customers.Delete(context.Background(), p1.ID)
 
 	// Subscribe the Customer to the Plan
-	sub, err := Subscriptions.Create(cust.ID, &sub1)
+	sub, err := Subscriptions.Create(context.Background(), cust.ID, &sub1)
 	if err != nil {
 		t.Errorf("Expected Subscription, got error %s", err.Error())
 	}
 
 	// Now cancel the subscription
-	subs, err := Subscriptions.Cancel(cust.ID, sub.ID, false)
+	subs, err := Subscriptions.Cancel(context.Background(), cust.ID, sub.ID, false)
 	if err != nil {
 		t.Errorf("Expected Subscription Cancellation, got error %s", err.Error())
 	}
@@ -149,21 +158,21 @@ func TestCancelSubscription(t *testing.T) {
 
 func TestCancelSubscriptionAtPeriodEnd(t *testing.T) {
 	// Create the customer, and defer its deletion
-	cust, _ := Customers.Create(&cust1)
-	defer Customers.Delete(cust.ID)
+	cust, _ := Customers.Create(context.Background(), &cust1)
+	defer Customers.Delete(context.Background(), cust.ID)
 
 	// Create the plan, and defer its deletion
-	Plans.Create(&p1)
-	defer Customers.Delete(p1.ID)
+	Plans.Create(context.Background(), &p1)
+	defer Customers.Delete(context.Background(), p1.ID)
 
 	// Subscribe the Customer to the Plan
-	sub, err := Subscriptions.Create(cust.ID, &sub1)
+	sub, err := Subscriptions.Create(context.Background(), cust.ID, &sub1)
 	if err != nil {
 		t.Errorf("Expected Subscription, got error %s", err.Error())
 	}
 
 	// Now cancel the subscription
-	subs, err := Subscriptions.Cancel(cust.ID, sub.ID, true)
+	subs, err := Subscriptions.Cancel(context.Background(), cust.ID, sub.ID, true)
 	if err != nil {
 		t.Errorf("Expected Subscription Cancellation, got error %s", err.Error())
 	}
@@ -176,3 +185,247 @@ func TestCancelSubscriptionAtPeriodEnd(t *testing.T) {
 		t.Errorf("Expected CancelAtPeriodEnd to be %t, got %t", true, subs.CancelAtPeriodEnd)
 	}
 }
+
+// TestChangePlan will test that ChangePlan previews and applies the change
+// with the same proration date, then invoices and pays the proration.
+func TestChangePlan(t *testing.T) {
+	dates := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch r.URL.Path {
+		case "/v1/invoices/upcoming":
+			dates["preview"] = r.URL.Query().Get("subscription_proration_date")
+			fmt.Fprint(w, `{"id": "", "amount_due": 500}`)
+		case "/v1/customers/cus_1/subscriptions/sub_1":
+			dates["update"] = form.Get("proration_date")
+			fmt.Fprintf(w, `{"id": "sub_1", "plan": {"id": "%s"}}`, form.Get("plan"))
+		case "/v1/invoices":
+			fmt.Fprint(w, `{"id": "in_1", "amount_due": 500}`)
+		case "/v1/invoices/in_1/pay":
+			fmt.Fprint(w, `{"id": "in_1", "amount_due": 500, "paid": true}`)
+		}
+	}))
+	defer srv.Close()
+
+	old := _default.URL
+	SetUrl(srv.URL)
+	defer SetUrl(old)
+
+	res, err := Subscriptions.ChangePlan(context.Background(), "cus_1", "sub_1", "gold", &ChangePlanOptions{InvoiceNow: true})
+	if err != nil {
+		t.Fatalf("ChangePlan failed: %s", err)
+	}
+	if res.Preview.AmountDue != 500 {
+		t.Errorf("Expected preview amount 500, got %d", res.Preview.AmountDue)
+	}
+	if res.Subscription.Plan.ID != "gold" {
+		t.Errorf("Expected plan gold, got %s", res.Subscription.Plan.ID)
+	}
+	if res.Invoice == nil || !res.Invoice.Paid {
+		t.Errorf("Expected proration invoice to be paid, got %+v", res.Invoice)
+	}
+	if dates["preview"] == "" || dates["preview"] != dates["update"] {
+		t.Errorf("Expected matching proration dates, got %v", dates)
+	}
+}
+
+// TestTopLevelSubscriptions will test that subscriptions are created and
+// listed with the top-level endpoints when no customer ID is given.
+func TestTopLevelSubscriptions(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"object": "list", "data": [{"id": "sub_1", "customer": "cus_1", "status": "past_due"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "sub_1", "customer": "cus_1", "items": {"object": "list", "data": [{"id": "si_1", "plan": {"id": "gold"}, "quantity": 2}]}}`)
+	})
+	ctx := context.Background()
+	sub, err := c.Subscriptions.Create(ctx, "", &SubscriptionParams{
+		Customer: "cus_1",
+		Items:    []*SubscriptionItemParams{{Plan: "gold", Quantity: 2}},
+	})
+	if err != nil || sub.ID != "sub_1" || len(sub.Items.Data) != 1 || sub.Items.Data[0].Plan.ID != "gold" {
+		t.Fatalf("Expected Subscription sub_1 to gold, got %+v %v", sub, err)
+	}
+	want := url.Values{"customer": {"cus_1"}, "items[0][plan]": {"gold"}, "items[0][quantity]": {"2"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	list, err := c.Subscriptions.ListFiltered(ctx, &SubscriptionListParams{Customer: "cus_1", Status: SubscriptionPastDue})
+	if err != nil || len(list.Data) != 1 || list.Data[0].Status != SubscriptionPastDue {
+		t.Fatalf("Expected past due Subscription, got %v %v", list, err)
+	}
+	if want := (url.Values{"customer": {"cus_1"}, "status": {"past_due"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	if want := []string{"POST /v1/subscriptions", "GET /v1/subscriptions"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestEndTrialNow will test that a trial is ended immediately by sending now
+// as its end, in place of any TrialEnd.
+func TestEndTrialNow(t *testing.T) {
+	end := UnixTime{time.Unix(1500000000, 0)}
+	got := subscriptionValues(&SubscriptionParams{TrialEnd: &end, EndTrialNow: true})
+	if want := (url.Values{"trial_end": {"now"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	got = subscriptionValues(&SubscriptionParams{TrialEnd: &end})
+	if want := (url.Values{"trial_end": {"1500000000"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestSubscriptionItems will test that an item is added to a Subscription and
+// that the items of a Subscription are listed by its ID.
+func TestSubscriptionItems(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"object": "list", "has_more": true, "data": [{"id": "si_1", "subscription": "sub_1", "quantity": 1}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "si_2", "subscription": "sub_1", "plan": {"id": "seats"}, "quantity": 5}`)
+	})
+	ctx := context.Background()
+	prorate := false
+	item, err := c.SubscriptionItems.Create(ctx, &SubscriptionItemParams{Subscription: "sub_1", Plan: "seats", Quantity: 5, Prorate: &prorate})
+	if err != nil || item.ID != "si_2" || item.Plan.ID != "seats" || item.Quantity != 5 {
+		t.Fatalf("Expected Subscription Item si_2 with 5 seats, got %+v %v", item, err)
+	}
+	want := url.Values{"subscription": {"sub_1"}, "plan": {"seats"}, "quantity": {"5"}, "prorate": {"false"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	items, more, err := c.SubscriptionItems.List(ctx, "sub_1", 10, "", "")
+	if err != nil || len(items) != 1 || !more {
+		t.Errorf("Expected 1 Subscription Item and more, got %d %v %v", len(items), more, err)
+	}
+	if got := srv.forms[1].Get("subscription"); got != "sub_1" {
+		t.Errorf("Expected subscription sub_1, got %q", got)
+	}
+
+	if want := []string{"POST /v1/subscription_items", "GET /v1/subscription_items"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestCreateSubscriptionSchedule will test that a Subscription Schedule is
+// created with its phases nested by index, and that it can be released.
+func TestCreateSubscriptionSchedule(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		status := ScheduleNotStarted
+		if strings.HasSuffix(r.URL.Path, "/release") {
+			status = ScheduleReleased
+		}
+		fmt.Fprintf(w, `{"id": "sub_sched_1", "customer": "cus_1", "status": %q, "phases": [{"plans": [{"plan": "basic", "quantity": 1}]}, {"plans": [{"plan": "pro"}]}]}`, status)
+	})
+	ctx := context.Background()
+	start := UnixTime{time.Unix(1600000000, 0)}
+	sched, err := c.SubscriptionSchedules.Create(ctx, &SubscriptionScheduleParams{
+		Customer:    "cus_1",
+		StartDate:   &start,
+		EndBehavior: ScheduleEndRelease,
+		Phases: []*SchedulePhaseParams{
+			{Plans: []*SchedulePhaseItem{{Plan: "basic", Quantity: 1}}, Iterations: 3},
+			{Plans: []*SchedulePhaseItem{{Plan: "pro"}}},
+		},
+	})
+	if err != nil || sched.Status != ScheduleNotStarted || len(sched.Phases) != 2 || sched.Phases[1].Plans[0].Plan != "pro" {
+		t.Fatalf("Expected Subscription Schedule with 2 phases, got %+v %v", sched, err)
+	}
+	want := url.Values{
+		"customer":                      {"cus_1"},
+		"start_date":                    {"1600000000"},
+		"end_behavior":                  {"release"},
+		"phases[0][plans][0][plan]":     {"basic"},
+		"phases[0][plans][0][quantity]": {"1"},
+		"phases[0][iterations]":         {"3"},
+		"phases[1][plans][0][plan]":     {"pro"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	if sched, err = c.SubscriptionSchedules.Release(ctx, "sub_sched_1"); err != nil || sched.Status != ScheduleReleased {
+		t.Errorf("Expected released Subscription Schedule, got %+v %v", sched, err)
+	}
+	if want := []string{"POST /v1/subscription_schedules", "POST /v1/subscription_schedules/sub_sched_1/release"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
+
+// TestCreateUsageRecord will test that usage of a metered Subscription Item is
+// reported with its quantity, timestamp and action.
+func TestCreateUsageRecord(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "mbur_1", "subscription_item": "si_1", "quantity": 100, "timestamp": 1600000000}`)
+	})
+	rec, err := c.UsageRecords.Create(context.Background(), "si_1", 100, UnixTime{time.Unix(1600000000, 0)}, UsageSet)
+	if err != nil || rec.ID != "mbur_1" || rec.Quantity != 100 {
+		t.Fatalf("Expected Usage Record mbur_1, got %+v %v", rec, err)
+	}
+	if want := []string{"POST /v1/subscription_items/si_1/usage_records"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+	want := url.Values{"quantity": {"100"}, "timestamp": {"1600000000"}, "action": {"set"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+}
+
+// TestSetQuantity will test that the quantity of a Subscription is sent on
+// its own, including a quantity of zero.
+func TestSetQuantity(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "sub_1", "quantity": %s}`, r.FormValue("quantity"))
+	})
+	for i, quantity := range []int{25, 0} {
+		sub, err := c.Subscriptions.SetQuantity(context.Background(), "cus_1", "sub_1", quantity)
+		if err != nil || sub.Quantity != quantity {
+			t.Errorf("Expected quantity %d, got %+v %v", quantity, sub, err)
+		}
+		if want := (url.Values{"quantity": {strconv.Itoa(quantity)}}); !reflect.DeepEqual(srv.forms[i], want) {
+			t.Errorf("Expected %v, got %v", want, srv.forms[i])
+		}
+	}
+}
+
+// TestChangePlanWithoutCustomer will test that the customer of a Subscription
+// is looked up when ChangePlan is not given one, so the proration is invoiced
+// to them.
+func TestChangePlanWithoutCustomer(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/subscriptions/sub_1":
+			fmt.Fprint(w, `{"id": "sub_1", "customer": "cus_1"}`)
+		case "/v1/invoices/upcoming":
+			fmt.Fprint(w, `{"id": "", "amount_due": 500}`)
+		case "/v1/customers/cus_1/subscriptions/sub_1":
+			fmt.Fprint(w, `{"id": "sub_1", "customer": "cus_1", "plan": {"id": "gold"}}`)
+		default:
+			fmt.Fprint(w, `{"id": "in_1", "amount_due": 0}`)
+		}
+	})
+
+	res, err := c.Subscriptions.ChangePlan(context.Background(), "", "sub_1", "gold", &ChangePlanOptions{InvoiceNow: true})
+	if err != nil || res.Invoice == nil || res.Invoice.ID != "in_1" {
+		t.Fatalf("Expected proration Invoice in_1, got %+v %v", res, err)
+	}
+	want := []string{
+		"GET /v1/subscriptions/sub_1",
+		"GET /v1/invoices/upcoming",
+		"POST /v1/customers/cus_1/subscriptions/sub_1",
+		"POST /v1/invoices",
+	}
+	if !reflect.DeepEqual(srv.paths, want) {
+		t.Fatalf("Expected requests %v, got %v", want, srv.paths)
+	}
+	if srv.forms[1].Get("customer") != "cus_1" || srv.forms[3].Get("customer") != "cus_1" {
+		t.Errorf("Expected the preview and invoice for cus_1, got %v %v", srv.forms[1], srv.forms[3])
+	}
+}
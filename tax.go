@@ -0,0 +1,252 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Tax Behaviors
+const (
+	TaxBehaviorExclusive = "exclusive"
+	TaxBehaviorInclusive = "inclusive"
+)
+
+// Tax Address Sources
+const (
+	TaxAddressBilling  = "billing"
+	TaxAddressShipping = "shipping"
+)
+
+// TaxCalculation represents the tax computed by Stripe Tax for a cart of line
+// items shipped to a customer. A TaxCalculation is not recorded for
+// reporting until a TaxTransaction is created from it.
+//
+// see https://stripe.com/docs/api/tax/calculations/object
+type TaxCalculation struct {
+	APIResource
+	ID                 string              `json:"id"`
+	Currency           string              `json:"currency"`
+	Customer           string              `json:"customer,omitempty"`
+	CustomerDetails    *TaxCustomerDetails `json:"customer_details"`
+	AmountTotal        int64               `json:"amount_total"`
+	TaxAmountExclusive int64               `json:"tax_amount_exclusive"`
+	TaxAmountInclusive int64               `json:"tax_amount_inclusive"`
+	TaxBreakdown       []*TaxBreakdown     `json:"tax_breakdown"`
+	LineItems          *List[TaxLineItem]  `json:"line_items,omitempty"`
+	ShippingCost       *TaxShippingCost    `json:"shipping_cost,omitempty"`
+	TaxDate            UnixTime            `json:"tax_date"`
+	ExpiresAt          *UnixTime           `json:"expires_at,omitempty"`
+	Livemode           bool                `json:"livemode"`
+}
+
+// TaxCustomerDetails describes where a customer is located, which decides the
+// taxes that apply to them.
+type TaxCustomerDetails struct {
+	// The address of the customer.
+	Address *Address `json:"address,omitempty" form:"address"`
+
+	// Whether Address is the billing or the shipping address of the
+	// customer.
+	AddressSource string `json:"address_source,omitempty" form:"address_source"`
+
+	// (Optional) The IP address of the customer, used when no address is
+	// given.
+	IPAddress string `json:"ip_address,omitempty" form:"ip_address"`
+
+	// (Optional) Whether the customer is exempt from tax, ie none, exempt or
+	// reverse.
+	TaxabilityOverride string `json:"taxability_override,omitempty" form:"taxability_override"`
+}
+
+// TaxBreakdown is the tax of a TaxCalculation in one jurisdiction.
+type TaxBreakdown struct {
+	Amount           int64           `json:"amount"`
+	TaxableAmount    int64           `json:"taxable_amount"`
+	Inclusive        bool            `json:"inclusive"`
+	TaxabilityReason string          `json:"taxability_reason,omitempty"`
+	TaxRateDetails   *TaxRateDetails `json:"tax_rate_details"`
+}
+
+// TaxRateDetails describes the rate of a TaxBreakdown, ie 19% VAT in DE.
+type TaxRateDetails struct {
+	Country           string `json:"country,omitempty"`
+	State             string `json:"state,omitempty"`
+	PercentageDecimal string `json:"percentage_decimal"`
+	TaxType           string `json:"tax_type,omitempty"`
+}
+
+// TaxLineItem is a line item of a TaxCalculation or TaxTransaction, with the
+// tax computed for it.
+type TaxLineItem struct {
+	ID          string `json:"id"`
+	Amount      int64  `json:"amount"`
+	AmountTax   int64  `json:"amount_tax"`
+	Quantity    int    `json:"quantity"`
+	Reference   string `json:"reference"`
+	Product     string `json:"product,omitempty"`
+	TaxBehavior string `json:"tax_behavior"`
+	TaxCode     string `json:"tax_code"`
+}
+
+// TaxShippingCost is the cost of shipping of a TaxCalculation, with the tax
+// computed for it.
+type TaxShippingCost struct {
+	Amount      int64  `json:"amount"`
+	AmountTax   int64  `json:"amount_tax"`
+	TaxBehavior string `json:"tax_behavior"`
+	TaxCode     string `json:"tax_code"`
+}
+
+// TaxCalculationParams encapsulates options for calculating tax.
+type TaxCalculationParams struct {
+	// 3-letter ISO code for currency.
+	Currency string `form:"currency"`
+
+	// (Optional) The ID of an existing customer whose address is used, in
+	// place of CustomerDetails.
+	Customer string `form:"customer"`
+
+	// (Optional) The location of the customer, required unless Customer is
+	// set.
+	CustomerDetails *TaxCustomerDetails `form:"customer_details"`
+
+	// The line items of the cart to calculate tax for.
+	LineItems []*TaxLineItemParams `form:"line_items"`
+
+	// (Optional) The cost of shipping the cart.
+	ShippingCost *TaxShippingCostParams `form:"shipping_cost"`
+
+	// (Optional) The time at which tax is calculated. Default is now.
+	TaxDate *UnixTime `form:"tax_date"`
+}
+
+// TaxLineItemParams encapsulates options for a line item of a
+// TaxCalculation.
+type TaxLineItemParams struct {
+	// The amount of the line item, in cents, including every unit.
+	Amount int64 `form:"amount,always"`
+
+	// A unique reference for the line item in your system, ie a SKU.
+	Reference string `form:"reference"`
+
+	// (Optional) The quantity of the line item. Default is 1.
+	Quantity int `form:"quantity"`
+
+	// (Optional) The ID of the Product of the line item, whose tax code is
+	// used unless TaxCode is set.
+	Product string `form:"product"`
+
+	// (Optional) Whether Amount includes tax. Default is exclusive.
+	TaxBehavior string `form:"tax_behavior"`
+
+	// (Optional) The tax code of the line item, ie txcd_99999999. Default is
+	// the tax code of your account.
+	TaxCode string `form:"tax_code"`
+}
+
+// TaxShippingCostParams encapsulates options for the cost of shipping of a
+// TaxCalculation.
+type TaxShippingCostParams struct {
+	// The cost of shipping, in cents.
+	Amount int64 `form:"amount,always"`
+
+	// (Optional) Whether Amount includes tax. Default is exclusive.
+	TaxBehavior string `form:"tax_behavior"`
+
+	// (Optional) The tax code of shipping. Default is txcd_92010001.
+	TaxCode string `form:"tax_code"`
+}
+
+// TaxCalculationClient encapsulates operations for calculating tax with
+// Stripe Tax using the Stripe REST API.
+type TaxCalculationClient struct{ api }
+
+// Calculates the tax of a cart of line items.
+//
+// see https://stripe.com/docs/api/tax/calculations/create
+func (c TaxCalculationClient) Create(ctx context.Context, params *TaxCalculationParams) (*TaxCalculation, error) {
+	res := &TaxCalculation{}
+	return res, c.query(ctx, "POST", "/tax/calculations", formValues(params), res)
+}
+
+// Retrieves the TaxCalculation with the given ID.
+//
+// see https://stripe.com/docs/api/tax/calculations/retrieve
+func (c TaxCalculationClient) Get(ctx context.Context, id string) (*TaxCalculation, error) {
+	res := &TaxCalculation{}
+	return res, c.query(ctx, "GET", "/tax/calculations/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of the line items of the TaxCalculation with the given ID.
+//
+// see https://stripe.com/docs/api/tax/calculations/line_items
+func (c TaxCalculationClient) ListLineItems(ctx context.Context, id string, limit int, before, after string) ([]*TaxLineItem, bool, error) {
+	return c.listTaxLineItems(ctx, fmt.Sprintf("/tax/calculations/%s/line_items", url.QueryEscape(id)), limit, before, after)
+}
+
+// TaxTransaction represents a TaxCalculation recorded, once the cart was paid
+// for, so that its tax is reported and remitted.
+//
+// see https://stripe.com/docs/api/tax/transactions/object
+type TaxTransaction struct {
+	APIResource
+	ID              string              `json:"id"`
+	Type            string              `json:"type"`
+	Reference       string              `json:"reference"`
+	Currency        string              `json:"currency"`
+	Customer        string              `json:"customer,omitempty"`
+	CustomerDetails *TaxCustomerDetails `json:"customer_details"`
+	LineItems       *List[TaxLineItem]  `json:"line_items,omitempty"`
+	ShippingCost    *TaxShippingCost    `json:"shipping_cost,omitempty"`
+	TaxDate         UnixTime            `json:"tax_date"`
+	Created         UnixTime            `json:"created"`
+	Livemode        bool                `json:"livemode"`
+	Metadata        map[string]string   `json:"metadata,omitempty"`
+}
+
+// TaxTransactionClient encapsulates operations for recording and querying
+// Stripe Tax transactions using the Stripe REST API.
+type TaxTransactionClient struct{ api }
+
+// CreateFromCalculation records the TaxCalculation with the given ID as a
+// TaxTransaction. The reference, ie the ID of the order in your system, must
+// be unique among your transactions.
+//
+// see https://stripe.com/docs/api/tax/transactions/create_from_calculation
+func (c TaxTransactionClient) CreateFromCalculation(ctx context.Context, calculationID, reference string, metadata map[string]string) (*TaxTransaction, error) {
+	params := struct {
+		Calculation string            `form:"calculation"`
+		Reference   string            `form:"reference"`
+		Metadata    map[string]string `form:"metadata"`
+	}{calculationID, reference, metadata}
+
+	res := &TaxTransaction{}
+	return res, c.query(ctx, "POST", "/tax/transactions/create_from_calculation", formValues(params), res)
+}
+
+// Retrieves the TaxTransaction with the given ID.
+//
+// see https://stripe.com/docs/api/tax/transactions/retrieve
+func (c TaxTransactionClient) Get(ctx context.Context, id string) (*TaxTransaction, error) {
+	res := &TaxTransaction{}
+	return res, c.query(ctx, "GET", "/tax/transactions/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of the line items of the TaxTransaction with the given ID.
+//
+// see https://stripe.com/docs/api/tax/transactions/line_items
+func (c TaxTransactionClient) ListLineItems(ctx context.Context, id string, limit int, before, after string) ([]*TaxLineItem, bool, error) {
+	return c.listTaxLineItems(ctx, fmt.Sprintf("/tax/transactions/%s/line_items", url.QueryEscape(id)), limit, before, after)
+}
+
+// listTaxLineItems returns a page of the line items at path, which are listed
+// the same way for calculations and transactions.
+func (a api) listTaxLineItems(ctx context.Context, path string, limit int, before, after string) ([]*TaxLineItem, bool, error) {
+	res := struct {
+		ListObject
+		Data []*TaxLineItem
+	}{}
+	err := a.query(ctx, "GET", path, listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
@@ -0,0 +1,114 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Tax ID Types
+const (
+	TaxIDAUABN   = "au_abn"
+	TaxIDCABN    = "ca_bn"
+	TaxIDCHVAT   = "ch_vat"
+	TaxIDEUVAT   = "eu_vat"
+	TaxIDGBVAT   = "gb_vat"
+	TaxIDINGST   = "in_gst"
+	TaxIDNOVAT   = "no_vat"
+	TaxIDNZGST   = "nz_gst"
+	TaxIDUSEIN   = "us_ein"
+	TaxIDZAVAT   = "za_vat"
+	TaxIDUnknown = "unknown"
+)
+
+// Tax ID Verification Statuses
+const (
+	TaxIDPending     = "pending"
+	TaxIDVerified    = "verified"
+	TaxIDUnverified  = "unverified"
+	TaxIDUnavailable = "unavailable"
+)
+
+// TaxID represents a tax identifier of a Customer, such as a VAT number,
+// which is shown on their invoices.
+//
+// see https://stripe.com/docs/api#tax_id_object
+type TaxID struct {
+	APIResource
+	ID           string             `json:"id"`
+	Customer     string             `json:"customer"`
+	Type         string             `json:"type"`
+	Value        string             `json:"value"`
+	Country      string             `json:"country,omitempty"`
+	Verification *TaxIDVerification `json:"verification,omitempty"`
+	Created      UnixTime           `json:"created"`
+	Livemode     bool               `json:"livemode"`
+}
+
+// TaxIDVerification holds the result of the verification of a Tax ID with
+// the relevant tax authority.
+type TaxIDVerification struct {
+	Status          string `json:"status"`
+	VerifiedName    string `json:"verified_name,omitempty"`
+	VerifiedAddress string `json:"verified_address,omitempty"`
+}
+
+// TaxIDClient encapsulates operations for adding, deleting and querying the
+// tax IDs of a customer using the Stripe REST API.
+type TaxIDClient struct{ api }
+
+func (c TaxIDClient) path(customerID, taxID string) string {
+	p := fmt.Sprintf("/customers/%s/tax_ids", url.QueryEscape(customerID))
+	if taxID != "" {
+		p += "/" + url.QueryEscape(taxID)
+	}
+	return p
+}
+
+// Adds a tax ID of the given type, ie eu_vat, to the Customer with the given
+// ID.
+//
+// see https://stripe.com/docs/api#create_tax_id
+func (c TaxIDClient) Create(ctx context.Context, customerID, taxIDType, value string) (*TaxID, error) {
+	values := url.Values{"type": {taxIDType}, "value": {value}}
+	res := &TaxID{}
+	return res, c.query(ctx, "POST", c.path(customerID, ""), values, res)
+}
+
+// Retrieves the tax ID with the given ID of a Customer.
+//
+// see https://stripe.com/docs/api#retrieve_tax_id
+func (c TaxIDClient) Get(ctx context.Context, customerID, taxID string) (*TaxID, error) {
+	res := &TaxID{}
+	return res, c.query(ctx, "GET", c.path(customerID, taxID), nil, res)
+}
+
+// Deletes a tax ID of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#delete_tax_id
+func (c TaxIDClient) Delete(ctx context.Context, customerID, taxID string) (bool, error) {
+	res := &DeleteResp{}
+	err := c.query(ctx, "DELETE", c.path(customerID, taxID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the tax IDs of the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#list_tax_ids
+func (c TaxIDClient) List(ctx context.Context, customerID string, limit int, before, after string) ([]*TaxID, bool, error) {
+	res := struct {
+		ListObject
+		Data []*TaxID
+	}{}
+	err := c.query(ctx, "GET", c.path(customerID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the tax IDs of the Customer with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_tax_ids
+func (c TaxIDClient) ListFiltered(ctx context.Context, customerID string, params *ListParams) (*List[TaxID], error) {
+	res := &List[TaxID]{}
+	return res, c.query(ctx, "GET", c.path(customerID, ""), formValues(params), res)
+}
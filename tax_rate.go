@@ -0,0 +1,137 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// TaxRate represents a tax, ie VAT or GST, applied to the invoices of
+// subscriptions and to invoice items.
+//
+// see https://stripe.com/docs/api#tax_rate_object
+type TaxRate struct {
+	APIResource
+	ID           string            `json:"id"`
+	DisplayName  string            `json:"display_name"`
+	Description  string            `json:"description,omitempty"`
+	Percentage   float64           `json:"percentage"`
+	Inclusive    bool              `json:"inclusive"`
+	Jurisdiction string            `json:"jurisdiction,omitempty"`
+	Country      string            `json:"country,omitempty"`
+	State        string            `json:"state,omitempty"`
+	Active       bool              `json:"active"`
+	Created      UnixTime          `json:"created"`
+	Livemode     bool              `json:"livemode"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// TaxRateParams encapsulates options for creating and updating TaxRates.
+type TaxRateParams struct {
+	// The name of the tax shown to customers, ie VAT. Required when creating
+	// a TaxRate.
+	DisplayName string `form:"display_name"`
+
+	// The rate of the tax, as a percentage out of 100. Required when creating
+	// a TaxRate.
+	Percentage float64 `form:"percentage"`
+
+	// Whether the tax is included in the amount it applies to, rather than
+	// added to it. Only used when creating a TaxRate.
+	Inclusive bool `form:"inclusive,always"`
+
+	// (Optional) The jurisdiction of the tax, shown to customers.
+	Jurisdiction string `form:"jurisdiction"`
+
+	// (Optional) The two-letter code of the country of the tax.
+	Country string `form:"country"`
+
+	// (Optional) The code of the state of the tax, ie CA.
+	State string `form:"state"`
+
+	// (Optional) A description of the tax, for your own use.
+	Description string `form:"description"`
+
+	// (Optional) Whether the tax can be applied to new subscriptions and
+	// invoice items. Default is true.
+	Active *bool `form:"active"`
+
+	Metadata map[string]string `form:"metadata"`
+}
+
+// TaxRateListParams encapsulates options for filtering a list of TaxRates.
+type TaxRateListParams struct {
+	ListParams
+
+	// (Optional) Only return active or inactive tax rates.
+	Active *bool `form:"active"`
+
+	// (Optional) Only return inclusive or exclusive tax rates.
+	Inclusive *bool `form:"inclusive"`
+
+	// (Optional) Only return tax rates created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// TaxRateClient encapsulates operations for creating, updating and querying
+// tax rates using the Stripe REST API.
+type TaxRateClient struct{ api }
+
+// Creates a new TaxRate.
+//
+// see https://stripe.com/docs/api#create_tax_rate
+func (c TaxRateClient) Create(ctx context.Context, params *TaxRateParams) (*TaxRate, error) {
+	res := &TaxRate{}
+	return res, c.query(ctx, "POST", "/tax_rates", formValues(params), res)
+}
+
+// Retrieves the TaxRate with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_tax_rate
+func (c TaxRateClient) Get(ctx context.Context, id string) (*TaxRate, error) {
+	res := &TaxRate{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the TaxRate into v, which may be any type
+// with matching JSON fields.
+func (c TaxRateClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/tax_rates/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the TaxRate with the given ID. The percentage of a TaxRate, and
+// whether it is inclusive, can not be changed; create a new TaxRate instead.
+//
+// see https://stripe.com/docs/api#update_tax_rate
+func (c TaxRateClient) Update(ctx context.Context, id string, params *TaxRateParams) (*TaxRate, error) {
+	values := formValues(params)
+	values.Del("percentage")
+	values.Del("inclusive")
+
+	res := &TaxRate{}
+	return res, c.query(ctx, "POST", "/tax_rates/"+url.QueryEscape(id), values, res)
+}
+
+// Returns a list of TaxRates matching the given filters, or all of your
+// TaxRates when params is nil.
+//
+// see https://stripe.com/docs/api#list_tax_rates
+func (c TaxRateClient) List(ctx context.Context, params *TaxRateListParams) ([]*TaxRate, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of TaxRates matching the given filters, or of all your
+// TaxRates when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_tax_rates
+func (c TaxRateClient) ListFiltered(ctx context.Context, params *TaxRateListParams) (*List[TaxRate], error) {
+	res := &List[TaxRate]{}
+	return res, c.query(ctx, "GET", "/tax_rates", formValues(params), res)
+}
+
+// ListAll returns an Iter over every TaxRate matching the filters of params,
+// newest first, fetching pages as they are needed. The Limit of params, if
+// any, sets the size of each page.
+func (c TaxRateClient) ListAll(ctx context.Context, params *TaxRateListParams) *Iter[TaxRate] {
+	return newIter(ctx, c.backend(), "/tax_rates", params, func(tr *TaxRate) string { return tr.ID })
+}
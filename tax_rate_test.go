@@ -0,0 +1,64 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestTaxRates will test that a TaxRate is created, that its percentage is
+// not sent when it is updated, and that it can be applied to a Subscription
+// and an Invoice Item.
+func TestTaxRates(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "txr_vat", "display_name": "VAT", "percentage": 19.5, "inclusive": false, "jurisdiction": "DE", "active": true}`)
+	})
+	ctx := context.Background()
+	params := &TaxRateParams{DisplayName: "VAT", Percentage: 19.5, Jurisdiction: "DE"}
+	rate, err := c.TaxRates.Create(ctx, params)
+	if err != nil || rate.ID != "txr_vat" || rate.Percentage != 19.5 || !rate.Active {
+		t.Fatalf("Expected active 19.5%% TaxRate txr_vat, got %+v %v", rate, err)
+	}
+	want := url.Values{"display_name": {"VAT"}, "percentage": {"19.5"}, "inclusive": {"false"}, "jurisdiction": {"DE"}}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	params.Description = "German VAT"
+	if _, err := c.TaxRates.Update(ctx, "txr_vat", params); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	want = url.Values{"display_name": {"VAT"}, "jurisdiction": {"DE"}, "description": {"German VAT"}}
+	if !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	sub := &SubscriptionParams{
+		Customer:        "cus_1",
+		DefaultTaxRates: []string{"txr_vat"},
+		Items:           []*SubscriptionItemParams{{Plan: "gold", TaxRates: []string{"txr_reduced"}}},
+	}
+	if _, err := c.Subscriptions.Create(ctx, "", sub); err != nil {
+		t.Fatalf("Create Subscription failed: %s", err)
+	}
+	want = url.Values{"customer": {"cus_1"}, "default_tax_rates[0]": {"txr_vat"}, "items[0][plan]": {"gold"}, "items[0][tax_rates][0]": {"txr_reduced"}}
+	if !reflect.DeepEqual(srv.forms[2], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[2])
+	}
+
+	item := &InvoiceItemParams{Customer: "cus_1", Amount: 1000, Currency: "eur", TaxRates: []string{"txr_vat"}}
+	if _, err := c.InvoiceItems.Create(ctx, item); err != nil {
+		t.Fatalf("Create Invoice Item failed: %s", err)
+	}
+	if got := srv.forms[3]["tax_rates[0]"]; !reflect.DeepEqual(got, []string{"txr_vat"}) {
+		t.Errorf("Expected tax_rates[0] txr_vat, got %v", got)
+	}
+
+	wantPaths := []string{"POST /v1/tax_rates", "POST /v1/tax_rates/txr_vat", "POST /v1/subscriptions", "POST /v1/invoiceitems"}
+	if !reflect.DeepEqual(srv.paths, wantPaths) {
+		t.Errorf("Expected %v, got %v", wantPaths, srv.paths)
+	}
+}
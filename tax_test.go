@@ -0,0 +1,68 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestTaxCalculation will test that tax is calculated for a cart of line
+// items, and that the calculation is recorded as a TaxTransaction.
+func TestTaxCalculation(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tax/calculations":
+			fmt.Fprint(w, `{"id": "taxcalc_1", "currency": "usd", "amount_total": 1095, "tax_amount_exclusive": 95,
+				"tax_breakdown": [{"amount": 95, "taxable_amount": 1000, "tax_rate_details": {"country": "US", "state": "CA", "percentage_decimal": "9.5"}}],
+				"line_items": {"object": "list", "data": [{"id": "tax_li_1", "amount": 1000, "amount_tax": 95, "reference": "sku_1"}]}}`)
+		default:
+			fmt.Fprint(w, `{"id": "tax_1", "type": "transaction", "reference": "order_1"}`)
+		}
+	})
+	ctx := context.Background()
+	calc, err := c.TaxCalculations.Create(ctx, &TaxCalculationParams{
+		Currency: "usd",
+		CustomerDetails: &TaxCustomerDetails{
+			Address:       &Address{PostalCode: "94103", Country: "US"},
+			AddressSource: TaxAddressShipping,
+		},
+		LineItems: []*TaxLineItemParams{{Amount: 1000, Reference: "sku_1"}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if calc.TaxAmountExclusive != 95 || len(calc.TaxBreakdown) != 1 || calc.TaxBreakdown[0].TaxRateDetails.State != "CA" ||
+		calc.LineItems == nil || len(calc.LineItems.Data) != 1 || calc.LineItems.Data[0].AmountTax != 95 {
+		t.Errorf("Expected 95 of CA tax on one line item, got %+v", calc)
+	}
+	want := url.Values{
+		"currency":                               {"usd"},
+		"customer_details[address][postal_code]": {"94103"},
+		"customer_details[address][country]":     {"US"},
+		"customer_details[address_source]":       {"shipping"},
+		"line_items[0][amount]":                  {"1000"},
+		"line_items[0][reference]":               {"sku_1"},
+	}
+	if !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[0])
+	}
+
+	tx, err := c.TaxTransactions.CreateFromCalculation(ctx, calc.ID, "order_1", nil)
+	if err != nil || tx.ID != "tax_1" || tx.Reference != "order_1" {
+		t.Fatalf("Expected TaxTransaction tax_1 for order_1, got %+v %v", tx, err)
+	}
+	if want := (url.Values{"calculation": {"taxcalc_1"}, "reference": {"order_1"}}); !reflect.DeepEqual(srv.forms[1], want) {
+		t.Errorf("Expected %v, got %v", want, srv.forms[1])
+	}
+
+	if _, _, err := c.TaxTransactions.ListLineItems(ctx, tx.ID, 10, "", ""); err != nil {
+		t.Fatalf("ListLineItems failed: %s", err)
+	}
+
+	if want := []string{"POST /v1/tax/calculations", "POST /v1/tax/transactions/create_from_calculation", "GET /v1/tax/transactions/tax_1/line_items"}; !reflect.DeepEqual(srv.paths, want) {
+		t.Errorf("Expected %v, got %v", want, srv.paths)
+	}
+}
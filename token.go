@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -9,6 +10,7 @@ import (
 //
 // see https://stripe.com/docs/api#token_object
 type Token struct {
+	APIResource
 	ID       string   `json:"id"`
 	Card     *Card    `json:"card"`
 	Created  UnixTime `json:"created"`
@@ -18,7 +20,7 @@ type Token struct {
 
 // TokenClient encapsulates operations for creating and querying tokens using
 // the Stripe REST API.
-type TokenClient struct{}
+type TokenClient struct{ api }
 
 // Creates a single use token that wraps the details of a credit card.
 // This token can be used in place of a credit card hash with any API method.
@@ -26,21 +28,26 @@ type TokenClient struct{}
 // attaching them to a customer.
 //
 // see https://stripe.com/docs/api#create_token
-func (TokenClient) Create(params *CardParams) (*Token, error) {
+func (c TokenClient) Create(ctx context.Context, params *CardParams) (*Token, error) {
 	token := &Token{}
 	values := make(url.Values)
-	appendCardParams(values, true, params)
+	appendForm(values, "card", params)
 
-	err := query("POST", "/tokens", values, token)
+	err := c.query(ctx, "POST", "/tokens", values, token)
 	return token, err
 }
 
 // Retrieves the card token with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_token
-func (TokenClient) Get(id string) (*Token, error) {
-	token := Token{}
+func (c TokenClient) Get(ctx context.Context, id string) (*Token, error) {
+	token := &Token{}
+	return token, c.GetInto(ctx, id, token)
+}
+
+// GetInto is like Get, but decodes the Token into v, which may be any
+// type with matching JSON fields.
+func (c TokenClient) GetInto(ctx context.Context, id string, v interface{}) error {
 	path := "/tokens/" + url.QueryEscape(id)
-	err := query("GET", path, nil, &token)
-	return &token, err
+	return c.query(ctx, "GET", path, nil, v)
 }
@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -31,7 +32,7 @@ var (
 func TestCreateToken(t *testing.T) {
 
 	// Create the token
-	resp, err := Tokens.Create(&token1)
+	resp, err := Tokens.Create(context.Background(), &token1)
 
 	if err != nil {
 		t.Errorf("Expected Token Created, got Error %s", err.Error())
@@ -61,14 +62,14 @@ func TestCreateToken(t *testing.T) {
 // TestCreateToken will test that we can successfully Retrieve a Card Token.
 func TestRetrieveToken(t *testing.T) {
 	// Create the token
-	resp, err := Tokens.Create(&token1)
+	resp, err := Tokens.Create(context.Background(), &token1)
 	if err != nil {
 		t.Errorf("Expected Successful Token, got Error %s", err.Error())
 		return
 	}
 
 	// Retrieve the Token from the database
-	_, err = Tokens.Get(resp.ID)
+	_, err = Tokens.Get(context.Background(), resp.ID)
 	if err != nil {
 		t.Errorf("Expected to retrieve Token by ID, got Error %s", err.Error())
 		return
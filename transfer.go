@@ -0,0 +1,153 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// Transfer represents funds moved from your Stripe balance to a connected
+// account.
+//
+// see https://stripe.com/docs/api#transfer_object
+type Transfer struct {
+	APIResource
+	ID                 string                  `json:"id"`
+	Amount             int64                   `json:"amount"`
+	AmountReversed     int64                   `json:"amount_reversed"`
+	Currency           string                  `json:"currency"`
+	Created            UnixTime                `json:"created"`
+	Description        string                  `json:"description,omitempty"`
+	Destination        string                  `json:"destination"`
+	DestinationPayment string                  `json:"destination_payment,omitempty"`
+	Recipient          string                  `json:"recipient,omitempty"`
+	TransferGroup      string                  `json:"transfer_group,omitempty"`
+	SourceTransaction  string                  `json:"source_transaction,omitempty"`
+	BalanceTransaction string                  `json:"balance_transaction"`
+	Reversed           bool                    `json:"reversed"`
+	Reversals          *List[TransferReversal] `json:"reversals,omitempty"`
+	Livemode           bool                    `json:"livemode"`
+	Metadata           map[string]string       `json:"metadata,omitempty"`
+}
+
+// TransferParams encapsulates options for creating a Transfer.
+type TransferParams struct {
+	// A positive integer in the smallest currency unit representing how much
+	// to transfer.
+	Amount int64 `form:"amount"`
+
+	// 3-letter ISO code for currency.
+	Currency string `form:"currency"`
+
+	// The ID of the connected account to transfer to.
+	Destination string `form:"destination"`
+
+	// (Optional) The ID of a legacy Recipient to transfer to, in place of
+	// Destination.
+	Recipient string `form:"recipient"`
+
+	// (Optional) The ID of a charge whose funds are transferred. The transfer
+	// is made once the charge's funds are available, even if your balance is
+	// not.
+	SourceTransaction string `form:"source_transaction"`
+
+	// (Optional) A string that identifies the transfer as part of a group.
+	TransferGroup string `form:"transfer_group"`
+
+	// (Optional) An arbitrary string to attach to the transfer.
+	Description string `form:"description"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows the request to be safely retried
+	// without transferring the funds twice.
+	IdempotencyKey string
+}
+
+// TransferListParams encapsulates options for filtering a list of Transfers.
+type TransferListParams struct {
+	ListParams
+
+	// (Optional) Only return transfers for the connected account with this
+	// ID.
+	Destination string `form:"destination"`
+
+	// (Optional) Only return transfers with the specified transfer group.
+	TransferGroup string `form:"transfer_group"`
+
+	// (Optional) Only return transfers created within this range.
+	Created *DateRange `form:"created"`
+}
+
+// TransferClient encapsulates operations for querying transfers using the
+// Stripe REST API.
+type TransferClient struct{ api }
+
+// Creates a new Transfer to a connected account.
+//
+// see https://stripe.com/docs/api#create_transfer
+func (c TransferClient) Create(ctx context.Context, params *TransferParams) (*Transfer, error) {
+	values := formValues(params)
+
+	res := &Transfer{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
+	return res, c.query(ctx, "POST", "/transfers", values, res)
+}
+
+// Retrieves the Transfer with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_transfer
+func (c TransferClient) Get(ctx context.Context, id string) (*Transfer, error) {
+	res := &Transfer{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Transfer into v, which may be any type
+// with matching JSON fields.
+func (c TransferClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/transfers/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the description and metadata of a Transfer. Other details of a
+// Transfer cannot be changed once it is created.
+//
+// see https://stripe.com/docs/api#update_transfer
+func (c TransferClient) Update(ctx context.Context, id string, params *TransferParams) (*Transfer, error) {
+	// only the description and metadata can be changed
+	values := formValues(struct {
+		Description string            `form:"description"`
+		Metadata    map[string]string `form:"metadata"`
+	}{params.Description, params.Metadata})
+
+	res := &Transfer{}
+	return res, c.query(ctx, "POST", "/transfers/"+url.QueryEscape(id), values, res)
+}
+
+// Reverse returns amount cents of the Transfer with the given ID to your
+// Stripe balance, or all of what has not been reversed yet if amount is zero.
+// See TransferReversalClient for more options.
+//
+// see https://stripe.com/docs/api#create_transfer_reversal
+func (c TransferClient) Reverse(ctx context.Context, id string, amount int64) (*TransferReversal, error) {
+	return TransferReversalClient{c.api}.Create(ctx, id, &TransferReversalParams{Amount: amount})
+}
+
+// Returns a list of Transfers matching the given filters, or all of your
+// Transfers when params is nil.
+//
+// see https://stripe.com/docs/api#list_transfers
+func (c TransferClient) List(ctx context.Context, params *TransferListParams) ([]*Transfer, bool, error) {
+	res, err := c.ListFiltered(ctx, params)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Transfers matching the given filters, or of all your
+// Transfers when params is nil, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_transfers
+func (c TransferClient) ListFiltered(ctx context.Context, params *TransferListParams) (*List[Transfer], error) {
+	res := &List[Transfer]{}
+	return res, c.query(ctx, "GET", "/transfers", formValues(params), res)
+}
@@ -0,0 +1,105 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TransferReversal represents funds returned to your Stripe balance from a
+// Transfer to a connected account.
+//
+// see https://stripe.com/docs/api#transfer_reversal_object
+type TransferReversal struct {
+	APIResource
+	ID                 string            `json:"id"`
+	Amount             int64             `json:"amount"`
+	Currency           string            `json:"currency"`
+	Created            UnixTime          `json:"created"`
+	Transfer           string            `json:"transfer"`
+	BalanceTransaction string            `json:"balance_transaction"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// TransferReversalParams encapsulates options for reversing a Transfer.
+type TransferReversalParams struct {
+	// (Optional) The amount in cents to reverse. Default is the entire
+	// amount of the Transfer that has not been reversed yet.
+	Amount int64 `form:"amount"`
+
+	// (Optional) An arbitrary string attached to the reversal.
+	Description string `form:"description"`
+
+	// (Optional) Whether to also refund the application fee that was
+	// collected with the Transfer, in proportion to the amount reversed.
+	RefundApplicationFee bool `form:"refund_application_fee"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+
+	// (Optional) A unique key that allows a Create request to be safely
+	// retried without reversing the Transfer twice.
+	IdempotencyKey string
+}
+
+// TransferReversalClient encapsulates operations for reversing transfers
+// using the Stripe REST API.
+type TransferReversalClient struct{ api }
+
+func (c TransferReversalClient) path(transferID, reversalID string) string {
+	p := fmt.Sprintf("/transfers/%s/reversals", url.QueryEscape(transferID))
+	if reversalID != "" {
+		p += "/" + url.QueryEscape(reversalID)
+	}
+	return p
+}
+
+// Reverses all or part of the Transfer with the given ID, returning the
+// funds to your Stripe balance.
+//
+// see https://stripe.com/docs/api#create_transfer_reversal
+func (c TransferReversalClient) Create(ctx context.Context, transferID string, params *TransferReversalParams) (*TransferReversal, error) {
+	if params == nil {
+		params = &TransferReversalParams{}
+	}
+	res := &TransferReversal{}
+	if params.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, params.IdempotencyKey)
+	}
+	return res, c.query(ctx, "POST", c.path(transferID, ""), formValues(params), res)
+}
+
+// Retrieves the reversal with the given ID of a Transfer.
+//
+// see https://stripe.com/docs/api#retrieve_transfer_reversal
+func (c TransferReversalClient) Get(ctx context.Context, transferID, reversalID string) (*TransferReversal, error) {
+	res := &TransferReversal{}
+	return res, c.GetInto(ctx, transferID, reversalID, res)
+}
+
+// GetInto is like Get, but decodes the Transfer Reversal into v, which may be
+// any type with matching JSON fields.
+func (c TransferReversalClient) GetInto(ctx context.Context, transferID, reversalID string, v interface{}) error {
+	return c.query(ctx, "GET", c.path(transferID, reversalID), nil, v)
+}
+
+// Returns a list of the reversals of a Transfer.
+//
+// see https://stripe.com/docs/api#list_transfer_reversals
+func (c TransferReversalClient) List(ctx context.Context, transferID string, limit int, before, after string) ([]*TransferReversal, bool, error) {
+	res := struct {
+		ListObject
+		Data []*TransferReversal
+	}{}
+	err := c.query(ctx, "GET", c.path(transferID, ""), listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of the reversals of the Transfer with the given ID, with
+// the pagination options of params, along with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_transfer_reversals
+func (c TransferReversalClient) ListFiltered(ctx context.Context, transferID string, params *ListParams) (*List[TransferReversal], error) {
+	res := &List[TransferReversal]{}
+	return res, c.query(ctx, "GET", c.path(transferID, ""), formValues(params), res)
+}
@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestUpdateTransfer will test that only the description and metadata of a
+// Transfer are sent when it is updated.
+func TestUpdateTransfer(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "tr_1", "amount": 1000, "destination": "acct_1", "description": "Order 123"}`)
+	})
+	tr, err := c.Transfers.Update(context.Background(), "tr_1", &TransferParams{
+		Amount:      500,
+		Destination: "acct_2",
+		Description: "Order 123",
+		Metadata:    map[string]string{"order": "123"},
+	})
+	if err != nil || tr.Description != "Order 123" {
+		t.Fatalf("Expected updated Transfer, got %v %v", tr, err)
+	}
+	want := url.Values{"description": {"Order 123"}, "metadata[order]": {"123"}}
+	if srv.paths[0] != "POST /v1/transfers/tr_1" || !reflect.DeepEqual(srv.forms[0], want) {
+		t.Errorf("Expected %v sent to tr_1, got %v to %s", want, srv.forms[0], srv.paths[0])
+	}
+
+	if tr, err = c.Transfers.Get(context.Background(), "tr_1"); err != nil || tr.Destination != "acct_1" {
+		t.Errorf("Expected Transfer tr_1, got %v %v", tr, err)
+	}
+}
+
+// TestReverseTransfer will test that a Transfer is reversed in part, and
+// that its reversals are listed.
+func TestReverseTransfer(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"has_more": false, "data": [{"id": "trr_1", "amount": 300, "transfer": "tr_1"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "trr_1", "amount": 300, "transfer": "tr_1"}`)
+	})
+	rev, err := c.Transfers.Reverse(context.Background(), "tr_1", 300)
+	if err != nil || rev.ID != "trr_1" || rev.Transfer != "tr_1" {
+		t.Fatalf("Expected Transfer Reversal trr_1, got %v %v", rev, err)
+	}
+	if srv.paths[0] != "POST /v1/transfers/tr_1/reversals" || srv.forms[0].Get("amount") != "300" {
+		t.Errorf("Expected reversal of 300 for tr_1, got %v to %s", srv.forms[0], srv.paths[0])
+	}
+
+	revs, more, err := c.TransferReversals.List(context.Background(), "tr_1", 10, "", "")
+	if err != nil || more || len(revs) != 1 || revs[0].Amount != 300 {
+		t.Errorf("Expected one Transfer Reversal, got %v %v %v", revs, more, err)
+	}
+}
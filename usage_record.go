@@ -0,0 +1,76 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Usage Record Actions
+const (
+	UsageIncrement = "increment"
+	UsageSet       = "set"
+)
+
+// UsageRecord represents the usage of a metered Subscription Item reported at
+// a point in time.
+//
+// see https://stripe.com/docs/api#usage_record_object
+type UsageRecord struct {
+	APIResource
+	ID               string   `json:"id"`
+	SubscriptionItem string   `json:"subscription_item"`
+	Quantity         int64    `json:"quantity"`
+	Timestamp        UnixTime `json:"timestamp"`
+	Livemode         bool     `json:"livemode"`
+}
+
+// UsageRecordSummary represents the total usage of a metered Subscription
+// Item over a billing period.
+//
+// see https://stripe.com/docs/api#usage_record_summary_object
+type UsageRecordSummary struct {
+	ID               string `json:"id"`
+	SubscriptionItem string `json:"subscription_item"`
+	Invoice          string `json:"invoice,omitempty"`
+	Period           Period `json:"period"`
+	TotalUsage       int64  `json:"total_usage"`
+	Livemode         bool   `json:"livemode"`
+}
+
+// UsageRecordClient encapsulates operations for reporting and summarizing
+// the usage of metered subscription items using the Stripe REST API.
+type UsageRecordClient struct{ api }
+
+// Reports quantity units of usage of the Subscription Item with the given ID
+// at the given time. The action must be one of increment, to add to any usage
+// already reported at that time, or set, to replace it.
+//
+// see https://stripe.com/docs/api#usage_record_create
+func (c UsageRecordClient) Create(ctx context.Context, subscriptionItemID string, quantity int64, timestamp UnixTime, action string) (*UsageRecord, error) {
+	values := url.Values{
+		"quantity":  {strconv.FormatInt(quantity, 10)},
+		"timestamp": {strconv.FormatInt(timestamp.Unix(), 10)},
+	}
+	if action != "" {
+		values.Set("action", action)
+	}
+	res := &UsageRecord{}
+	path := fmt.Sprintf("/subscription_items/%s/usage_records", url.QueryEscape(subscriptionItemID))
+	return res, c.query(ctx, "POST", path, values, res)
+}
+
+// Returns a list of the usage totals of the Subscription Item with the given
+// ID for each billing period, newest first.
+//
+// see https://stripe.com/docs/api#usage_record_summary_list
+func (c UsageRecordClient) ListSummaries(ctx context.Context, subscriptionItemID string, limit int, before, after string) ([]*UsageRecordSummary, bool, error) {
+	res := struct {
+		ListObject
+		Data []*UsageRecordSummary
+	}{}
+	path := fmt.Sprintf("/subscription_items/%s/usage_record_summaries", url.QueryEscape(subscriptionItemID))
+	err := c.query(ctx, "GET", path, listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
@@ -0,0 +1,279 @@
+// Package webhook receives Stripe webhook events over HTTP, verifying their
+// signatures before handing them to a Publisher or to callbacks registered
+// for each event type. ConstructEvent verifies and parses a single event for
+// programs that serve webhooks themselves.
+//
+// see https://stripe.com/docs/webhooks
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cupcake/stripe"
+)
+
+// DefaultTolerance is the maximum age of a signed event that is accepted, if
+// the Handler does not set its own.
+const DefaultTolerance = 5 * time.Minute
+
+// the maximum size of an event payload that is read
+const maxPayload = 1 << 20
+
+// Errors returned when an event cannot be verified.
+var (
+	ErrInvalidHeader = errors.New("webhook: invalid Stripe-Signature header")
+	ErrNoSignature   = errors.New("webhook: no signature matches the payload")
+	ErrTooOld        = errors.New("webhook: event timestamp is outside the tolerance")
+)
+
+// Publisher receives verified events from a Handler. Implementations would
+// typically write the raw payload to a durable queue (ie SQS or Kafka) for
+// later processing, and must only return nil once it is safely stored, since
+// Stripe will not retry an event the Handler acknowledged.
+type Publisher interface {
+	Publish(ctx context.Context, event *stripe.Event, payload []byte) error
+}
+
+// PublisherFunc adapts an ordinary function to the Publisher interface.
+type PublisherFunc func(ctx context.Context, event *stripe.Event, payload []byte) error
+
+func (f PublisherFunc) Publish(ctx context.Context, event *stripe.Event, payload []byte) error {
+	return f(ctx, event, payload)
+}
+
+// ChannelPublisher is a Publisher that sends events on a channel, for
+// processing in the same process. Publish blocks until the event is received
+// or the request is canceled, so a buffered channel is recommended.
+type ChannelPublisher chan *stripe.Event
+
+func (c ChannelPublisher) Publish(ctx context.Context, event *stripe.Event, payload []byte) error {
+	select {
+	case c <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Handler is an http.Handler that verifies the signature of each webhook
+// event, passes it to the Publisher, if any, and then to the callbacks
+// registered for its type. It responds with 400 Bad Request if the event
+// cannot be verified or parsed, 500 Internal Server Error if the Publisher or
+// a callback fails, so that Stripe retries, and 200 OK otherwise, including
+// for events without a callback.
+type Handler struct {
+	// The signing secret of the webhook endpoint.
+	Secret string
+
+	// (Optional) The maximum age of an event. Default is DefaultTolerance.
+	Tolerance time.Duration
+
+	// (Optional) The Publisher to send verified events to.
+	Publisher Publisher
+
+	// the callbacks registered for each event type
+	callbacks map[string][]func(*stripe.Event) error
+}
+
+// On registers f to be called with every event of the given type, ie
+// stripe.EventChargeSucceeded. Callbacks must not be registered while the
+// Handler is serving requests.
+func (h *Handler) On(eventType string, f func(*stripe.Event) error) {
+	if h.callbacks == nil {
+		h.callbacks = make(map[string][]func(*stripe.Event) error)
+	}
+	h.callbacks[eventType] = append(h.callbacks[eventType], f)
+}
+
+// OnChargeSucceeded registers f to be called with the Charge of every
+// charge.succeeded event.
+func (h *Handler) OnChargeSucceeded(f func(*stripe.Charge) error) {
+	h.On(stripe.EventChargeSucceeded, object(f))
+}
+
+// OnChargeFailed registers f to be called with the Charge of every
+// charge.failed event.
+func (h *Handler) OnChargeFailed(f func(*stripe.Charge) error) {
+	h.On(stripe.EventChargeFailed, object(f))
+}
+
+// OnChargeRefunded registers f to be called with the Charge of every
+// charge.refunded event.
+func (h *Handler) OnChargeRefunded(f func(*stripe.Charge) error) {
+	h.On(stripe.EventChargeRefunded, object(f))
+}
+
+// OnCustomerSubscriptionDeleted registers f to be called with the
+// Subscription of every customer.subscription.deleted event.
+func (h *Handler) OnCustomerSubscriptionDeleted(f func(*stripe.Subscription) error) {
+	h.On(stripe.EventCustomerSubscriptionDeleted, object(f))
+}
+
+// OnInvoicePaymentSucceeded registers f to be called with the Invoice of
+// every invoice.payment_succeeded event.
+func (h *Handler) OnInvoicePaymentSucceeded(f func(*stripe.Invoice) error) {
+	h.On(stripe.EventInvoicePaymentSucceeded, object(f))
+}
+
+// OnInvoicePaymentFailed registers f to be called with the Invoice of every
+// invoice.payment_failed event.
+func (h *Handler) OnInvoicePaymentFailed(f func(*stripe.Invoice) error) {
+	h.On(stripe.EventInvoicePaymentFailed, object(f))
+}
+
+// objectError is returned by a callback when the object of an event cannot
+// be decoded, which Stripe retrying would not fix.
+type objectError struct{ err error }
+
+func (e *objectError) Error() string {
+	return "webhook: invalid event object: " + e.err.Error()
+}
+
+// object returns a callback that decodes the object of an event into a T
+// and calls f with it.
+func object[T any](f func(*T) error) func(*stripe.Event) error {
+	return func(event *stripe.Event) error {
+		v := new(T)
+		if event.Data == nil {
+			return &objectError{errors.New("no data")}
+		}
+		if err := event.GetObject(v); err != nil {
+			return &objectError{err}
+		}
+		return f(v)
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayload))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tolerance := h.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+	event, err := constructEvent(payload, r.Header.Get("Stripe-Signature"), h.Secret, tolerance, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if h.Publisher != nil {
+		if err := h.Publisher.Publish(r.Context(), event, payload); err != nil {
+			http.Error(w, "failed to publish event", http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, f := range h.callbacks[event.Type] {
+		var bad *objectError
+		if err := f(event); errors.As(err, &bad) {
+			http.Error(w, bad.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConstructEvent verifies that payload, the raw body of a webhook request,
+// was signed by Stripe with the signing secret of the endpoint, and parses the
+// event. sigHeader is the Stripe-Signature header of the request. Events
+// signed more than DefaultTolerance ago, or as far in the future, are
+// rejected with ErrTooOld to prevent replay attacks.
+//
+// see https://stripe.com/docs/webhooks/signatures
+func ConstructEvent(payload []byte, sigHeader, secret string) (*stripe.Event, error) {
+	return constructEvent(payload, sigHeader, secret, DefaultTolerance, time.Now())
+}
+
+// ConstructEventWithTolerance is like ConstructEvent, but rejects events
+// signed more than tolerance ago instead.
+func ConstructEventWithTolerance(payload []byte, sigHeader, secret string, tolerance time.Duration) (*stripe.Event, error) {
+	return constructEvent(payload, sigHeader, secret, tolerance, time.Now())
+}
+
+// constructEvent verifies the signature header of payload against secret and
+// parses the event.
+func constructEvent(payload []byte, header, secret string, tolerance time.Duration, now time.Time) (*stripe.Event, error) {
+	timestamp, signatures, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	expected := sign(payload, secret, timestamp)
+	valid := false
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrNoSignature
+	}
+	if age := now.Sub(timestamp); age > tolerance || age < -tolerance {
+		return nil, ErrTooOld
+	}
+
+	event := &stripe.Event{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// parseHeader parses a Stripe-Signature header of the form
+// t=<timestamp>,v1=<signature>,v1=<signature>.
+func parseHeader(header string) (time.Time, [][]byte, error) {
+	var timestamp time.Time
+	var signatures [][]byte
+	for _, pair := range strings.Split(header, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return timestamp, nil, ErrInvalidHeader
+		}
+		switch parts[0] {
+		case "t":
+			sec, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				return timestamp, nil, ErrInvalidHeader
+			}
+			timestamp = time.Unix(sec, 0)
+		case "v1":
+			sig, err := hex.DecodeString(parts[1])
+			if err != nil {
+				continue
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+	if timestamp.IsZero() || len(signatures) == 0 {
+		return timestamp, nil, ErrInvalidHeader
+	}
+	return timestamp, signatures, nil
+}
+
+// sign computes the v1 signature of payload sent at timestamp.
+func sign(payload []byte, secret string, timestamp time.Time) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
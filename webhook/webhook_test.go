@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cupcake/stripe"
+)
+
+const secret = "whsec_test"
+
+var payload = []byte(`{"id": "evt_1", "type": "charge.succeeded", "data": {"object": {"id": "ch_1", "amount": 500}}}`)
+
+func signedRequest(payload []byte, secret string, t time.Time) *http.Request {
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(string(payload)))
+	sig := hex.EncodeToString(sign(payload, secret, t))
+	r.Header.Set("Stripe-Signature", fmt.Sprintf("t=%d,v1=%s", t.Unix(), sig))
+	return r
+}
+
+// TestHandler will test that verified events are published, and that invalid
+// events and publishing failures are reported to Stripe.
+func TestHandler(t *testing.T) {
+	events := make(ChannelPublisher, 1)
+	h := &Handler{Secret: secret, Publisher: events}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(payload, secret, time.Now()))
+	if w.Code != 200 {
+		t.Fatalf("Expected 200, got %d %s", w.Code, w.Body)
+	}
+	event := <-events
+	charge := &stripe.Charge{}
+	if err := event.GetObject(charge); err != nil || charge.ID != "ch_1" {
+		t.Errorf("Expected charge ch_1 in event, got %v %v", charge.ID, err)
+	}
+
+	tests := []*http.Request{
+		signedRequest(payload, "whsec_wrong", time.Now()),
+		signedRequest(payload, secret, time.Now().Add(-time.Hour)),
+		httptest.NewRequest("POST", "/webhook", strings.NewReader(string(payload))),
+	}
+	for i, r := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != 400 {
+			t.Errorf("Expected 400 for request %d, got %d", i, w.Code)
+		}
+	}
+
+	h.Publisher = PublisherFunc(func(ctx context.Context, e *stripe.Event, p []byte) error {
+		return errors.New("queue unavailable")
+	})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(payload, secret, time.Now()))
+	if w.Code != 500 {
+		t.Errorf("Expected 500 when publishing fails, got %d", w.Code)
+	}
+}
+
+// TestConstructEvent will test that a signed payload is parsed, and that
+// payloads with a wrong, missing or stale signature are rejected.
+func TestConstructEvent(t *testing.T) {
+	header := func(secret string, ts time.Time) string {
+		return signedRequest(payload, secret, ts).Header.Get("Stripe-Signature")
+	}
+
+	event, err := ConstructEvent(payload, header(secret, time.Now()), secret)
+	if err != nil || event.ID != "evt_1" || event.Type != "charge.succeeded" {
+		t.Errorf("Expected event evt_1, got %v %v", event, err)
+	}
+
+	tests := []struct {
+		header string
+		err    error
+	}{
+		{header("whsec_wrong", time.Now()), ErrNoSignature},
+		{header(secret, time.Now().Add(-time.Hour)), ErrTooOld},
+		{header(secret, time.Now().Add(time.Hour)), ErrTooOld},
+		{"", ErrInvalidHeader},
+		{"t=abc,v1=00", ErrInvalidHeader},
+	}
+	for i, test := range tests {
+		if _, err := ConstructEvent(payload, test.header, secret); err != test.err {
+			t.Errorf("Expected %v for header %d, got %v", test.err, i, err)
+		}
+	}
+
+	old := header(secret, time.Now().Add(-time.Hour))
+	if _, err := ConstructEventWithTolerance(payload, old, secret, 2*time.Hour); err != nil {
+		t.Errorf("Expected event within a longer tolerance, got %v", err)
+	}
+}
+
+// TestHandlerCallbacks will test that events are routed to the callbacks for
+// their type with their decoded object, and that failing callbacks are
+// reported to Stripe.
+func TestHandlerCallbacks(t *testing.T) {
+	h := &Handler{Secret: secret}
+	var charges []string
+	h.OnChargeSucceeded(func(c *stripe.Charge) error {
+		charges = append(charges, c.ID)
+		return nil
+	})
+	failed := false
+	h.OnInvoicePaymentFailed(func(inv *stripe.Invoice) error {
+		failed = true
+		return errors.New("database unavailable")
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(payload, secret, time.Now()))
+	if w.Code != 200 || fmt.Sprint(charges) != "[ch_1]" {
+		t.Errorf("Expected 200 and charge ch_1, got %d %v", w.Code, charges)
+	}
+
+	// events without a callback are acknowledged
+	other := []byte(`{"id": "evt_2", "type": "customer.created", "data": {"object": {"id": "cus_1"}}}`)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(other, secret, time.Now()))
+	if w.Code != 200 {
+		t.Errorf("Expected 200 for event without callback, got %d", w.Code)
+	}
+
+	invoice := []byte(`{"id": "evt_3", "type": "invoice.payment_failed", "data": {"object": {"id": "in_1"}}}`)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(invoice, secret, time.Now()))
+	if w.Code != 500 || !failed {
+		t.Errorf("Expected 500 when the callback fails, got %d", w.Code)
+	}
+
+	bad := []byte(`{"id": "evt_4", "type": "charge.succeeded", "data": {"object": "ch_1"}}`)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(bad, secret, time.Now()))
+	if w.Code != 400 {
+		t.Errorf("Expected 400 for an invalid object, got %d", w.Code)
+	}
+}
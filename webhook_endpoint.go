@@ -0,0 +1,127 @@
+package stripe
+
+import (
+	"context"
+	"net/url"
+)
+
+// Webhook Endpoint Statuses.
+const (
+	WebhookEndpointEnabled  = "enabled"
+	WebhookEndpointDisabled = "disabled"
+)
+
+// WebhookEndpoint represents a URL that Stripe sends events to.
+//
+// see https://stripe.com/docs/api#webhook_endpoint_object
+type WebhookEndpoint struct {
+	APIResource
+	ID            string            `json:"id"`
+	URL           string            `json:"url"`
+	EnabledEvents []string          `json:"enabled_events"`
+	Status        string            `json:"status"`
+	Description   string            `json:"description,omitempty"`
+	APIVersion    string            `json:"api_version,omitempty"`
+	Application   string            `json:"application,omitempty"`
+	Created       UnixTime          `json:"created"`
+	Livemode      bool              `json:"livemode"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+
+	// The signing secret of the endpoint, used to verify the events sent to
+	// it (see the webhook package). Only returned when the endpoint is
+	// created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookEndpointParams encapsulates options for creating and updating
+// Webhook Endpoints.
+type WebhookEndpointParams struct {
+	// The URL of the endpoint.
+	URL string `form:"url"`
+
+	// The types of events sent to the endpoint, ie charge.succeeded, or *
+	// for every type.
+	EnabledEvents []string `form:"enabled_events"`
+
+	// (Optional) A description of what the endpoint is used for.
+	Description string `form:"description"`
+
+	// (Optional) The API version events are sent in. Default is the version
+	// of the account. Can only be set when the endpoint is created.
+	APIVersion string `form:"api_version"`
+
+	// (Optional) Whether to disable the endpoint. Can only be set when the
+	// endpoint is updated.
+	Disabled *bool `form:"disabled"`
+
+	// (Optional) Metadata.
+	Metadata map[string]string `form:"metadata"`
+}
+
+// WebhookEndpointClient encapsulates operations for registering and managing
+// webhook endpoints using the Stripe REST API.
+type WebhookEndpointClient struct{ api }
+
+// Creates a new Webhook Endpoint. The signing secret of the endpoint is only
+// returned by this call.
+//
+// see https://stripe.com/docs/api#create_webhook_endpoint
+func (c WebhookEndpointClient) Create(ctx context.Context, params *WebhookEndpointParams) (*WebhookEndpoint, error) {
+	res := &WebhookEndpoint{}
+	return res, c.query(ctx, "POST", "/webhook_endpoints", formValues(params), res)
+}
+
+// Retrieves the Webhook Endpoint with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_webhook_endpoint
+func (c WebhookEndpointClient) Get(ctx context.Context, id string) (*WebhookEndpoint, error) {
+	res := &WebhookEndpoint{}
+	return res, c.GetInto(ctx, id, res)
+}
+
+// GetInto is like Get, but decodes the Webhook Endpoint into v, which may be
+// any type with matching JSON fields.
+func (c WebhookEndpointClient) GetInto(ctx context.Context, id string, v interface{}) error {
+	return c.query(ctx, "GET", "/webhook_endpoints/"+url.QueryEscape(id), nil, v)
+}
+
+// Updates the URL, event types, description or metadata of a Webhook
+// Endpoint, or disables it.
+//
+// see https://stripe.com/docs/api#update_webhook_endpoint
+func (c WebhookEndpointClient) Update(ctx context.Context, id string, params *WebhookEndpointParams) (*WebhookEndpoint, error) {
+	res := &WebhookEndpoint{}
+	return res, c.query(ctx, "POST", "/webhook_endpoints/"+url.QueryEscape(id), formValues(params), res)
+}
+
+// Deletes the Webhook Endpoint with the given ID.
+//
+// see https://stripe.com/docs/api#delete_webhook_endpoint
+func (c WebhookEndpointClient) Delete(ctx context.Context, id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := c.query(ctx, "DELETE", "/webhook_endpoints/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Webhook Endpoints.
+//
+// see https://stripe.com/docs/api#list_webhook_endpoints
+func (c WebhookEndpointClient) List(ctx context.Context, limit int, before, after string) ([]*WebhookEndpoint, bool, error) {
+	res := struct {
+		ListObject
+		Data []*WebhookEndpoint
+	}{}
+	err := c.query(ctx, "GET", "/webhook_endpoints", listParams(limit, before, after), &res)
+	return res.Data, res.More, err
+}
+
+// Returns a page of Webhook Endpoints, with the pagination options of params, along
+// with the metadata of the list.
+//
+// see https://stripe.com/docs/api#list_webhook_endpoints
+func (c WebhookEndpointClient) ListFiltered(ctx context.Context, params *ListParams) (*List[WebhookEndpoint], error) {
+	res := &List[WebhookEndpoint]{}
+	return res, c.query(ctx, "GET", "/webhook_endpoints", formValues(params), res)
+}
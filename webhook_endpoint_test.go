@@ -0,0 +1,29 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestCreateWebhookEndpoint will test that a Webhook Endpoint is created with
+// its URL and event types, and that its signing secret is returned.
+func TestCreateWebhookEndpoint(t *testing.T) {
+	srv, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "we_1", "url": "https://example.com/hook", "enabled_events": ["charge.succeeded", "charge.failed"], "status": "enabled", "secret": "whsec_1"}`)
+	})
+	endpoint, err := c.WebhookEndpoints.Create(context.Background(), &WebhookEndpointParams{
+		URL:           "https://example.com/hook",
+		EnabledEvents: []string{EventChargeSucceeded, EventChargeFailed},
+	})
+	if err != nil {
+		t.Fatalf("Expected Webhook Endpoint, got Error %s", err.Error())
+	}
+	if endpoint.Secret != "whsec_1" || endpoint.Status != WebhookEndpointEnabled || len(endpoint.EnabledEvents) != 2 {
+		t.Errorf("Expected enabled endpoint with its secret, got %+v", endpoint)
+	}
+	if srv.forms[0].Get("url") != "https://example.com/hook" || srv.forms[0].Get("enabled_events[0]") != "charge.succeeded" || srv.forms[0].Get("enabled_events[1]") != "charge.failed" {
+		t.Errorf("Expected URL and event types to be sent, got %v", srv.forms[0])
+	}
+}